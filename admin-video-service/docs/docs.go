@@ -50,6 +50,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "video:manage 권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "요청 처리 실패시 오류 메시지 반환",
                         "schema": {
@@ -101,6 +107,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "video:manage 권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "요청 처리 실패시 오류 메시지 반환",
                         "schema": {
@@ -134,10 +146,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dto.VideoData"
                         }
                     }
                 ],
@@ -154,6 +163,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "video:manage 권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "TOO_MANY_REQUESTS - 같은 사용자의 요청이 처리중",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "요청 처리 실패시 오류 메시지 반환",
                         "schema": {
@@ -176,8 +203,37 @@ const docTemplate = `{
         "dto.ErrorResponse": {
             "type": "object",
             "properties": {
-                "err": {
-                    "type": "string"
+                "code": {
+                    "description": "INVALID_REQUEST(400), UNAUTHORIZED(401), FORBIDDEN(403), NOT_FOUND(404), CONFLICT(409), TOO_MANY_REQUESTS(429), DATABASE_ERROR(500), INTERNAL_ERROR(500)",
+                    "type": "string",
+                    "example": "INVALID_REQUEST"
+                },
+                "detail": {
+                    "description": "원인 (디버깅용, 변경될 수 있음)",
+                    "type": "string",
+                    "example": "invalid date format, should be YYYY-MM-DD"
+                },
+                "message": {
+                    "description": "사용자 안내 문구",
+                    "type": "string",
+                    "example": "요청 값이 올바르지 않아요."
+                }
+            }
+        },
+        "dto.VideoData": {
+            "type": "object",
+            "properties": {
+                "deselectedVideos": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "selectedVideos": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
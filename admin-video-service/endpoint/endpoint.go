@@ -11,8 +11,7 @@ import (
 
 func GetVimeoLevel1sEndpoint(s service.AdminVideoService) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
-		id := request.(uint)
-		level1s, err := s.GetLevel1s(id)
+		level1s, err := s.GetLevel1s()
 		if err != nil {
 			return dto.BasicResponse{Code: err.Error()}, err
 		}
@@ -22,10 +21,8 @@ func GetVimeoLevel1sEndpoint(s service.AdminVideoService) endpoint.Endpoint {
 
 func GetVimeoLevel2sEndpoint(s service.AdminVideoService) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
-		reqMap := request.(map[string]interface{})
-		id := reqMap["id"].(uint)
-		projectId := reqMap["projectId"].(string)
-		level1s, err := s.GetLevel2s(id, projectId)
+		projectId := request.(string)
+		level1s, err := s.GetLevel2s(projectId)
 		if err != nil {
 			return dto.BasicResponse{Code: err.Error()}, err
 		}
@@ -4,6 +4,7 @@ go 1.21.5
 
 require (
 	github.com/dgrijalva/jwt-go v3.2.0+incompatible
+	github.com/disterbia/wellkinson/common v1.0.0
 	github.com/gin-contrib/cors v1.5.0
 	github.com/gin-gonic/gin v1.9.1
 	github.com/go-kit/kit v0.13.0
@@ -60,3 +61,6 @@ require (
 	gopkg.in/yaml.v2 v2.4.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+// 로컬 공통 모듈 사용 (go.work 밖에서 빌드할때, Docker 는 저장소 루트를 context 로 빌드)
+replace github.com/disterbia/wellkinson/common => ../common
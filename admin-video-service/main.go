@@ -10,6 +10,7 @@ import (
 	"log"
 	"os"
 
+	"github.com/disterbia/wellkinson/common/util"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
@@ -46,9 +47,10 @@ func main() {
 	}
 	router.Use(cors.New(config))
 
-	router.GET("/get-items", transport.GetVimeoLevel1sHandler(getVimeoLevel1sEndpoint))
-	router.GET("/get-videos/:id", transport.GetVimeoLevel2sHandler(getVimeoLevel2sEndpoint))
-	router.POST("/save-videos", transport.SaveHandler(saveEndpoint))
+	videoManager := util.RequirePermission(util.PermVideoManage)
+	router.GET("/get-items", videoManager, transport.GetVimeoLevel1sHandler(getVimeoLevel1sEndpoint))
+	router.GET("/get-videos/:id", videoManager, transport.GetVimeoLevel2sHandler(getVimeoLevel2sEndpoint))
+	router.POST("/save-videos", videoManager, transport.SaveHandler(saveEndpoint))
 
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 	router.Run(":44400")
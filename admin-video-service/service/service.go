@@ -2,22 +2,22 @@
 package service
 
 import (
-	"admin-video-service/common/model"
 	"admin-video-service/dto"
 	"encoding/json"
-	"errors"
 	"io"
 	"log"
 	"net/http"
 	"strings"
 	"sync"
 
+	"github.com/disterbia/wellkinson/common/model"
+	"github.com/disterbia/wellkinson/common/util"
 	"gorm.io/gorm"
 )
 
 type AdminVideoService interface {
-	GetLevel1s(id uint) ([]dto.VimeoLevel1, error)
-	GetLevel2s(id uint, projectId string) ([]dto.VimeoLevel2, error)
+	GetLevel1s() ([]dto.VimeoLevel1, error)
+	GetLevel2s(projectId string) ([]dto.VimeoLevel2, error)
 	SaveVideos(videoData dto.VideoData) (string, error)
 }
 
@@ -28,17 +28,9 @@ type adminVideoService struct {
 func NewAdminVideoService(db *gorm.DB) AdminVideoService {
 	return &adminVideoService{db: db}
 }
-func (service *adminVideoService) GetLevel1s(id uint) ([]dto.VimeoLevel1, error) {
-	var user model.User
-	err := service.db.Where("id = ?", id).Find(&user).Error
-	if err != nil {
-		return nil, errors.New("db error")
-	}
-
-	if !user.IsAdmin {
-		return nil, errors.New("deny")
-	}
 
+// 권한 검사는 라우트의 RequirePermission(video:manage) 에서 처리
+func (service *adminVideoService) GetLevel1s() ([]dto.VimeoLevel1, error) {
 	apiURL := "https://api.vimeo.com/users/145953562/projects/14798949/items"
 
 	// HTTP 클라이언트 생성
@@ -91,17 +83,7 @@ func (service *adminVideoService) GetLevel1s(id uint) ([]dto.VimeoLevel1, error)
 	return vimeoData, nil // 결과 반환
 }
 
-func (service *adminVideoService) GetLevel2s(id uint, projectId string) ([]dto.VimeoLevel2, error) {
-	var user model.User
-	err := service.db.Where("id = ?", id).Find(&user).Error
-	if err != nil {
-		return nil, errors.New("db error")
-	}
-
-	if !user.IsAdmin {
-		return nil, errors.New("deny")
-	}
-
+func (service *adminVideoService) GetLevel2s(projectId string) ([]dto.VimeoLevel2, error) {
 	apiURL := "https://api.vimeo.com/users/145953562/projects/" + projectId + "/videos"
 
 	// HTTP 클라이언트 생성
@@ -140,7 +122,7 @@ func (service *adminVideoService) GetLevel2s(id uint, projectId string) ([]dto.V
 	var videos []model.Video
 	err = service.db.Where("project_id = ?", projectId).Find(&videos).Error
 	if err != nil {
-		return nil, errors.New("db error")
+		return nil, util.NewError(util.ErrDatabase, "db error")
 	}
 
 	var vimeoData []dto.VimeoLevel2
@@ -166,16 +148,6 @@ func (service *adminVideoService) GetLevel2s(id uint, projectId string) ([]dto.V
 }
 
 func (service *adminVideoService) SaveVideos(videoData dto.VideoData) (string, error) {
-	var user model.User
-	err := service.db.Where("id = ?", videoData.Id).Find(&user).Error
-	if err != nil {
-		return "", errors.New("db error")
-	}
-
-	if !user.IsAdmin {
-		return "", errors.New("deny")
-	}
-
 	selectedVideos := videoData.SelectedVideos
 	deselectedVideos := videoData.DeselectedVideos
 
@@ -260,12 +232,12 @@ func (service *adminVideoService) SaveVideos(videoData dto.VideoData) (string, e
 
 	if len(proIds) > 0 {
 		if err := service.db.Where("project_id IN ?", proIds).Delete(&model.Video{}).Error; err != nil {
-			return "", errors.New("db error2")
+			return "", util.NewError(util.ErrDatabase, "db error2")
 		}
 
 		if len(videos) > 0 {
 			if err := service.db.Create(&videos).Error; err != nil {
-				return "", errors.New("db error3")
+				return "", util.NewError(util.ErrDatabase, "db error3")
 			}
 		}
 	}
@@ -273,7 +245,7 @@ func (service *adminVideoService) SaveVideos(videoData dto.VideoData) (string, e
 	// 해제된 비디오 처리
 	if len(deselectedVideos) > 0 {
 		if err := service.db.Where("video_id IN ?", deselectedVideos).Delete(&model.Video{}).Error; err != nil {
-			return "", errors.New("db error4")
+			return "", util.NewError(util.ErrDatabase, "db error4")
 		}
 	}
 
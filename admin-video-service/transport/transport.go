@@ -2,11 +2,11 @@
 package transport
 
 import (
-	"admin-video-service/common/util"
 	"admin-video-service/dto"
 	"net/http"
 	"sync"
 
+	"github.com/disterbia/wellkinson/common/util"
 	"github.com/gin-gonic/gin"
 	kitEndpoint "github.com/go-kit/kit/endpoint"
 )
@@ -20,19 +20,14 @@ var userLocks sync.Map
 // @Param Authorization header string true "Bearer {jwt_token}"
 // @Success 200 {object} []dto.VimeoLevel1 "웰킨스 폴더 내용"
 // @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 403 {object} dto.ErrorResponse "video:manage 권한 없음"
 // @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
 // @Router /get-items [get]
 func GetVimeoLevel1sHandler(getEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		id, _, err := util.VerifyJWT(c)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-			return
-		}
-
-		response, err := getEndpoint(c.Request.Context(), id)
+		response, err := getEndpoint(c.Request.Context(), nil)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, err)
 			return
 		}
 
@@ -49,22 +44,15 @@ func GetVimeoLevel1sHandler(getEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 // @Param id path string true "id"
 // @Success 200 {object} []dto.VimeoLevel2 "해당 폴더 내용"
 // @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 403 {object} dto.ErrorResponse "video:manage 권한 없음"
 // @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
 // @Router /get-items/{id} [get]
 func GetVimeoLevel2sHandler(getEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		id, _, err := util.VerifyJWT(c)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-			return
-		}
 		projectId := c.Param("id")
-		response, err := getEndpoint(c.Request.Context(), map[string]interface{}{
-			"id":        id,
-			"projectId": projectId,
-		})
+		response, err := getEndpoint(c.Request.Context(), projectId)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, err)
 			return
 		}
 
@@ -81,24 +69,27 @@ func GetVimeoLevel2sHandler(getEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 // @Param request body dto.VideoData true "활성화 할 id 배열"
 // @Success 200 {object} dto.BasicResponse "성공시 200 반환"
 // @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 403 {object} dto.ErrorResponse "video:manage 권한 없음"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
+// @Failure 429 {object} dto.ErrorResponse "TOO_MANY_REQUESTS - 같은 사용자의 요청이 처리중"
 // @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
 // @Router /save-videos/{id} [post]
 func SaveHandler(saveEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		id, _, err := util.VerifyJWT(c)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
 			return
 		}
 		var videoData dto.VideoData // 삭제할 ID 배열
 		if err := c.ShouldBindJSON(&videoData); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			util.AbortBadRequest(c, err)
 			return
 		}
 
 		// 사용자별 잠금 시작
 		if _, loaded := userLocks.LoadOrStore(id, true); loaded {
-			c.JSON(http.StatusTooManyRequests, gin.H{"error": "Concurrent request detected"})
+			util.AbortError(c, util.NewError(util.ErrTooManyRequests, "concurrent request detected"))
 			return
 		}
 		defer userLocks.Delete(id)
@@ -106,7 +97,7 @@ func SaveHandler(saveEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 		videoData.Id = id
 		response, err := saveEndpoint(c.Request.Context(), videoData)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, err)
 			return
 		}
 
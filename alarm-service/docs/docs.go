@@ -40,7 +40,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/dto.AlarmResponse"
+                                "$ref": "#/definitions/dto.NotificationResponse"
                             }
                         }
                     },
@@ -50,6 +50,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "요청 처리 실패시 오류 메시지 반환",
                         "schema": {
@@ -100,6 +106,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "요청 처리 실패시 오류 메시지 반환",
                         "schema": {
@@ -109,6 +121,83 @@ const docTemplate = `{
                 }
             }
         },
+        "/notification-action": {
+            "post": {
+                "description": "푸시 알림의 확인/다시 알림/복용 버튼을 눌렀을때 호출 - type, parent_id, alarm_id, scheduled_at 은 알림 data 값 그대로 전달\naction- ack: 확인 (약 알람은 복용 처리) snooze: minutes 분 뒤 다시 알림 (기본 10분, 최대 120분) taken: 복용 (약 알람만)\n보호자에게 온 미복용 알림(escalation)은 patient_uid 를 함께 보내면 약 쓰기 권한이 있을때 환자 대신 복용 처리 (ack, taken 만 가능)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "알람 /alarm"
+                ],
+                "summary": "알림 액션",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {jwt_token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "요청 DTO",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.NotificationActionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "성공시 200 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BasicResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "FORBIDDEN - 환자의 약 쓰기 권한이 없는 보호자",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "NOT_FOUND - 알람 또는 약이 없거나 삭제됨",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "SERVICE_UNAVAILABLE - 복용 처리 서비스 연결 실패",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/read-notis": {
             "post": {
                 "description": "수신 알림 조회시 자동 읽음 처리라면 수신 알림 조회완료 후 함께 호출",
@@ -144,6 +233,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "요청 처리 실패시 오류 메시지 반환",
                         "schema": {
@@ -200,6 +295,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "요청 처리 실패시 오류 메시지 반환",
                         "schema": {
@@ -256,6 +357,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "요청 처리 실패시 오류 메시지 반환",
                         "schema": {
@@ -309,6 +416,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "TOO_MANY_REQUESTS - 같은 사용자의 요청이 처리중",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "요청 처리 실패시 오류 메시지 반환",
                         "schema": {
@@ -371,6 +490,10 @@ const docTemplate = `{
                 "id": {
                     "type": "integer"
                 },
+                "next_fire_at": {
+                    "type": "string",
+                    "example": "yyyy-mm-ddTHH:mmZ (UTC)"
+                },
                 "start_at": {
                     "type": "string",
                     "example": "yyyy-mm-dd"
@@ -405,8 +528,83 @@ const docTemplate = `{
         "dto.ErrorResponse": {
             "type": "object",
             "properties": {
-                "err": {
-                    "type": "string"
+                "code": {
+                    "description": "INVALID_REQUEST(400), UNAUTHORIZED(401), FORBIDDEN(403), NOT_FOUND(404), CONFLICT(409), TOO_MANY_REQUESTS(429), DATABASE_ERROR(500), INTERNAL_ERROR(500)",
+                    "type": "string",
+                    "example": "INVALID_REQUEST"
+                },
+                "detail": {
+                    "description": "원인 (디버깅용, 변경될 수 있음)",
+                    "type": "string",
+                    "example": "invalid date format, should be YYYY-MM-DD"
+                },
+                "message": {
+                    "description": "사용자 안내 문구",
+                    "type": "string",
+                    "example": "요청 값이 올바르지 않아요."
+                }
+            }
+        },
+        "dto.NotificationActionRequest": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "ack,snooze,taken"
+                },
+                "alarm_id": {
+                    "type": "integer"
+                },
+                "minutes": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "parent_id": {
+                    "type": "integer"
+                },
+                "patient_uid": {
+                    "description": "보호자에게 온 미복용 알림이면 알림 data 의 patient_uid",
+                    "type": "integer"
+                },
+                "scheduled_at": {
+                    "type": "string",
+                    "example": "알림 data 의 scheduled_at (RFC3339)"
+                },
+                "type": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.NotificationResponse": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "example": "알람내용"
+                },
+                "created": {
+                    "type": "string",
+                    "example": "YYYY-mm-ddTHH:mm:ss "
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_read": {
+                    "type": "boolean"
+                },
+                "parent_id": {
+                    "type": "integer"
+                },
+                "timestamp": {
+                    "type": "string",
+                    "example": "HH:mm"
+                },
+                "type": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "string",
+                    "example": "YYYY-mm-ddTHH:mm:ss "
                 }
             }
         }
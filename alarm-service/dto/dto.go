@@ -12,15 +12,16 @@ type AlarmRequest struct {
 }
 
 type AlarmResponse struct {
-	Id        uint   `json:"id"`
-	Type      uint   `json:"type"`
-	Body      string `json:"body" example:"알람내용"`
-	StartAt   string `json:"start_at" example:"yyyy-mm-dd"`
-	EndAt     string `json:"end_at" example:"yyyy-mm-dd"`
-	Timestamp string `json:"timestamp" example:"HH:mm"`
-	Week      []uint `json:"week"`
-	Created   string `json:"created" example:"YYYY-mm-ddTHH:mm:ss "`
-	Updated   string `json:"updated" example:"YYYY-mm-ddTHH:mm:ss "`
+	Id         uint   `json:"id"`
+	Type       uint   `json:"type"`
+	Body       string `json:"body" example:"알람내용"`
+	StartAt    string `json:"start_at" example:"yyyy-mm-dd"`
+	EndAt      string `json:"end_at" example:"yyyy-mm-dd"`
+	Timestamp  string `json:"timestamp" example:"HH:mm"`
+	Week       []uint `json:"week"`
+	NextFireAt string `json:"next_fire_at" example:"yyyy-mm-ddTHH:mmZ (UTC)"`
+	Created    string `json:"created" example:"YYYY-mm-ddTHH:mm:ss "`
+	Updated    string `json:"updated" example:"YYYY-mm-ddTHH:mm:ss "`
 }
 
 type NotificationResponse struct {
@@ -34,12 +35,26 @@ type NotificationResponse struct {
 	Updated   string `json:"updated" example:"YYYY-mm-ddTHH:mm:ss "`
 }
 
+type NotificationActionRequest struct {
+	Uid         uint   `json:"-"`
+	Action      string `json:"action" example:"ack,snooze,taken"`
+	AlarmId     uint   `json:"alarm_id"`
+	Type        uint   `json:"type"`
+	ParentId    uint   `json:"parent_id"`
+	ScheduledAt string `json:"scheduled_at" example:"알림 data 의 scheduled_at (RFC3339)"`
+	Minutes     uint   `json:"minutes" example:"10"`
+	PatientUid  uint   `json:"patient_uid"` // 보호자에게 온 미복용 알림이면 알림 data 의 patient_uid
+}
+
 type SuccessResponse struct {
 	Jwt string `json:"jwt"`
 }
 
+// 오류 응답 - code 로 분기하고 message 는 사용자에게 표시 (Accept-Language: en 이면 영어)
 type ErrorResponse struct {
-	Err string `json:"err" `
+	Code    string `json:"code" example:"INVALID_REQUEST"`                                       // INVALID_REQUEST(400), UNAUTHORIZED(401), FORBIDDEN(403), NOT_FOUND(404), CONFLICT(409), TOO_MANY_REQUESTS(429), DATABASE_ERROR(500), INTERNAL_ERROR(500)
+	Message string `json:"message" example:"요청 값이 올바르지 않아요."`                                    // 사용자 안내 문구
+	Detail  string `json:"detail,omitempty" example:"invalid date format, should be YYYY-MM-DD"` // 원인 (디버깅용, 변경될 수 있음)
 }
 
 type BasicResponse struct {
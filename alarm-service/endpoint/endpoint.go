@@ -81,3 +81,14 @@ func RemoveNotiEndpoint(s service.AlarmService) endpoint.Endpoint {
 		return dto.BasicResponse{Code: code}, nil
 	}
 }
+
+func NotificationActionEndpoint(s service.AlarmService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(dto.NotificationActionRequest)
+		code, err := s.HandleNotificationAction(req)
+		if err != nil {
+			return dto.BasicResponse{Code: err.Error()}, err
+		}
+		return dto.BasicResponse{Code: code}, nil
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"log"
 	"net"
 	"os"
+	"time"
 
 	_ "alarm-service/docs"
 	pb "alarm-service/proto"
@@ -18,7 +19,12 @@ import (
 	"github.com/joho/godotenv"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"gorm.io/gorm"
 
+	"github.com/disterbia/wellkinson/common/model"
 	swaggerFiles "github.com/swaggo/files"
 )
 
@@ -32,6 +38,17 @@ func main() {
 	database, err := db.NewDB(dbPath)
 	if err != nil {
 		log.Println("Database connection error:", err)
+	} else {
+		// 공통 모델의 Alarm.User 관계로 users 테이블이나 외래키가 만들어지지 않도록 관계는 무시
+		migrator := database.Session(&gorm.Session{})
+		migrator.IgnoreRelationshipsWhenMigrating = true
+		if err := migrator.AutoMigrate(&model.Alarm{}, &model.NotificationAction{}, &model.ProcessedRequest{}); err != nil {
+			log.Println("migration error:", err)
+		}
+		// 기존 알람의 다음 발송 시각 계산
+		if err := service.BackfillNextFireAt(database); err != nil {
+			log.Println("next_fire_at backfill error:", err)
+		}
 	}
 
 	lis, err := net.Listen("tcp", ":50051")
@@ -41,6 +58,10 @@ func main() {
 	grpcServer := grpc.NewServer()
 	alarmServer := &service.AlarmServer{Db: database}
 	pb.RegisterAlarmServiceServer(grpcServer, alarmServer)
+	// 헬스 체크 (compose 준비 확인, 클라이언트 연결 확인)
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	go service.WatchHealth(healthServer, database)
 
 	go func() {
 		if err := grpcServer.Serve(lis); err != nil {
@@ -48,7 +69,25 @@ func main() {
 		}
 	}()
 
-	alarmSvc := service.NewAlarmService(database)
+	// 오래된 멱등키 정리
+	go func() {
+		ticker := time.NewTicker(24 * time.Hour)
+		defer ticker.Stop()
+		for range ticker.C {
+			if err := service.PruneProcessedRequests(database); err != nil {
+				log.Println("processed request prune error:", err)
+			}
+		}
+	}()
+
+	// 알림 액션(복용 처리)용 gRPC 클라이언트
+	conn, err := grpc.Dial("medicine:50053", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("failed to connect to medicine service: %v", err)
+	}
+	defer conn.Close()
+
+	alarmSvc := service.NewAlarmService(database, conn)
 
 	saveAlarmEndpoint := endpoint.SaveAlarmEndpoint(alarmSvc)
 	removeAlarmsEndpoint := endpoint.RemoveAlarmEndpoint(alarmSvc)
@@ -56,12 +95,14 @@ func main() {
 	getNoitsEndpoint := endpoint.GetNotiEndpoint(alarmSvc)
 	readAllNotisEndpoint := endpoint.ReadAllEndpoint(alarmSvc)
 	removeNotisEndpoint := endpoint.RemoveNotiEndpoint(alarmSvc)
+	notificationActionEndpoint := endpoint.NotificationActionEndpoint(alarmSvc)
 	router := gin.Default()
 
 	router.POST("/save-alarm", transport.SaveAlarmHandler(saveAlarmEndpoint))
 	router.POST("/remove-alarms", transport.RemoveAlarmsHandler(removeAlarmsEndpoint))
 	router.POST("/remove-notis", transport.RemoveNoitsHandler(removeNotisEndpoint))
 	router.POST("/read-notis", transport.ReadAllHandler(readAllNotisEndpoint))
+	router.POST("/notification-action", transport.NotificationActionHandler(notificationActionEndpoint))
 	router.GET("/get-alarms", transport.GetHandler(getAlarmsEndpoint))
 	router.GET("/get-notis", transport.GetNotisHandler(getNoitsEndpoint))
 
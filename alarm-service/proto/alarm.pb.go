@@ -25,14 +25,18 @@ type AlarmRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ParentId  int32   `protobuf:"varint,1,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
-	StartAt   string  `protobuf:"bytes,2,opt,name=start_at,json=startAt,proto3" json:"start_at,omitempty"`
-	EndAt     string  `protobuf:"bytes,3,opt,name=end_at,json=endAt,proto3" json:"end_at,omitempty"`
-	Timestamp string  `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Week      []int32 `protobuf:"varint,5,rep,packed,name=week,proto3" json:"week,omitempty"`
-	Type      int32   `protobuf:"varint,6,opt,name=type,proto3" json:"type,omitempty"`
-	Body      string  `protobuf:"bytes,7,opt,name=body,proto3" json:"body,omitempty"`
-	Uid       int32   `protobuf:"varint,8,opt,name=uid,proto3" json:"uid,omitempty"`
+	ParentId    int32   `protobuf:"varint,1,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	StartAt     string  `protobuf:"bytes,2,opt,name=start_at,json=startAt,proto3" json:"start_at,omitempty"`
+	EndAt       string  `protobuf:"bytes,3,opt,name=end_at,json=endAt,proto3" json:"end_at,omitempty"`
+	Timestamp   string  `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Week        []int32 `protobuf:"varint,5,rep,packed,name=week,proto3" json:"week,omitempty"`
+	Type        int32   `protobuf:"varint,6,opt,name=type,proto3" json:"type,omitempty"`
+	Body        string  `protobuf:"bytes,7,opt,name=body,proto3" json:"body,omitempty"`
+	Uid         int32   `protobuf:"varint,8,opt,name=uid,proto3" json:"uid,omitempty"`
+	RepeatType  int32   `protobuf:"varint,9,opt,name=repeat_type,json=repeatType,proto3" json:"repeat_type,omitempty"`
+	RepeatEvery int32   `protobuf:"varint,10,opt,name=repeat_every,json=repeatEvery,proto3" json:"repeat_every,omitempty"`
+	RepeatOff   int32   `protobuf:"varint,11,opt,name=repeat_off,json=repeatOff,proto3" json:"repeat_off,omitempty"`
+	AnchorAt    string  `protobuf:"bytes,12,opt,name=anchor_at,json=anchorAt,proto3" json:"anchor_at,omitempty"`
 }
 
 func (x *AlarmRequest) Reset() {
@@ -123,6 +127,34 @@ func (x *AlarmRequest) GetUid() int32 {
 	return 0
 }
 
+func (x *AlarmRequest) GetRepeatType() int32 {
+	if x != nil {
+		return x.RepeatType
+	}
+	return 0
+}
+
+func (x *AlarmRequest) GetRepeatEvery() int32 {
+	if x != nil {
+		return x.RepeatEvery
+	}
+	return 0
+}
+
+func (x *AlarmRequest) GetRepeatOff() int32 {
+	if x != nil {
+		return x.RepeatOff
+	}
+	return 0
+}
+
+func (x *AlarmRequest) GetAnchorAt() string {
+	if x != nil {
+		return x.AnchorAt
+	}
+	return ""
+}
+
 type AlarmResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -280,11 +312,145 @@ func (x *MultiAlarmRequest) GetAlarmRequests() []*AlarmRequest {
 	return nil
 }
 
+type ReplaceAlarmsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ParentId       int32           `protobuf:"varint,1,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	Type           int32           `protobuf:"varint,2,opt,name=type,proto3" json:"type,omitempty"`
+	Uid            int32           `protobuf:"varint,3,opt,name=uid,proto3" json:"uid,omitempty"`
+	Alarms         []*AlarmRequest `protobuf:"bytes,4,rep,name=alarms,proto3" json:"alarms,omitempty"`
+	IdempotencyKey string          `protobuf:"bytes,5,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+}
+
+func (x *ReplaceAlarmsRequest) Reset() {
+	*x = ReplaceAlarmsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_alarm_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReplaceAlarmsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplaceAlarmsRequest) ProtoMessage() {}
+
+func (x *ReplaceAlarmsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_alarm_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplaceAlarmsRequest.ProtoReflect.Descriptor instead.
+func (*ReplaceAlarmsRequest) Descriptor() ([]byte, []int) {
+	return file_alarm_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ReplaceAlarmsRequest) GetParentId() int32 {
+	if x != nil {
+		return x.ParentId
+	}
+	return 0
+}
+
+func (x *ReplaceAlarmsRequest) GetType() int32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *ReplaceAlarmsRequest) GetUid() int32 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *ReplaceAlarmsRequest) GetAlarms() []*AlarmRequest {
+	if x != nil {
+		return x.Alarms
+	}
+	return nil
+}
+
+func (x *ReplaceAlarmsRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type ReplaceAlarmsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status   string  `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	AlarmIds []int32 `protobuf:"varint,2,rep,packed,name=alarm_ids,json=alarmIds,proto3" json:"alarm_ids,omitempty"`
+}
+
+func (x *ReplaceAlarmsResponse) Reset() {
+	*x = ReplaceAlarmsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_alarm_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReplaceAlarmsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplaceAlarmsResponse) ProtoMessage() {}
+
+func (x *ReplaceAlarmsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_alarm_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplaceAlarmsResponse.ProtoReflect.Descriptor instead.
+func (*ReplaceAlarmsResponse) Descriptor() ([]byte, []int) {
+	return file_alarm_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ReplaceAlarmsResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ReplaceAlarmsResponse) GetAlarmIds() []int32 {
+	if x != nil {
+		return x.AlarmIds
+	}
+	return nil
+}
+
 var File_alarm_proto protoreflect.FileDescriptor
 
 var file_alarm_proto_rawDesc = []byte{
 	0x0a, 0x0b, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x61,
-	0x6c, 0x61, 0x72, 0x6d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x22, 0xc9, 0x01, 0x0a, 0x0c,
+	0x6c, 0x61, 0x72, 0x6d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x22, 0xc9, 0x02, 0x0a, 0x0c,
 	0x41, 0x6c, 0x61, 0x72, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09,
 	0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
 	0x08, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x74, 0x61,
@@ -297,7 +463,15 @@ var file_alarm_proto_rawDesc = []byte{
 	0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x74, 0x79, 0x70,
 	0x65, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52,
 	0x04, 0x62, 0x6f, 0x64, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x08, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x03, 0x75, 0x69, 0x64, 0x22, 0x27, 0x0a, 0x0d, 0x41, 0x6c, 0x61, 0x72, 0x6d,
+	0x28, 0x05, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x70, 0x65, 0x61,
+	0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x72, 0x65,
+	0x70, 0x65, 0x61, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x70, 0x65,
+	0x61, 0x74, 0x5f, 0x65, 0x76, 0x65, 0x72, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b,
+	0x72, 0x65, 0x70, 0x65, 0x61, 0x74, 0x45, 0x76, 0x65, 0x72, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x72,
+	0x65, 0x70, 0x65, 0x61, 0x74, 0x5f, 0x6f, 0x66, 0x66, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x09, 0x72, 0x65, 0x70, 0x65, 0x61, 0x74, 0x4f, 0x66, 0x66, 0x12, 0x1b, 0x0a, 0x09, 0x61, 0x6e,
+	0x63, 0x68, 0x6f, 0x72, 0x5f, 0x61, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x61,
+	0x6e, 0x63, 0x68, 0x6f, 0x72, 0x41, 0x74, 0x22, 0x27, 0x0a, 0x0d, 0x41, 0x6c, 0x61, 0x72, 0x6d,
 	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
 	0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
 	0x22, 0x59, 0x0a, 0x12, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52,
@@ -311,32 +485,54 @@ var file_alarm_proto_rawDesc = []byte{
 	0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x61, 0x6c, 0x61, 0x72, 0x6d,
 	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x52, 0x65, 0x71,
 	0x75, 0x65, 0x73, 0x74, 0x52, 0x0d, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x73, 0x32, 0x8a, 0x03, 0x0a, 0x0c, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x53, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x12, 0x43, 0x0a, 0x08, 0x53, 0x65, 0x74, 0x41, 0x6c, 0x61, 0x72, 0x6d,
-	0x12, 0x1a, 0x2e, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
-	0x41, 0x6c, 0x61, 0x72, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x61,
-	0x6c, 0x61, 0x72, 0x6d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x41, 0x6c, 0x61, 0x72,
-	0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x0b, 0x55, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x12, 0x1a, 0x2e, 0x61, 0x6c, 0x61, 0x72, 0x6d,
-	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x73, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x2e, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x4c, 0x0a, 0x0b, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x41, 0x6c, 0x61, 0x72, 0x6d,
-	0x12, 0x20, 0x2e, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
-	0x41, 0x6c, 0x61, 0x72, 0x6d, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x2e, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x4d, 0x0a, 0x0d, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x53, 0x65, 0x74, 0x41, 0x6c, 0x61, 0x72, 0x6d,
-	0x12, 0x1f, 0x2e, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
-	0x4d, 0x75, 0x6c, 0x74, 0x69, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x1b, 0x2e, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x2e, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x50,
-	0x0a, 0x10, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x6c, 0x61,
-	0x72, 0x6d, 0x12, 0x1f, 0x2e, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x52, 0x65, 0x71, 0x75,
+	0x73, 0x74, 0x73, 0x22, 0xb6, 0x01, 0x0a, 0x14, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x41,
+	0x6c, 0x61, 0x72, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09,
+	0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x08, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a,
+	0x03, 0x75, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12,
+	0x32, 0x0a, 0x06, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x41,
+	0x6c, 0x61, 0x72, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x06, 0x61, 0x6c, 0x61,
+	0x72, 0x6d, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e,
+	0x63, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x69, 0x64,
+	0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x22, 0x4c, 0x0a, 0x15,
+	0x52, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1b, 0x0a,
+	0x09, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x05,
+	0x52, 0x08, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x49, 0x64, 0x73, 0x32, 0xe4, 0x03, 0x0a, 0x0c, 0x41,
+	0x6c, 0x61, 0x72, 0x6d, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x43, 0x0a, 0x08, 0x53,
+	0x65, 0x74, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x12, 0x1a, 0x2e, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x52, 0x65, 0x71, 0x75,
 	0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x73, 0x65, 0x72, 0x76, 0x69,
 	0x63, 0x65, 0x2e, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x42, 0x04, 0x5a, 0x02, 0x2e, 0x2f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x12, 0x46, 0x0a, 0x0b, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x12,
+	0x1a, 0x2e, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x41,
+	0x6c, 0x61, 0x72, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x61, 0x6c,
+	0x61, 0x72, 0x6d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x41, 0x6c, 0x61, 0x72, 0x6d,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4c, 0x0a, 0x0b, 0x52, 0x65, 0x6d, 0x6f,
+	0x76, 0x65, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x12, 0x20, 0x2e, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x52, 0x65, 0x6d, 0x6f,
+	0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x61, 0x6c, 0x61, 0x72,
+	0x6d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x0d, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x53,
+	0x65, 0x74, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x12, 0x1f, 0x2e, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x41, 0x6c, 0x61, 0x72,
+	0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x61, 0x6c, 0x61, 0x72, 0x6d,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x50, 0x0a, 0x10, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x12, 0x1f, 0x2e, 0x61, 0x6c, 0x61, 0x72,
+	0x6d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x41, 0x6c,
+	0x61, 0x72, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x61, 0x6c, 0x61,
+	0x72, 0x6d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x58, 0x0a, 0x0d, 0x52, 0x65, 0x70, 0x6c, 0x61,
+	0x63, 0x65, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x73, 0x12, 0x22, 0x2e, 0x61, 0x6c, 0x61, 0x72, 0x6d,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x41,
+	0x6c, 0x61, 0x72, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x61,
+	0x6c, 0x61, 0x72, 0x6d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x52, 0x65, 0x70, 0x6c,
+	0x61, 0x63, 0x65, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x42, 0x04, 0x5a, 0x02, 0x2e, 0x2f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -351,30 +547,35 @@ func file_alarm_proto_rawDescGZIP() []byte {
 	return file_alarm_proto_rawDescData
 }
 
-var file_alarm_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_alarm_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_alarm_proto_goTypes = []interface{}{
-	(*AlarmRequest)(nil),       // 0: alarmservice.AlarmRequest
-	(*AlarmResponse)(nil),      // 1: alarmservice.AlarmResponse
-	(*AlarmRemoveRequest)(nil), // 2: alarmservice.AlarmRemoveRequest
-	(*MultiAlarmRequest)(nil),  // 3: alarmservice.MultiAlarmRequest
+	(*AlarmRequest)(nil),          // 0: alarmservice.AlarmRequest
+	(*AlarmResponse)(nil),         // 1: alarmservice.AlarmResponse
+	(*AlarmRemoveRequest)(nil),    // 2: alarmservice.AlarmRemoveRequest
+	(*MultiAlarmRequest)(nil),     // 3: alarmservice.MultiAlarmRequest
+	(*ReplaceAlarmsRequest)(nil),  // 4: alarmservice.ReplaceAlarmsRequest
+	(*ReplaceAlarmsResponse)(nil), // 5: alarmservice.ReplaceAlarmsResponse
 }
 var file_alarm_proto_depIdxs = []int32{
 	0, // 0: alarmservice.MultiAlarmRequest.alarm_requests:type_name -> alarmservice.AlarmRequest
-	0, // 1: alarmservice.AlarmService.SetAlarm:input_type -> alarmservice.AlarmRequest
-	0, // 2: alarmservice.AlarmService.UpdateAlarm:input_type -> alarmservice.AlarmRequest
-	2, // 3: alarmservice.AlarmService.RemoveAlarm:input_type -> alarmservice.AlarmRemoveRequest
-	3, // 4: alarmservice.AlarmService.MultiSetAlarm:input_type -> alarmservice.MultiAlarmRequest
-	3, // 5: alarmservice.AlarmService.MultiUpdateAlarm:input_type -> alarmservice.MultiAlarmRequest
-	1, // 6: alarmservice.AlarmService.SetAlarm:output_type -> alarmservice.AlarmResponse
-	1, // 7: alarmservice.AlarmService.UpdateAlarm:output_type -> alarmservice.AlarmResponse
-	1, // 8: alarmservice.AlarmService.RemoveAlarm:output_type -> alarmservice.AlarmResponse
-	1, // 9: alarmservice.AlarmService.MultiSetAlarm:output_type -> alarmservice.AlarmResponse
-	1, // 10: alarmservice.AlarmService.MultiUpdateAlarm:output_type -> alarmservice.AlarmResponse
-	6, // [6:11] is the sub-list for method output_type
-	1, // [1:6] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	0, // 1: alarmservice.ReplaceAlarmsRequest.alarms:type_name -> alarmservice.AlarmRequest
+	0, // 2: alarmservice.AlarmService.SetAlarm:input_type -> alarmservice.AlarmRequest
+	0, // 3: alarmservice.AlarmService.UpdateAlarm:input_type -> alarmservice.AlarmRequest
+	2, // 4: alarmservice.AlarmService.RemoveAlarm:input_type -> alarmservice.AlarmRemoveRequest
+	3, // 5: alarmservice.AlarmService.MultiSetAlarm:input_type -> alarmservice.MultiAlarmRequest
+	3, // 6: alarmservice.AlarmService.MultiUpdateAlarm:input_type -> alarmservice.MultiAlarmRequest
+	4, // 7: alarmservice.AlarmService.ReplaceAlarms:input_type -> alarmservice.ReplaceAlarmsRequest
+	1, // 8: alarmservice.AlarmService.SetAlarm:output_type -> alarmservice.AlarmResponse
+	1, // 9: alarmservice.AlarmService.UpdateAlarm:output_type -> alarmservice.AlarmResponse
+	1, // 10: alarmservice.AlarmService.RemoveAlarm:output_type -> alarmservice.AlarmResponse
+	1, // 11: alarmservice.AlarmService.MultiSetAlarm:output_type -> alarmservice.AlarmResponse
+	1, // 12: alarmservice.AlarmService.MultiUpdateAlarm:output_type -> alarmservice.AlarmResponse
+	5, // 13: alarmservice.AlarmService.ReplaceAlarms:output_type -> alarmservice.ReplaceAlarmsResponse
+	8, // [8:14] is the sub-list for method output_type
+	2, // [2:8] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_alarm_proto_init() }
@@ -431,6 +632,30 @@ func file_alarm_proto_init() {
 				return nil
 			}
 		}
+		file_alarm_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReplaceAlarmsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_alarm_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReplaceAlarmsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -438,7 +663,7 @@ func file_alarm_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_alarm_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -0,0 +1,253 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v4.25.1
+// source: medicine.proto
+
+package __
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TakeMedicineRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uid        int32  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	MedicineId int32  `protobuf:"varint,2,opt,name=medicine_id,json=medicineId,proto3" json:"medicine_id,omitempty"`
+	DateTaken  string `protobuf:"bytes,3,opt,name=date_taken,json=dateTaken,proto3" json:"date_taken,omitempty"`
+	TimeTaken  string `protobuf:"bytes,4,opt,name=time_taken,json=timeTaken,proto3" json:"time_taken,omitempty"`
+	RealTaken  string `protobuf:"bytes,5,opt,name=real_taken,json=realTaken,proto3" json:"real_taken,omitempty"`
+}
+
+func (x *TakeMedicineRequest) Reset() {
+	*x = TakeMedicineRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_medicine_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TakeMedicineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TakeMedicineRequest) ProtoMessage() {}
+
+func (x *TakeMedicineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_medicine_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TakeMedicineRequest.ProtoReflect.Descriptor instead.
+func (*TakeMedicineRequest) Descriptor() ([]byte, []int) {
+	return file_medicine_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TakeMedicineRequest) GetUid() int32 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *TakeMedicineRequest) GetMedicineId() int32 {
+	if x != nil {
+		return x.MedicineId
+	}
+	return 0
+}
+
+func (x *TakeMedicineRequest) GetDateTaken() string {
+	if x != nil {
+		return x.DateTaken
+	}
+	return ""
+}
+
+func (x *TakeMedicineRequest) GetTimeTaken() string {
+	if x != nil {
+		return x.TimeTaken
+	}
+	return ""
+}
+
+func (x *TakeMedicineRequest) GetRealTaken() string {
+	if x != nil {
+		return x.RealTaken
+	}
+	return ""
+}
+
+type MedicineResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (x *MedicineResponse) Reset() {
+	*x = MedicineResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_medicine_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MedicineResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MedicineResponse) ProtoMessage() {}
+
+func (x *MedicineResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_medicine_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MedicineResponse.ProtoReflect.Descriptor instead.
+func (*MedicineResponse) Descriptor() ([]byte, []int) {
+	return file_medicine_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MedicineResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+var File_medicine_proto protoreflect.FileDescriptor
+
+var file_medicine_proto_rawDesc = []byte{
+	0x0a, 0x0e, 0x6d, 0x65, 0x64, 0x69, 0x63, 0x69, 0x6e, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x0f, 0x6d, 0x65, 0x64, 0x69, 0x63, 0x69, 0x6e, 0x65, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x22, 0xa5, 0x01, 0x0a, 0x13, 0x54, 0x61, 0x6b, 0x65, 0x4d, 0x65, 0x64, 0x69, 0x63, 0x69,
+	0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x6d,
+	0x65, 0x64, 0x69, 0x63, 0x69, 0x6e, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0a, 0x6d, 0x65, 0x64, 0x69, 0x63, 0x69, 0x6e, 0x65, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a,
+	0x64, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x61, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x64, 0x61, 0x74, 0x65, 0x54, 0x61, 0x6b, 0x65, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x74,
+	0x69, 0x6d, 0x65, 0x5f, 0x74, 0x61, 0x6b, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x74, 0x69, 0x6d, 0x65, 0x54, 0x61, 0x6b, 0x65, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65,
+	0x61, 0x6c, 0x5f, 0x74, 0x61, 0x6b, 0x65, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x72, 0x65, 0x61, 0x6c, 0x54, 0x61, 0x6b, 0x65, 0x6e, 0x22, 0x2a, 0x0a, 0x10, 0x4d, 0x65, 0x64,
+	0x69, 0x63, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x32, 0x6a, 0x0a, 0x0f, 0x4d, 0x65, 0x64, 0x69, 0x63, 0x69, 0x6e,
+	0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x57, 0x0a, 0x0c, 0x54, 0x61, 0x6b, 0x65,
+	0x4d, 0x65, 0x64, 0x69, 0x63, 0x69, 0x6e, 0x65, 0x12, 0x24, 0x2e, 0x6d, 0x65, 0x64, 0x69, 0x63,
+	0x69, 0x6e, 0x65, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x54, 0x61, 0x6b, 0x65, 0x4d,
+	0x65, 0x64, 0x69, 0x63, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21,
+	0x2e, 0x6d, 0x65, 0x64, 0x69, 0x63, 0x69, 0x6e, 0x65, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x2e, 0x4d, 0x65, 0x64, 0x69, 0x63, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x42, 0x04, 0x5a, 0x02, 0x2e, 0x2f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_medicine_proto_rawDescOnce sync.Once
+	file_medicine_proto_rawDescData = file_medicine_proto_rawDesc
+)
+
+func file_medicine_proto_rawDescGZIP() []byte {
+	file_medicine_proto_rawDescOnce.Do(func() {
+		file_medicine_proto_rawDescData = protoimpl.X.CompressGZIP(file_medicine_proto_rawDescData)
+	})
+	return file_medicine_proto_rawDescData
+}
+
+var file_medicine_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_medicine_proto_goTypes = []interface{}{
+	(*TakeMedicineRequest)(nil), // 0: medicineservice.TakeMedicineRequest
+	(*MedicineResponse)(nil),    // 1: medicineservice.MedicineResponse
+}
+var file_medicine_proto_depIdxs = []int32{
+	0, // 0: medicineservice.MedicineService.TakeMedicine:input_type -> medicineservice.TakeMedicineRequest
+	1, // 1: medicineservice.MedicineService.TakeMedicine:output_type -> medicineservice.MedicineResponse
+	1, // [1:2] is the sub-list for method output_type
+	0, // [0:1] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_medicine_proto_init() }
+func file_medicine_proto_init() {
+	if File_medicine_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_medicine_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TakeMedicineRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_medicine_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MedicineResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_medicine_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_medicine_proto_goTypes,
+		DependencyIndexes: file_medicine_proto_depIdxs,
+		MessageInfos:      file_medicine_proto_msgTypes,
+	}.Build()
+	File_medicine_proto = out.File
+	file_medicine_proto_rawDesc = nil
+	file_medicine_proto_goTypes = nil
+	file_medicine_proto_depIdxs = nil
+}
@@ -0,0 +1,173 @@
+// /alarm-service/service/action.go
+package service
+
+import (
+	"alarm-service/dto"
+	pb "alarm-service/proto"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/disterbia/wellkinson/common/model"
+	"github.com/disterbia/wellkinson/common/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// 알림 액션
+const (
+	actionAck    = "ack"    // 확인 (약 알람은 복용 처리)
+	actionSnooze = "snooze" // N분 뒤 다시 알림
+	actionTaken  = "taken"  // 복용 (약 알람만)
+)
+
+const (
+	defaultSnoozeMinutes = 10
+	maxSnoozeMinutes     = 120
+	medicineCallTimeout  = 5 * time.Second
+)
+
+// 푸시 알림에서 누른 액션 처리 - parent_id, type, scheduled_at 은 알림 data 값 그대로 사용
+func (service *alarmService) HandleNotificationAction(actionRequest dto.NotificationActionRequest) (string, error) {
+	scheduledAt, err := time.Parse(time.RFC3339, actionRequest.ScheduledAt)
+	if err != nil {
+		return "", util.NewError(util.ErrInvalidRequest, "invalid scheduled_at")
+	}
+	scheduledAt = scheduledAt.UTC().Truncate(time.Minute)
+
+	// 보호자에게 간 미복용 알림이면 환자 대신 복용 처리 (위임 쓰기 권한 확인)
+	caregiverUid := uint(0)
+	if actionRequest.PatientUid != 0 && actionRequest.PatientUid != actionRequest.Uid {
+		if actionRequest.Type != uint(util.MedicineType) || actionRequest.Action == actionSnooze {
+			return "", util.NewError(util.ErrInvalidRequest, "invalid action")
+		}
+		ok, err := service.delegation.HasAccess(actionRequest.PatientUid, actionRequest.Uid, util.DomainMedicine, true)
+		if err != nil {
+			return "", util.WrapError(util.ErrDatabase, err)
+		}
+		if !ok {
+			return "", util.NewError(util.ErrForbidden, "no delegated access")
+		}
+		caregiverUid = actionRequest.Uid
+		actionRequest.Uid = actionRequest.PatientUid
+	}
+	loc := userLocation(service.db, actionRequest.Uid)
+	now := time.Now()
+
+	action := model.NotificationAction{Uid: actionRequest.Uid, Type: actionRequest.Type, ParentId: actionRequest.ParentId,
+		Action: actionRequest.Action, ScheduledAt: scheduledAt.Format(util.FireLayout)}
+
+	switch actionRequest.Action {
+	case actionAck, actionTaken:
+		if actionRequest.Type == uint(util.MedicineType) {
+			if err := service.takeMedicine(actionRequest, scheduledAt.In(loc), now.In(loc)); err != nil {
+				return "", err
+			}
+		} else if actionRequest.Action == actionTaken {
+			return "", util.NewError(util.ErrInvalidRequest, "invalid action")
+		}
+	case actionSnooze:
+		minutes := actionRequest.Minutes
+		if minutes == 0 {
+			minutes = defaultSnoozeMinutes
+		}
+		if minutes > maxSnoozeMinutes {
+			return "", util.NewError(util.ErrInvalidRequest, "invalid minutes")
+		}
+		snoozeAt, err := service.snooze(actionRequest, scheduledAt, now.Add(time.Duration(minutes)*time.Minute), loc)
+		if err != nil {
+			return "", err
+		}
+		action.SnoozeUntil = snoozeAt
+	default:
+		return "", util.NewError(util.ErrInvalidRequest, "invalid action")
+	}
+
+	if err := service.db.Create(&action).Error; err != nil {
+		return "", util.NewError(util.ErrDatabase, "db error")
+	}
+	if caregiverUid != 0 {
+		if err := service.delegation.RecordWrite(actionRequest.Uid, caregiverUid, util.DomainMedicine, "notification-action "+actionRequest.Action, http.StatusOK); err != nil {
+			log.Println("delegated write audit error:", err)
+		}
+	}
+	return "200", nil
+}
+
+// medicine-service 의 복용 처리 호출 (원래 알람 시각을 복용 예정 시각으로 사용)
+func (service *alarmService) takeMedicine(actionRequest dto.NotificationActionRequest, scheduledAt, realTaken time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), medicineCallTimeout)
+	defer cancel()
+	response, err := service.medicineClient.TakeMedicine(ctx, &pb.TakeMedicineRequest{
+		Uid:        int32(actionRequest.Uid),
+		MedicineId: int32(actionRequest.ParentId),
+		DateTaken:  scheduledAt.Format("2006-01-02"),
+		TimeTaken:  scheduledAt.Format("15:04"),
+		RealTaken:  realTaken.Format("15:04"),
+	})
+	if err != nil {
+		log.Printf("Failed to take medicine: %v", err)
+		return takeMedicineError(err)
+	}
+	log.Printf("take medicine: %v", response)
+	return nil
+}
+
+// medicine-service 응답 상태를 오류 코드로 - 없거나 삭제된 약과 연결 실패를 구분
+func takeMedicineError(err error) error {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return util.NewError(util.ErrNotFound, "medicine not found")
+	case codes.InvalidArgument:
+		return util.NewError(util.ErrInvalidRequest, status.Convert(err).Message())
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return util.WrapError(util.ErrServiceUnavailable, err)
+	}
+	return util.WrapError(util.ErrInternal, err)
+}
+
+// 원래 알람 내용으로 1회용 알람 생성 - 발송 후 fcm-service 에서 삭제됨
+func (service *alarmService) snooze(actionRequest dto.NotificationActionRequest, scheduledAt, snoozeAt time.Time, loc *time.Location) (string, error) {
+	// 직접 등록한 알람은 parent_id 가 없으므로 alarm_id 로 조회
+	query := service.db.Where("uid = ? AND id = ?", actionRequest.Uid, actionRequest.AlarmId)
+	if actionRequest.AlarmId == 0 {
+		query = service.db.Where("uid = ? AND type = ? AND parent_id = ?", actionRequest.Uid, actionRequest.Type, actionRequest.ParentId)
+	}
+	var origin model.Alarm
+	if err := query.Order("id").First(&origin).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", util.NewError(util.ErrNotFound, "alarm not found")
+		}
+		return "", util.NewError(util.ErrDatabase, "db error2")
+	}
+
+	local := snoozeAt.In(loc)
+	week, err := json.Marshal([]int{int(local.Weekday())})
+	if err != nil {
+		return "", err
+	}
+	alarm := model.Alarm{Uid: origin.Uid, ParentId: origin.ParentId, Type: origin.Type, Body: origin.Body,
+		StartAt: local.Format("2006-01-02"), EndAt: local.Format("2006-01-02"), Timestamp: local.Format("15:04"), Week: week,
+		ScheduledAt: scheduledAt.Format(util.FireLayout)}
+	alarm.NextFireAt = util.NextFireAt(alarm.StartAt, alarm.EndAt, alarm.Timestamp, alarm.Week, alarmRepeat(alarm), loc, local.Add(-time.Minute))
+	if alarm.NextFireAt == "" {
+		return "", util.NewError(util.ErrInvalidRequest, "invalid minutes")
+	}
+
+	// 같은 알람의 이전 다시 알림은 새 시각으로 대체
+	err = service.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("uid = ? AND type = ? AND parent_id = ? AND body = ? AND scheduled_at = ?", alarm.Uid, alarm.Type, alarm.ParentId, alarm.Body, alarm.ScheduledAt).
+			Delete(&model.Alarm{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&alarm).Error
+	})
+	if err != nil {
+		return "", util.NewError(util.ErrDatabase, "db error3")
+	}
+	return alarm.NextFireAt, nil
+}
@@ -0,0 +1,33 @@
+// /alarm-service/service/action_test.go
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/disterbia/wellkinson/common/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestTakeMedicineError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"deleted medicine", status.Error(codes.NotFound, "medicine not found"), util.ErrNotFound},
+		{"bad request", status.Error(codes.InvalidArgument, "over dose"), util.ErrInvalidRequest},
+		{"medicine db down", status.Error(codes.Unavailable, "db unavailable"), util.ErrServiceUnavailable},
+		{"timeout", status.Error(codes.DeadlineExceeded, "deadline"), util.ErrServiceUnavailable},
+		{"internal", status.Error(codes.Internal, "boom"), util.ErrInternal},
+		{"connection", errors.New("dial error"), util.ErrInternal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := util.ToAppError(takeMedicineError(tt.err)).Code; got != tt.want {
+				t.Errorf("takeMedicineError() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
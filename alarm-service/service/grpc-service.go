@@ -2,14 +2,26 @@
 package service
 
 import (
-	"alarm-service/common/model"
-	"alarm-service/common/util"
 	pb "alarm-service/proto"
 	"context"
 	"encoding/json"
 	"errors"
+	"strings"
+	"time"
 
+	"github.com/disterbia/wellkinson/common/model"
+	"github.com/disterbia/wellkinson/common/util"
+	"github.com/jackc/pgx/v5/pgconn"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	idempotencyKeyHeader = "idempotency-key"
+	processedKeepDays    = 30 // 멱등키 보관 기간 (relay 재시도 기간보다 길게)
 )
 
 type AlarmServer struct {
@@ -32,10 +44,14 @@ func (s *AlarmServer) SetAlarm(ctx context.Context, req *pb.AlarmRequest) (*pb.A
 	var alarm model.Alarm
 
 	if err := util.CopyStruct(req, &alarm); err != nil {
-		return nil, err
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
-	if err := s.Db.Create(&alarm).Error; err != nil {
-		return nil, errors.New("db error")
+	setNextFireAt(&alarm, userLocation(s.Db, alarm.Uid))
+	err := s.once(requestKey(ctx), "SetAlarm", nil, func(tx *gorm.DB) error {
+		return tx.Create(&alarm).Error
+	})
+	if err != nil {
+		return nil, dbStatus(err)
 	}
 
 	return &pb.AlarmResponse{Status: "Success"}, nil
@@ -44,10 +60,11 @@ func (s *AlarmServer) SetAlarm(ctx context.Context, req *pb.AlarmRequest) (*pb.A
 
 func (s *AlarmServer) RemoveAlarm(ctx context.Context, req *pb.AlarmRemoveRequest) (*pb.AlarmResponse, error) {
 
-	result := s.Db.Where("parent_id IN ? AND uid= ? AND type=?", req.ParentIds, req.Uid, req.Type).Delete(&model.Alarm{})
-
-	if result.Error != nil {
-		return nil, errors.New("db error")
+	err := s.once(requestKey(ctx), "RemoveAlarm", nil, func(tx *gorm.DB) error {
+		return tx.Where("parent_id IN ? AND uid= ? AND type=?", req.ParentIds, req.Uid, req.Type).Delete(&model.Alarm{}).Error
+	})
+	if err != nil {
+		return nil, dbStatus(err)
 	}
 
 	return &pb.AlarmResponse{Status: "Success"}, nil
@@ -57,15 +74,16 @@ func (s *AlarmServer) UpdateAlarm(ctx context.Context, req *pb.AlarmRequest) (*p
 
 	var alarm model.Alarm
 
-	result := s.Db.Where("parent_id = ? AND uid= ? AND type=?", req.ParentId, req.Uid, req.Type).Delete(&model.Alarm{})
-	if result.Error != nil {
-		return nil, errors.New("db error")
-	}
 	if err := util.CopyStruct(req, &alarm); err != nil {
-		return nil, err
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
-	if err := s.Db.Create(&alarm).Error; err != nil {
-		return nil, errors.New("db error")
+	setNextFireAt(&alarm, userLocation(s.Db, alarm.Uid))
+	err := s.once(requestKey(ctx), "UpdateAlarm", nil, func(tx *gorm.DB) error {
+		_, err := replaceAlarms(tx, []int32{req.ParentId}, req.Uid, req.Type, []model.Alarm{alarm})
+		return err
+	})
+	if err != nil {
+		return nil, dbStatus(err)
 	}
 
 	return &pb.AlarmResponse{Status: "Success"}, nil
@@ -77,10 +95,16 @@ func (s *AlarmServer) MultiSetAlarm(ctx context.Context, req *pb.MultiAlarmReque
 	var alarms []model.Alarm
 
 	if err := util.CopyStruct(req.AlarmRequests, &alarms); err != nil {
-		return nil, err
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	for i := range alarms {
+		setNextFireAt(&alarms[i], userLocation(s.Db, alarms[i].Uid))
 	}
-	if err := s.Db.Create(&alarms).Error; err != nil {
-		return nil, errors.New("db error")
+	err := s.once(requestKey(ctx), "MultiSetAlarm", nil, func(tx *gorm.DB) error {
+		return tx.Create(&alarms).Error
+	})
+	if err != nil {
+		return nil, dbStatus(err)
 	}
 
 	return &pb.AlarmResponse{Status: "Success"}, nil
@@ -89,22 +113,164 @@ func (s *AlarmServer) MultiSetAlarm(ctx context.Context, req *pb.MultiAlarmReque
 
 func (s *AlarmServer) MultiUpdateAlarm(ctx context.Context, req *pb.MultiAlarmRequest) (*pb.AlarmResponse, error) {
 
+	if len(req.AlarmRequests) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "empty alarm requests")
+	}
+	uid, alarmType := req.AlarmRequests[0].Uid, req.AlarmRequests[0].Type
 	var alarms []model.Alarm
 	var ids []int32
 	for _, v := range req.AlarmRequests {
+		if v.Uid != uid || v.Type != alarmType {
+			return nil, status.Error(codes.InvalidArgument, "alarm owner mismatch")
+		}
 		ids = append(ids, v.ParentId)
 	}
-	result := s.Db.Where("parent_id IN ? AND uid= ? AND type=?", ids, req.AlarmRequests[0].Uid, req.AlarmRequests[0].Type).Delete(&model.Alarm{})
-	if result.Error != nil {
-		return nil, errors.New("db error")
-	}
 	if err := util.CopyStruct(req.AlarmRequests, &alarms); err != nil {
-		return nil, err
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	loc := userLocation(s.Db, uint(uid))
+	for i := range alarms {
+		setNextFireAt(&alarms[i], loc)
 	}
-	if err := s.Db.Create(&alarms).Error; err != nil {
-		return nil, errors.New("db error")
+	err := s.once(requestKey(ctx), "MultiUpdateAlarm", nil, func(tx *gorm.DB) error {
+		_, err := replaceAlarms(tx, ids, uid, alarmType, alarms)
+		return err
+	})
+	if err != nil {
+		return nil, dbStatus(err)
 	}
 
 	return &pb.AlarmResponse{Status: "Success"}, nil
 
 }
+
+// parent 의 알람을 한번에 교체 (삭제와 생성을 한 트랜잭션으로, alarms 가 비어 있으면 삭제만)
+// 모든 알람은 요청의 parent/uid/type 이어야 하고, 멱등키(요청 필드 또는 메타데이터)가 같으면 처음 결과를 그대로 응답
+func (s *AlarmServer) ReplaceAlarms(ctx context.Context, req *pb.ReplaceAlarmsRequest) (*pb.ReplaceAlarmsResponse, error) {
+	if req.ParentId <= 0 || req.Uid <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid parent")
+	}
+	alarms := make([]model.Alarm, 0, len(req.Alarms))
+	loc := userLocation(s.Db, uint(req.Uid))
+	for _, v := range req.Alarms {
+		// 비어 있으면 요청 값 사용
+		if (v.ParentId != 0 && v.ParentId != req.ParentId) || (v.Uid != 0 && v.Uid != req.Uid) || (v.Type != 0 && v.Type != req.Type) {
+			return nil, status.Error(codes.InvalidArgument, "alarm owner mismatch")
+		}
+		var alarm model.Alarm
+		if err := util.CopyStruct(v, &alarm); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		alarm.ParentId, alarm.Uid, alarm.Type = uint(req.ParentId), uint(req.Uid), uint(req.Type)
+		setNextFireAt(&alarm, loc)
+		alarms = append(alarms, alarm)
+	}
+
+	key := req.IdempotencyKey
+	if key == "" {
+		key = requestKey(ctx)
+	}
+	ids := make([]int32, 0, len(alarms))
+	err := s.once(key, "ReplaceAlarms", &ids, func(tx *gorm.DB) error {
+		var err error
+		ids, err = replaceAlarms(tx, []int32{req.ParentId}, req.Uid, req.Type, alarms)
+		return err
+	})
+	if err != nil {
+		return nil, dbStatus(err)
+	}
+
+	return &pb.ReplaceAlarmsResponse{Status: "Success", AlarmIds: ids}, nil
+}
+
+// parent 들의 알람을 지우고 새로 저장 - 저장한 알람 id 반환
+func replaceAlarms(tx *gorm.DB, parentIds []int32, uid, alarmType int32, alarms []model.Alarm) ([]int32, error) {
+	if err := tx.Where("parent_id IN ? AND uid= ? AND type=?", parentIds, uid, alarmType).Delete(&model.Alarm{}).Error; err != nil {
+		return nil, err
+	}
+	ids := make([]int32, 0, len(alarms))
+	if len(alarms) == 0 {
+		return ids, nil
+	}
+	if err := tx.Create(&alarms).Error; err != nil {
+		return nil, err
+	}
+	for _, v := range alarms {
+		ids = append(ids, int32(v.Id))
+	}
+	return ids, nil
+}
+
+// DB 오류를 gRPC 상태로 - 다시 보내도 실패하는 제약 위반은 AlreadyExists/InvalidArgument, 연결 실패만 Unavailable
+func dbStatus(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return status.Error(codes.NotFound, "not found")
+	}
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		// 서버 응답이 아닌 오류 (연결 실패, 시간 초과 등)
+		return status.Error(codes.Unavailable, "db unavailable")
+	}
+	switch {
+	case pgErr.Code == "23505": // unique_violation
+		return status.Error(codes.AlreadyExists, "already exists")
+	case strings.HasPrefix(pgErr.Code, "22"), strings.HasPrefix(pgErr.Code, "23"): // 잘못된 값, 제약 위반
+		return status.Error(codes.InvalidArgument, "invalid alarm")
+	case pgErr.Code == "40001", pgErr.Code == "40P01": // 직렬화 실패, 교착 상태
+		return status.Error(codes.Aborted, "db conflict")
+	case strings.HasPrefix(pgErr.Code, "08"), strings.HasPrefix(pgErr.Code, "57P"), strings.HasPrefix(pgErr.Code, "53"): // 연결, 종료, 자원 부족
+		return status.Error(codes.Unavailable, "db unavailable")
+	}
+	return status.Error(codes.Internal, "db error")
+}
+
+// 요청 메타데이터의 멱등키
+func requestKey(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(idempotencyKeyHeader); len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+// 멱등키로 한번만 적용 - 키 저장과 알람 변경을 같은 트랜잭션으로 처리
+// 이미 처리한 키면 적용하지 않고 성공 (relay 가 응답을 못 받고 다시 보낸 경우), result 가 있으면 처음 결과를 채움
+func (s *AlarmServer) once(key, method string, result interface{}, apply func(tx *gorm.DB) error) error {
+	return s.Db.Transaction(func(tx *gorm.DB) error {
+		if key == "" {
+			return apply(tx)
+		}
+		created := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&model.ProcessedRequest{Key: key, Method: method})
+		if created.Error != nil {
+			return created.Error
+		}
+		if created.RowsAffected == 0 {
+			var processed model.ProcessedRequest
+			if err := tx.Where("key = ?", key).First(&processed).Error; err != nil {
+				return err
+			}
+			if result != nil && len(processed.Result) > 0 {
+				return json.Unmarshal(processed.Result, result)
+			}
+			return nil
+		}
+		if err := apply(tx); err != nil {
+			return err
+		}
+		if result == nil {
+			return nil
+		}
+		data, err := json.Marshal(result)
+		if err != nil {
+			return err
+		}
+		return tx.Model(&model.ProcessedRequest{}).Where("key = ?", key).Update("result", data).Error
+	})
+}
+
+// 오래된 멱등키 삭제 (하루마다 실행)
+func PruneProcessedRequests(db *gorm.DB) error {
+	before := time.Now().In(model.ServerLocation).AddDate(0, 0, -processedKeepDays).Format("2006-01-02 15:04:05")
+	return db.Where("created < ?", before).Delete(&model.ProcessedRequest{}).Error
+}
@@ -0,0 +1,42 @@
+// /alarm-service/service/grpc-service_test.go
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+func TestDbStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{"not found", gorm.ErrRecordNotFound, codes.NotFound},
+		{"unique violation", &pgconn.PgError{Code: "23505"}, codes.AlreadyExists},
+		{"wrapped unique violation", fmt.Errorf("insert: %w", &pgconn.PgError{Code: "23505"}), codes.AlreadyExists},
+		{"check violation", &pgconn.PgError{Code: "23514"}, codes.InvalidArgument},
+		{"not null violation", &pgconn.PgError{Code: "23502"}, codes.InvalidArgument},
+		{"invalid value", &pgconn.PgError{Code: "22P02"}, codes.InvalidArgument},
+		{"serialization failure", &pgconn.PgError{Code: "40001"}, codes.Aborted},
+		{"connection failure", &pgconn.PgError{Code: "08006"}, codes.Unavailable},
+		{"admin shutdown", &pgconn.PgError{Code: "57P01"}, codes.Unavailable},
+		{"undefined column", &pgconn.PgError{Code: "42703"}, codes.Internal},
+		{"no connection", errors.New("dial tcp: connection refused"), codes.Unavailable},
+		{"timeout", context.DeadlineExceeded, codes.Unavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := status.Code(dbStatus(tt.err)); got != tt.want {
+				t.Fatalf("dbStatus(%v) = %s, want %s", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+// /alarm-service/service/health.go
+package service
+
+import (
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"gorm.io/gorm"
+)
+
+const (
+	healthInterval   = 10 * time.Second
+	AlarmServiceName = "alarmservice.AlarmService"
+)
+
+// DB 연결 상태로 헬스 체크 상태 갱신 (전체 "" 와 AlarmService) - DB 가 없거나 응답하지 않으면 NOT_SERVING
+func WatchHealth(healthServer *health.Server, db *gorm.DB) {
+	for {
+		status := healthpb.HealthCheckResponse_NOT_SERVING
+		if db != nil {
+			if sqlDB, err := db.DB(); err == nil && sqlDB.Ping() == nil {
+				status = healthpb.HealthCheckResponse_SERVING
+			}
+		}
+		healthServer.SetServingStatus("", status)
+		healthServer.SetServingStatus(AlarmServiceName, status)
+		time.Sleep(healthInterval)
+	}
+}
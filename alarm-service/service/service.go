@@ -3,11 +3,13 @@
 package service
 
 import (
-	"alarm-service/common/model"
-	"alarm-service/common/util"
 	"alarm-service/dto"
+	pb "alarm-service/proto"
 	"errors"
 
+	"github.com/disterbia/wellkinson/common/model"
+	"github.com/disterbia/wellkinson/common/util"
+	"google.golang.org/grpc"
 	"gorm.io/gorm"
 )
 
@@ -18,14 +20,18 @@ type AlarmService interface {
 	GetNotifications(uid uint) ([]dto.NotificationResponse, error)
 	ReadAll(uid uint) (string, error)
 	RemoveNotifications(ids []uint, uid uint) (string, error)
+	HandleNotificationAction(actionRequest dto.NotificationActionRequest) (string, error)
 }
 
 type alarmService struct {
-	db *gorm.DB
+	db             *gorm.DB
+	medicineClient pb.MedicineServiceClient
+	delegation     util.DelegationStore
 }
 
-func NewAlarmService(db *gorm.DB) AlarmService {
-	return &alarmService{db: db}
+func NewAlarmService(db *gorm.DB, conn *grpc.ClientConn) AlarmService {
+	medicineClient := pb.NewMedicineServiceClient(conn)
+	return &alarmService{db: db, medicineClient: medicineClient, delegation: util.NewDelegationStore(db)}
 }
 func (service *alarmService) GetNotifications(uid uint) ([]dto.NotificationResponse, error) {
 	var notifications []model.Notification
@@ -46,7 +52,8 @@ func (service *alarmService) GetAlarms(id uint, page uint) ([]dto.AlarmResponse,
 	var alarms []model.Alarm
 	offset := int(page) * pageSize
 
-	result := service.db.Where("uid = ? ", id).Order("id DESC").Offset(offset).Limit(pageSize).Find(&alarms)
+	// 다시 알림(1회) 알람은 제외
+	result := service.db.Where("uid = ? AND scheduled_at = ''", id).Order("id DESC").Offset(offset).Limit(pageSize).Find(&alarms)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -76,19 +83,21 @@ func (service *alarmService) SaveAlarm(alarmRequest dto.AlarmRequest) (string, e
 		return "", err
 	}
 	alarm.Week = newWeekdays
+	setNextFireAt(&alarm, userLocation(service.db, alarm.Uid))
 
 	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 		// 레코드가 존재하지 않으면 새 레코드 생성
 		alarm.Id = 0
 		if err := service.db.Create(&alarm).Error; err != nil {
-			return "", errors.New("db error")
+			return "", util.NewError(util.ErrDatabase, "db error")
 		}
 	} else if result.Error != nil {
-		return "", errors.New("db error2")
+		return "", util.NewError(util.ErrDatabase, "db error2")
 	} else {
 		// 레코드가 존재하면 업데이트
-		if err := service.db.Model(&alarm).Updates(alarm).Error; err != nil {
-			return "", errors.New("db error3")
+		// 기간이 끝난 알람은 next_fire_at 이 "" 이므로 따로 갱신
+		if err := service.db.Model(&alarm).Updates(alarm).Update("next_fire_at", alarm.NextFireAt).Error; err != nil {
+			return "", util.NewError(util.ErrDatabase, "db error3")
 		}
 	}
 
@@ -100,7 +109,7 @@ func (service *alarmService) RemoveAlarm(ids []uint, uid uint) (string, error) {
 	result := service.db.Where("id IN ? AND uid= ?", ids, uid).Delete(&model.Alarm{})
 
 	if result.Error != nil {
-		return "", errors.New("db error")
+		return "", util.NewError(util.ErrDatabase, "db error")
 	}
 	return "200", nil
 }
@@ -109,7 +118,7 @@ func (service *alarmService) ReadAll(uid uint) (string, error) {
 	var noti model.Notification
 	result := service.db.Model(&noti).Where("uid = ?", uid).Select("is_read").Updates(map[string]interface{}{"is_read": true})
 	if result.Error != nil {
-		return "", errors.New("db error")
+		return "", util.NewError(util.ErrDatabase, "db error")
 	}
 	return "200", nil
 }
@@ -119,7 +128,7 @@ func (service *alarmService) RemoveNotifications(ids []uint, uid uint) (string,
 	result := service.db.Where("id IN ? AND uid= ?", ids, uid).Delete(&model.Notification{})
 
 	if result.Error != nil {
-		return "", errors.New("db error")
+		return "", util.NewError(util.ErrDatabase, "db error")
 	}
 	return "200", nil
 }
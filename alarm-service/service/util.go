@@ -2,12 +2,53 @@
 package service
 
 import (
-	"alarm-service/common/util"
 	"alarm-service/dto"
 	"encoding/json"
-	"errors"
+	"time"
+
+	"github.com/disterbia/wellkinson/common/model"
+	"github.com/disterbia/wellkinson/common/util"
+	"gorm.io/gorm"
 )
 
+// next_fire_at 이 없거나 예전 형식(서버 시간대)인 알람 재계산
+func BackfillNextFireAt(db *gorm.DB) error {
+	var alarms []model.Alarm
+	locations := make(map[uint]*time.Location)
+	return db.Where("next_fire_at IS NULL OR (next_fire_at <> '' AND next_fire_at NOT LIKE '%Z')").FindInBatches(&alarms, 500, func(tx *gorm.DB, batch int) error {
+		for i := range alarms {
+			loc, ok := locations[alarms[i].Uid]
+			if !ok {
+				loc = userLocation(db, alarms[i].Uid)
+				locations[alarms[i].Uid] = loc
+			}
+			setNextFireAt(&alarms[i], loc)
+			if err := db.Model(&alarms[i]).Update("next_fire_at", alarms[i].NextFireAt).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Error
+}
+
+// 다음 발송 시각 계산 (fcm-service 는 next_fire_at 인덱스로 발송 대상만 조회)
+func setNextFireAt(alarm *model.Alarm, loc *time.Location) {
+	alarm.NextFireAt = util.NextFireAt(alarm.StartAt, alarm.EndAt, alarm.Timestamp, alarm.Week, alarmRepeat(*alarm), loc, time.Now())
+}
+
+func alarmRepeat(alarm model.Alarm) util.Repeat {
+	return util.Repeat{Type: alarm.RepeatType, Every: alarm.RepeatEvery, Off: alarm.RepeatOff, Anchor: alarm.AnchorAt}
+}
+
+// 유저 시간대 (조회 실패시 기본 시간대)
+func userLocation(db *gorm.DB, uid uint) *time.Location {
+	var user model.User
+	if err := db.Select("time_zone").Where("id = ?", uid).First(&user).Error; err != nil {
+		return util.LoadUserLocation("")
+	}
+	return util.LoadUserLocation(user.TimeZone)
+}
+
 func validateAlarm(alarm dto.AlarmRequest) error {
 	if alarm.StartAt != "" {
 		if err := util.ValidateDate(alarm.StartAt); err != nil {
@@ -35,7 +76,7 @@ func validateWeek(weekdays json.RawMessage) (json.RawMessage, []int32, error) {
 	}
 
 	if len(weekdaySlice) == 0 {
-		return nil, nil, errors.New("must weekday")
+		return nil, nil, util.NewError(util.ErrInvalidRequest, "must weekday")
 	}
 	seen := make(map[int32]bool)
 	unique := []int32{}
@@ -3,7 +3,6 @@
 package transport
 
 import (
-	"alarm-service/common/util"
 	"alarm-service/dto"
 	"net/http"
 	"strconv"
@@ -11,6 +10,7 @@ import (
 
 	kitEndpoint "github.com/go-kit/kit/endpoint"
 
+	"github.com/disterbia/wellkinson/common/util"
 	"github.com/gin-gonic/gin"
 )
 
@@ -25,31 +25,33 @@ var userLocks sync.Map
 // @Param request body dto.AlarmRequest true "요청 DTO - 알람데이터, type 1:운동 2:약 3:수면"
 // @Success 200 {object} dto.BasicResponse "성공시 200 반환"
 // @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
+// @Failure 429 {object} dto.ErrorResponse "TOO_MANY_REQUESTS - 같은 사용자의 요청이 처리중"
 // @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
 // @Router /save-alarm [post]
 func SaveAlarmHandler(saveEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		id, _, err := util.VerifyJWT(c)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
 			return
 		}
 		// 사용자별 잠금 시작
 		if _, loaded := userLocks.LoadOrStore(id, true); loaded {
-			c.JSON(http.StatusTooManyRequests, gin.H{"error": "Concurrent request detected"})
+			util.AbortError(c, util.NewError(util.ErrTooManyRequests, "concurrent request detected"))
 			return
 		}
 		defer userLocks.Delete(id)
 
 		var req dto.AlarmRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			util.AbortBadRequest(c, err)
 			return
 		}
 		req.Uid = id
 		response, err := saveEndpoint(c.Request.Context(), req)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, err)
 			return
 		}
 
@@ -67,18 +69,19 @@ func SaveAlarmHandler(saveEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 // @Param request body []uint true "삭제할 id 배열"
 // @Success 200 {object} dto.BasicResponse "성공시 200 반환"
 // @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
 // @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
 // @Router /remove-alarms [post]
 func RemoveAlarmsHandler(removeEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		uid, _, err := util.VerifyJWT(c)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
 			return
 		}
 		var ids []uint // 삭제할 ID 배열
 		if err := c.ShouldBindJSON(&ids); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			util.AbortBadRequest(c, err)
 			return
 		}
 		response, err := removeEndpoint(c.Request.Context(), map[string]interface{}{
@@ -86,7 +89,7 @@ func RemoveAlarmsHandler(removeEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 			"ids": ids,
 		})
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, err)
 			return
 		}
 
@@ -104,13 +107,14 @@ func RemoveAlarmsHandler(removeEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 // @Param  page  query uint false  "페이지 번호 default 0"
 // @Success 200 {object} []dto.AlarmResponse "알람정보 - type 1:운동 2:약 3:수면"
 // @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
 // @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
 // @Router /get-presets [get]
 func GetHandler(getEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		id, _, err := util.VerifyJWT(c)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
 			return
 		}
 
@@ -119,7 +123,7 @@ func GetHandler(getEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 		if pageParam != "" {
 			parsed, err := strconv.ParseUint(pageParam, 10, 32)
 			if err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page parameter"})
+				util.AbortError(c, util.NewError(util.ErrInvalidRequest, "invalid page parameter"))
 				return
 			}
 			page = uint(parsed)
@@ -131,7 +135,7 @@ func GetHandler(getEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 			"page": page,
 		})
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, err)
 			return
 		}
 
@@ -147,19 +151,20 @@ func GetHandler(getEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 // @Param Authorization header string true "Bearer {jwt_token}"
 // @Success 200 {object} []dto.NotificationResponse "알람정보 - type 1:운동 2:약 3:수면"
 // @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
 // @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
 // @Router /get-notis [get]
 func GetNotisHandler(getEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		id, _, err := util.VerifyJWT(c)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
 			return
 		}
 
 		response, err := getEndpoint(c.Request.Context(), id)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, err)
 			return
 		}
 
@@ -176,18 +181,19 @@ func GetNotisHandler(getEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 // @Param Authorization header string true "Bearer {jwt_token}"
 // @Success 200 {object} dto.BasicResponse "성공시 200 반환"
 // @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
 // @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
 // @Router /read-notis [post]
 func ReadAllHandler(saveEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		id, _, err := util.VerifyJWT(c)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
 			return
 		}
 		response, err := saveEndpoint(c.Request.Context(), id)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, err)
 			return
 		}
 
@@ -205,18 +211,19 @@ func ReadAllHandler(saveEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 // @Param request body []uint true "삭제할 id 배열"
 // @Success 200 {object} dto.BasicResponse "성공시 200 반환"
 // @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
 // @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
 // @Router /remove-notis [post]
 func RemoveNoitsHandler(removeEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		uid, _, err := util.VerifyJWT(c)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
 			return
 		}
 		var ids []uint // 삭제할 ID 배열
 		if err := c.ShouldBindJSON(&ids); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			util.AbortBadRequest(c, err)
 			return
 		}
 		response, err := removeEndpoint(c.Request.Context(), map[string]interface{}{
@@ -224,7 +231,7 @@ func RemoveNoitsHandler(removeEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 			"ids": ids,
 		})
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, err)
 			return
 		}
 
@@ -233,3 +240,46 @@ func RemoveNoitsHandler(removeEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 
 	}
 }
+
+// @Tags 알람 /alarm
+// @Summary 알림 액션
+// @Description 푸시 알림의 확인/다시 알림/복용 버튼을 눌렀을때 호출 - type, parent_id, alarm_id, scheduled_at 은 알림 data 값 그대로 전달
+// @Description action- ack: 확인 (약 알람은 복용 처리) snooze: minutes 분 뒤 다시 알림 (기본 10분, 최대 120분) taken: 복용 (약 알람만)
+// @Description 보호자에게 온 미복용 알림(escalation)은 patient_uid 를 함께 보내면 약 쓰기 권한이 있을때 환자 대신 복용 처리 (ack, taken 만 가능)
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Bearer {jwt_token}"
+// @Param request body dto.NotificationActionRequest true "요청 DTO"
+// @Success 200 {object} dto.BasicResponse "성공시 200 반환"
+// @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
+// @Failure 403 {object} dto.ErrorResponse "FORBIDDEN - 환자의 약 쓰기 권한이 없는 보호자"
+// @Failure 404 {object} dto.ErrorResponse "NOT_FOUND - 알람 또는 약이 없거나 삭제됨"
+// @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 502 {object} dto.ErrorResponse "SERVICE_UNAVAILABLE - 복용 처리 서비스 연결 실패"
+// @Router /notification-action [post]
+func NotificationActionHandler(actionEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, _, err := util.VerifyJWT(c)
+		if err != nil {
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
+			return
+		}
+
+		var req dto.NotificationActionRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			util.AbortBadRequest(c, err)
+			return
+		}
+		req.Uid = id
+
+		response, err := actionEndpoint(c.Request.Context(), req)
+		if err != nil {
+			util.AbortError(c, err)
+			return
+		}
+
+		resp := response.(dto.BasicResponse)
+		c.JSON(http.StatusOK, resp)
+	}
+}
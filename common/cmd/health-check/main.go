@@ -0,0 +1,47 @@
+// /common/cmd/health-check/main.go
+// gRPC 헬스 체크 - SERVING 이면 0, 아니면 1 로 종료 (compose healthcheck 용, gRPC 서버가 있는 서비스 이미지에 함께 빌드)
+//
+//	health-check -addr localhost:50051 -service alarmservice.AlarmService
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func main() {
+	addr := flag.String("addr", "", "gRPC 주소 (예: localhost:50051)")
+	service := flag.String("service", "", "확인할 서비스 (비우면 서버 전체)")
+	timeout := flag.Duration("timeout", 3*time.Second, "제한 시간")
+	flag.Parse()
+	if *addr == "" {
+		log.Println("-addr is required")
+		os.Exit(1)
+	}
+
+	conn, err := grpc.Dial(*addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Println("failed to connect:", err)
+		os.Exit(1)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	response, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: *service})
+	if err != nil {
+		log.Println("health check error:", err)
+		os.Exit(1)
+	}
+	if response.Status != healthpb.HealthCheckResponse_SERVING {
+		log.Println("status:", response.Status)
+		os.Exit(1)
+	}
+}
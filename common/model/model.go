@@ -0,0 +1,558 @@
+// /common/model/model.go
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Created, Updated 는 컨테이너 TZ 와 무관하게 서버 기준 시간대(서울) 문자열로 저장
+// 유저 기준 날짜/시각 (알람 시각, 복용일 등) 은 유저의 time_zone 으로 해석
+var ServerLocation = func() *time.Location {
+	loc, err := time.LoadLocation("Asia/Seoul")
+	if err != nil {
+		return time.FixedZone("KST", 9*60*60)
+	}
+	return loc
+}()
+
+type TimestampModel struct {
+	Created string
+	Updated string
+}
+
+func (tm *TimestampModel) BeforeCreate(tx *gorm.DB) (err error) {
+	now := time.Now().In(ServerLocation).Format("2006-01-02 15:04:05")
+	if tm.Created == "" {
+		tm.Created = now
+	}
+	tm.Updated = now
+	return
+}
+
+type User struct {
+	TimestampModel
+	Id                    uint
+	IsAdmin               bool
+	Birthday              string
+	DeviceID              string `json:"device_id"`
+	Gender                bool
+	IndemnificationClause bool   `json:"indemnification_clause"`
+	FCMToken              string `json:"fcm_token"`
+	IsFirst               bool   `json:"is_first"`
+	Name                  string
+	PhoneNum              string `json:"phone_num"`
+	UseAutoLogin          bool   `json:"use_auto_login"`
+	UsePrivacyProtection  bool   `json:"use_privacy_protection"`
+	UseSleepTracking      bool   `json:"use_sleep_tracking"`
+	UserType              uint   `json:"user_type"`
+	TimeZone              string `gorm:"default:Asia/Seoul" json:"time_zone"` // IANA 시간대
+	Email                 string
+	SnsType               uint          `json:"sns_type"`
+	ProfileImage          Image         `json:"profile_image" gorm:"foreignkey:ParentId"`
+	LinkedEmails          []LinkedEmail `json:"linked_emails" gorm:"foreignkey:Uid"`
+}
+
+type Alarm struct {
+	TimestampModel
+	Id         uint
+	User       User `gorm:"foreignKey:Uid"`
+	Uid        uint
+	ParentId   uint `json:"parent_id"`
+	Type       uint
+	Body       string
+	StartAt    string ` json:"start_at"`
+	EndAt      string ` json:"end_at"`
+	Timestamp  string
+	Week       json.RawMessage `gorm:"type:json"`
+	NextFireAt string          `gorm:"index" json:"next_fire_at"` // UTC YYYY-mm-ddTHH:mmZ, 기간이 끝나면 ""
+	// 다시 알림(1회)이면 원래 알람 시각 (UTC YYYY-mm-ddTHH:mmZ), 일반 알람은 ""
+	ScheduledAt string `gorm:"default:''" json:"scheduled_at"`
+	RepeatType  uint   `gorm:"default:0" json:"repeat_type"`  // 0:요일 반복 2:N시간마다 3:N일마다 4:주기(복용/휴약)
+	RepeatEvery uint   `gorm:"default:0" json:"repeat_every"` // N시간/N일, 주기는 복용일수
+	RepeatOff   uint   `gorm:"default:0" json:"repeat_off"`   // 주기의 휴약일수
+	AnchorAt    string `gorm:"default:''" json:"anchor_at"`   // 반복 기준일 YYYY-MM-DD
+}
+
+type Notification struct {
+	TimestampModel
+	Id        uint
+	Uid       uint
+	Type      uint
+	Body      string
+	Timestamp string
+	ParentId  uint `json:"parent_id"`
+	IsRead    bool `json:"is_read"`
+}
+
+// 알람 발송 기록 - (알람, 발송 분) 당 한번만 생성되어 여러 인스턴스에서도 중복 발송 방지
+type AlarmDispatch struct {
+	TimestampModel
+	Id         uint
+	AlarmId    uint   `gorm:"uniqueIndex:idx_alarm_dispatch"`
+	FireMinute string `gorm:"uniqueIndex:idx_alarm_dispatch"` // UTC YYYY-mm-ddTHH:mmZ
+	Uid        uint
+	Status     int // 0 발송중 1 성공 2 실패
+}
+
+// 푸시 메시지별 발송 결과 - 일시적 실패는 NextRetry 이후 재시도
+type PushDelivery struct {
+	TimestampModel
+	Id         uint
+	DispatchId uint `gorm:"index"`
+	Uid        uint
+	Token      string
+	Payload    json.RawMessage `gorm:"type:json"`
+	Status     int             `gorm:"index"` // 0 발송중 1 성공 2 재시도 대기 3 실패 4 무효 토큰 5 기기별 발송 대기
+	Attempts   int
+	MessageId  string
+	Error      string
+	NextRetry  string
+}
+
+// 마지막으로 처리한 분 (재시작시 놓친 분부터 이어서 처리)
+type DispatchWatermark struct {
+	TimestampModel
+	Id         uint
+	Name       string `gorm:"uniqueIndex"`
+	LastMinute string
+}
+
+type Inquire struct {
+	TimestampModel
+	Id      uint
+	Uid     uint
+	Email   string
+	Title   string
+	Content string
+	Replies []InquireReply
+}
+
+type InquireReply struct {
+	TimestampModel
+	Id        uint
+	Uid       uint
+	InquireId uint `json:"inquire_id"`
+	ReplyType bool `json:"reply_type"`
+	Content   string
+}
+
+type DietPreset struct {
+	TimestampModel
+	Id    uint
+	Uid   uint
+	Name  string
+	Foods json.RawMessage `gorm:"type:json"`
+}
+
+type Diet struct {
+	TimestampModel
+	Id     uint
+	Uid    uint
+	Memo   string
+	Date   string
+	Time   string
+	Type   uint
+	Images []Image         `gorm:"foreignkey:ParentId"`
+	Foods  json.RawMessage `gorm:"type:json"`
+}
+
+type Image struct {
+	TimestampModel
+	Id  uint
+	Uid uint
+
+	//부모 아이디
+	ParentId uint `json:"parent_id"`
+	Type     uint
+
+	Url          string
+	ThumbnailUrl string `json:"thumbnail_url"`
+}
+
+type Emotion struct {
+	TimestampModel
+	Id      uint
+	Uid     uint
+	Emotion uint
+	State   string
+}
+
+type Exercise struct {
+	TimestampModel
+	Id              uint
+	Uid             uint
+	Title           string          `json:"title"`
+	ExerciseStartAt string          `json:"exercise_start_at"`
+	ExerciseEndAt   string          `json:"exercise_end_at"`
+	PlanStartAt     string          `json:"plan_start_at"`
+	PlanEndAt       string          `json:"plan_end_at"`
+	UseAlarm        bool            `json:"use_alarm"`
+	Weekdays        json.RawMessage `gorm:"type:json"`
+}
+
+type ExerciseInfo struct {
+	TimestampModel
+	Id            uint
+	Uid           uint
+	DatePerformed string `json:"date_performed"`
+	ExerciseId    uint   `json:"exercise_id"`
+}
+
+type FaceScore struct {
+	TimestampModel
+	Id    uint
+	Uid   uint
+	Score uint
+	Type  uint
+}
+
+type FaceExam struct {
+	TimestampModel
+	Id      uint
+	Type    uint
+	Title   string
+	VideoId string `json:"video_id"`
+}
+
+type FaceExercise struct {
+	TimestampModel
+	Id           uint
+	Type         uint
+	Title        string
+	VideoId      string `json:"video_id"`
+	GuideVideoId string `json:"guide_video_id"`
+}
+
+type Video struct {
+	TimestampModel
+	Id           uint
+	ProjectName  string `json:"project_name"`
+	Name         string
+	Duration     uint
+	ProjectId    string `json:"project_id"`
+	VideoId      string `json:"video_id"`
+	ThumbnailUrl string `json:"thumbnail_url"`
+}
+
+type Medicine struct {
+	TimestampModel
+	Id            uint
+	Uid           uint
+	Timestamp     json.RawMessage `gorm:"type:json"`
+	Weekdays      json.RawMessage `gorm:"type:json"`
+	Dose          float32
+	IntervalType  uint8   `json:"interval_type"`
+	IsActive      bool    `json:"is_active"`
+	LeastStore    float32 `json:"least_store"`
+	UseLeastStore bool    `json:"use_least_store"`
+	MedicineType  string  `json:"medicine_type"`
+	Name          string
+	Store         float32
+	StartAt       string `json:"start_at"`
+	EndAt         string `json:"end_at"`
+	UsePrivacy    bool   `json:"use_privacy"`
+	// interval_type 2:N시간마다 3:N일마다 4:주기(Interval일 복용, OffDays일 휴약) - start_at 기준
+	Interval uint            `gorm:"default:0" json:"interval"`
+	OffDays  uint            `gorm:"default:0" json:"off_days"`
+	Tapers   json.RawMessage `gorm:"type:json" json:"tapers"` // 기간별 용량 [{"start_at","end_at","dose"}]
+	// 재고 부족 알림을 보낸 날짜 (유저 시간대, 보충하면 비움)
+	RefillNotifiedAt string `gorm:"default:''" json:"refill_notified_at"`
+	DrugProductId    uint   `gorm:"default:0" json:"drug_product_id"` // 의약품 목록 연결 (0: 직접 입력)
+}
+
+// 약 보충 기록
+type MedicineRefill struct {
+	TimestampModel
+	Id         uint
+	Uid        uint `gorm:"index"`
+	MedicineId uint `gorm:"index"`
+	Amount     float32
+	Store      float32 // 보충 후 재고
+}
+
+type MedicineTake struct {
+	TimestampModel
+	Id         uint
+	Uid        uint
+	DateTaken  string `json:"date_taken"`
+	TimeTaken  string `json:"time_taken"`
+	RealTaken  string `json:"real_taken"`
+	Dose       float32
+	MedicineId uint `json:"medicine_id"`
+}
+
+type MedicineSearch struct {
+	TimestampModel
+	Id   uint
+	Name string
+}
+
+// 의약품 목록 (식약처 의약품 제품 허가정보)
+type DrugProduct struct {
+	TimestampModel
+	Id      uint
+	ItemSeq string `gorm:"uniqueIndex"` // 품목기준코드
+	Name    string `gorm:"index"`
+	Company string
+	EdiCode string // 보험코드
+	AtcCode string
+}
+
+// 의약품 주성분 - Key 는 염/수화물 표기를 뺀 성분명 (중복 성분, 상호작용 비교용)
+type DrugIngredient struct {
+	TimestampModel
+	Id            uint
+	DrugProductId uint `gorm:"index"`
+	Code          string
+	Name          string
+	NameEn        string
+	Key           string `gorm:"index"`
+}
+
+// 성분간 상호작용 - KeyA < KeyB 로 저장
+type DrugInteraction struct {
+	TimestampModel
+	Id          uint
+	KeyA        string `gorm:"uniqueIndex:idx_drug_interaction"`
+	KeyB        string `gorm:"uniqueIndex:idx_drug_interaction"`
+	Severity    uint   // 1 주의 2 병용금기
+	Description string
+}
+
+type SleepAlarm struct {
+	TimestampModel
+	Id        uint
+	Uid       uint
+	StartTime string          `json:"start_time"`
+	AlarmTime string          `json:"alarm_time"`
+	EndTime   string          `json:"end_time"`
+	Weekdays  json.RawMessage `gorm:"type:json"`
+	IsActive  bool            `json:"is_active"`
+}
+
+type SleepTime struct {
+	TimestampModel
+	Id        uint
+	Uid       uint
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+	DateSleep string `json:"date_sleep"`
+}
+
+type VocalWord struct {
+	TimestampModel
+	Id    uint
+	Type  uint
+	Title string
+}
+
+type VocalScore struct {
+	TimestampModel
+	Id    uint
+	Uid   uint
+	Score uint
+	Type  uint
+}
+
+type MainService struct {
+	TimestampModel
+	Id    uint
+	Title string
+	Level uint
+}
+
+type UserService struct {
+	TimestampModel
+	Id        uint
+	Uid       uint
+	ServiceId uint `json:"service_id"`
+	Title     string
+}
+
+type AuthCode struct {
+	TimestampModel
+	Id          uint
+	PhoneNumber string `gorm:"index"`
+	Code        string // 인증번호 해시
+	Ip          string `gorm:"index"`
+	Attempts    int
+	Invalidated bool
+}
+
+// 문자/알림톡 발송 기록 (실패시 재시도)
+type MessageDelivery struct {
+	TimestampModel
+	Id        uint
+	Receiver  string
+	Purpose   string // 메시지 템플릿 - 본문은 저장하지 않고 발송할때마다 생성
+	RefId     uint   // 템플릿이 참조하는 레코드 (auth_code: auth_codes.id)
+	Provider  string
+	Status    int `gorm:"index"` // 0 대기 1 성공 2 실패(재시도) 3 만료
+	Attempts  int
+	LastError string
+	NextRetry string
+	ExpiresAt string
+}
+
+type VerifiedNumbers struct {
+	TimestampModel
+	Id          uint
+	PhoneNumber string
+}
+
+type LinkedEmail struct {
+	TimestampModel
+	Id      uint
+	Email   string
+	Uid     uint
+	SnsType uint `json:"sns_type"`
+}
+
+type AppVersion struct {
+	TimestampModel
+	Id            uint
+	LatestVersion string `json:"latest_version"`
+	AndroidLink   string `json:"android_link"`
+	IosLink       string `json:"ios_link"`
+}
+
+// 처리한 요청의 멱등키 - 같은 키로 다시 오면 적용하지 않음
+type ProcessedRequest struct {
+	TimestampModel
+	Id     uint
+	Key    string `gorm:"uniqueIndex"`
+	Method string
+	Result json.RawMessage `gorm:"type:json"` // 같은 키로 다시 오면 돌려줄 결과 (알람 id 등)
+}
+
+// 알림 액션 기록 (확인, 다시 알림, 복용)
+type NotificationAction struct {
+	TimestampModel
+	Id          uint
+	Uid         uint `gorm:"index"`
+	Type        uint
+	ParentId    uint `json:"parent_id"`
+	Action      string
+	ScheduledAt string `json:"scheduled_at"` // 원래 알람 시각 (UTC YYYY-mm-ddTHH:mmZ)
+	SnoozeUntil string `json:"snooze_until"` // 다시 알림 시각 (UTC YYYY-mm-ddTHH:mmZ)
+}
+
+type Polices struct {
+	TimestampModel
+	Id         uint
+	Title      string `json:"title"`
+	Body       string `json:"body"`
+	PoliceType uint   `json:"police_type"`
+}
+
+type Session struct {
+	TimestampModel
+	Id               uint
+	Uid              uint
+	DeviceID         string `json:"device_id"`
+	RefreshTokenHash string `json:"refresh_token_hash"`
+	ExpiresAt        string `json:"expires_at"`
+	LastUsed         string `json:"last_used"`
+	RevokedAt        string `json:"revoked_at"`
+}
+
+// 부여된 역할 (patient, caregiver 는 user_type 으로도 부여됨)
+type UserRole struct {
+	TimestampModel
+	Id        uint
+	Uid       uint   `gorm:"uniqueIndex:idx_user_role"`
+	Role      string `gorm:"uniqueIndex:idx_user_role"`
+	GrantedBy uint   `json:"granted_by"`
+}
+
+// 보호자 연결 (환자가 초대하고 보호자가 수락)
+type CareLink struct {
+	TimestampModel
+	Id             uint
+	PatientUid     uint            `json:"patient_uid"`
+	CaregiverUid   uint            `json:"caregiver_uid"`
+	InviteCodeHash string          `json:"invite_code_hash"`
+	InviteExpires  string          `json:"invite_expires"`
+	Status         uint            // 0:초대중 1:연결됨 2:해제
+	Scopes         json.RawMessage `gorm:"type:json"` // 도메인별 권한 {"medicine":"write","sleep":"read"}
+}
+
+// 보호자가 환자 대신 수행한 쓰기 기록
+type DelegatedWrite struct {
+	TimestampModel
+	Id           uint
+	PatientUid   uint `json:"patient_uid"`
+	CaregiverUid uint `json:"caregiver_uid"`
+	Domain       string
+	Action       string
+	Status       int
+}
+
+// 관리자 로그인 정보 (비밀번호 해시, TOTP, 잠금)
+type AdminCredential struct {
+	TimestampModel
+	Id             uint
+	Uid            uint   `gorm:"uniqueIndex"`
+	PasswordHash   string `json:"-"`
+	TotpSecret     string `json:"-"`
+	TotpEnabled    bool   `json:"totp_enabled"`
+	TotpLastStep   int64  `json:"-"`
+	FailedAttempts int    `json:"failed_attempts"`
+	LockedUntil    string `json:"locked_until"`
+}
+
+// 관리자 로그인 시도 기록
+type AdminLoginAudit struct {
+	TimestampModel
+	Id      uint
+	Uid     uint
+	Email   string
+	Ip      string
+	Success bool
+	Reason  string
+}
+
+// 푸시 수신 기기 (유저당 여러 기기, 로그인시 등록/로그아웃시 해제)
+type Device struct {
+	TimestampModel
+	Id         uint
+	Uid        uint   `gorm:"uniqueIndex:idx_user_device"`
+	DeviceId   string `gorm:"uniqueIndex:idx_user_device" json:"device_id"`
+	FcmToken   string `gorm:"index" json:"fcm_token"`
+	Platform   string `json:"platform"`
+	AppVersion string `json:"app_version"`
+	LastSeen   string `gorm:"index" json:"last_seen"`
+}
+
+// 알람 변경 요청 (도메인 변경과 같은 트랜잭션에 저장, relay 가 alarm-service 로 전달)
+type AlarmOutbox struct {
+	TimestampModel
+	Id             uint
+	Source         string `gorm:"index:idx_alarm_outbox_pending"` // medicine, sleep, exercise
+	Status         int    `gorm:"index:idx_alarm_outbox_pending"` // 0 대기 1 성공 2 실패
+	ParentId       uint   `json:"parent_id"`
+	Uid            uint
+	Method         string          // ReplaceAlarms
+	Payload        json.RawMessage `gorm:"type:json"`
+	IdempotencyKey string          `gorm:"uniqueIndex" json:"idempotency_key"`
+	Attempts       int
+	NextRetry      string `json:"next_retry"` // 서버 기준 시각, 비어있으면 바로 전달
+	Error          string
+}
+
+// 약 복용 누락 에스컬레이션 - 알람 발송 후 복용 기록이 없으면 환자 재알림, 이후 보호자 알림
+type DoseEscalation struct {
+	TimestampModel
+	Id          uint
+	Uid         uint   `gorm:"index"`
+	MedicineId  uint   `gorm:"uniqueIndex:idx_dose_escalation" json:"medicine_id"`
+	DateTaken   string `gorm:"uniqueIndex:idx_dose_escalation" json:"date_taken"`
+	TimeTaken   string `gorm:"uniqueIndex:idx_dose_escalation" json:"time_taken"`
+	ScheduledAt string `json:"scheduled_at"` // 알람 시각 (UTC YYYY-mm-ddTHH:mmZ)
+	Stage       uint   // 0:복용 대기 1:환자 재알림 2:보호자 알림(누락) 3:복용/종료
+	Missed      bool
+	NextCheckAt string `gorm:"index" json:"next_check_at"` // UTC YYYY-mm-ddTHH:mmZ
+}
@@ -0,0 +1,27 @@
+// /common/util/authcode.go
+package util
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+)
+
+// 6자리 숫자 인증번호
+func GenerateAuthCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
+
+// 발송 제한에 걸린 경우 - RetryAfter 초 후 다시 요청 가능
+type CooldownError struct {
+	Reason     string
+	RetryAfter int
+}
+
+func (e *CooldownError) Error() string {
+	return e.Reason
+}
@@ -0,0 +1,48 @@
+// /common/util/care.go
+package util
+
+import (
+	"crypto/rand"
+	"math/big"
+)
+
+// 보호자에게 위임 가능한 도메인 (delegation.go 의 Domain* 상수)
+var CareDomains = []string{DomainMedicine, DomainExercise, DomainSleep, DomainDiet, DomainEmotion}
+
+// 도메인별 권한은 "read" 또는 "write" (write 는 read 포함)
+func ValidateCareScopes(scopes map[string]string) error {
+	if len(scopes) == 0 {
+		return NewError(ErrInvalidRequest, "check scopes")
+	}
+	for domain, access := range scopes {
+		valid := false
+		for _, d := range CareDomains {
+			if d == domain {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return NewError(ErrInvalidRequest, "invalid domain: "+domain)
+		}
+		if access != "read" && access != "write" {
+			return NewError(ErrInvalidRequest, "invalid access: "+access)
+		}
+	}
+	return nil
+}
+
+// 헷갈리는 문자(0,O,1,I) 를 뺀 초대 코드
+const inviteAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+func GenerateInviteCode() (string, error) {
+	code := make([]byte, 8)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(inviteAlphabet))))
+		if err != nil {
+			return "", err
+		}
+		code[i] = inviteAlphabet[n.Int64()]
+	}
+	return string(code), nil
+}
@@ -0,0 +1,28 @@
+// /common/util/care_test.go
+package util
+
+import "testing"
+
+func TestValidateCareScopes(t *testing.T) {
+	tests := []struct {
+		name    string
+		scopes  map[string]string
+		wantErr bool
+	}{
+		{"read and write", map[string]string{DomainMedicine: "write", DomainSleep: "read"}, false},
+		{"empty", map[string]string{}, true},
+		{"unknown domain", map[string]string{"finance": "read"}, true},
+		{"unknown access", map[string]string{DomainDiet: "admin"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCareScopes(tt.scopes)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateCareScopes() = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && ToAppError(err).Code != ErrInvalidRequest {
+				t.Errorf("ValidateCareScopes() code = %s, want %s", ToAppError(err).Code, ErrInvalidRequest)
+			}
+		})
+	}
+}
@@ -1,4 +1,4 @@
-// /common/util/util.go
+// /common/util/const.go
 package util
 
 var ExerciseType = 1
@@ -11,11 +11,11 @@ var UserProfileImageType = 0
 
 var DietImageType = 1
 
-var KakaoSnsType = 0
+var KakaoSnsType = 1
 
-var GoogleSnsType = 1
+var GoogleSnsType = 2
 
-var AppleSnsType = 2
+var AppleSnsType = 3
 
 var Interval_everyday = 0 //매일
 var Interval_mine = 1     // 자발적으로
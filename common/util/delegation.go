@@ -0,0 +1,138 @@
+// /common/util/delegation.go
+package util
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/disterbia/wellkinson/common/model"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// 보호자가 환자 대신 요청할때 대상 환자 uid 를 담는 헤더
+const ActingForHeader = "X-Acting-For"
+
+const actingUidKey = "acting_uid"
+
+// 위임 가능한 도메인
+const (
+	DomainMedicine = "medicine"
+	DomainExercise = "exercise"
+	DomainSleep    = "sleep"
+	DomainDiet     = "diet"
+	DomainEmotion  = "emotion"
+)
+
+// 보호자 연결 조회 및 위임 쓰기 기록 (main 에서 DB 구현 주입)
+type DelegationStore interface {
+	HasAccess(patientUid, caregiverUid uint, domain string, write bool) (bool, error)
+	RecordWrite(patientUid, caregiverUid uint, domain, action string, status int) error
+}
+
+var delegationStore DelegationStore
+
+func SetDelegationStore(store DelegationStore) {
+	delegationStore = store
+}
+
+// 보호자 연결 상태 (user-service 의 수락 상태)
+const careLinkAccepted = 1
+
+// care_links, delegated_writes 테이블을 사용하는 기본 구현
+type dbDelegationStore struct {
+	db *gorm.DB
+}
+
+func NewDelegationStore(db *gorm.DB) DelegationStore {
+	return &dbDelegationStore{db: db}
+}
+
+// 연결된 보호자이고 도메인 권한이 있는지 확인 (write 권한은 read 포함)
+func (store *dbDelegationStore) HasAccess(patientUid, caregiverUid uint, domain string, write bool) (bool, error) {
+	var links []model.CareLink
+	if err := store.db.Where("patient_uid = ? AND caregiver_uid = ? AND status = ?", patientUid, caregiverUid, careLinkAccepted).Find(&links).Error; err != nil {
+		return false, err
+	}
+
+	for _, link := range links {
+		var scopes map[string]string
+		if err := json.Unmarshal(link.Scopes, &scopes); err != nil {
+			continue
+		}
+		switch scopes[domain] {
+		case "write":
+			return true, nil
+		case "read":
+			if !write {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func (store *dbDelegationStore) RecordWrite(patientUid, caregiverUid uint, domain, action string, status int) error {
+	return store.db.Create(&model.DelegatedWrite{PatientUid: patientUid, CaregiverUid: caregiverUid, Domain: domain, Action: action, Status: status}).Error
+}
+
+// X-Acting-For 헤더가 있으면 보호자 권한을 확인하고 이후 VerifyJWT 가 환자 uid 를 반환하도록 설정
+// GET 이외의 요청은 위임 쓰기로 기록
+func DelegatedAccess(domain string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader(ActingForHeader)
+		if header == "" {
+			c.Next()
+			return
+		}
+		patientUid, err := strconv.ParseUint(header, 10, 64)
+		if err != nil || patientUid == 0 {
+			AbortError(c, NewError(ErrInvalidRequest, "invalid "+ActingForHeader))
+			return
+		}
+
+		caregiverUid, _, err := VerifyJWT(c)
+		if err != nil {
+			AbortError(c, WrapError(ErrUnauthorized, err))
+			return
+		}
+		if uint(patientUid) == caregiverUid {
+			c.Next()
+			return
+		}
+		if delegationStore == nil {
+			AbortError(c, NewError(ErrForbidden, "delegation not available"))
+			return
+		}
+
+		write := c.Request.Method != http.MethodGet
+		ok, err := delegationStore.HasAccess(uint(patientUid), caregiverUid, domain, write)
+		if err != nil {
+			AbortError(c, WrapError(ErrDatabase, err))
+			return
+		}
+		if !ok {
+			AbortError(c, NewError(ErrForbidden, "no delegated access"))
+			return
+		}
+
+		c.Set(actingUidKey, uint(patientUid))
+		c.Next()
+
+		if write {
+			if err := delegationStore.RecordWrite(uint(patientUid), caregiverUid, domain, c.Request.Method+" "+c.FullPath(), c.Writer.Status()); err != nil {
+				log.Println("delegated write audit error:", err)
+			}
+		}
+	}
+}
+
+// 보호자 위임 요청이면 DelegatedAccess 에서 확인된 환자 uid 사용
+func actingUid(c *gin.Context, uid uint) uint {
+	if v, ok := c.Get(actingUidKey); ok {
+		return v.(uint)
+	}
+	return uid
+}
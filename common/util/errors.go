@@ -0,0 +1,139 @@
+// /common/util/errors.go
+package util
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// 오류 코드 - 앱은 메시지가 아니라 코드로 분기하므로 값은 바꾸지 않음
+const (
+	ErrInvalidRequest       = "INVALID_REQUEST"
+	ErrUnauthorized         = "UNAUTHORIZED"
+	ErrForbidden            = "FORBIDDEN"
+	ErrNotFound             = "NOT_FOUND"
+	ErrConflict             = "CONFLICT"
+	ErrTooManyRequests      = "TOO_MANY_REQUESTS"
+	ErrDatabase             = "DATABASE_ERROR"
+	ErrInternal             = "INTERNAL_ERROR"
+	ErrServiceUnavailable   = "SERVICE_UNAVAILABLE"   // 게이트웨이, 서비스 간 호출에서 다른 서비스 연결 실패
+	ErrVerificationRequired = "VERIFICATION_REQUIRED" // 번호 인증 필요 (이전 "-1")
+	ErrAlreadyRegistered    = "ALREADY_REGISTERED"    // 이미 가입한 번호 (이전 "-2")
+	ErrVerificationMismatch = "VERIFICATION_MISMATCH" // 인증번호 불일치 (이전 "-1")
+	ErrVerificationExpired  = "VERIFICATION_EXPIRED"  // 인증번호 만료, 시도 횟수 초과 (이전 "-2")
+)
+
+type errorInfo struct {
+	status int
+	ko     string
+	en     string
+}
+
+// 코드별 HTTP 상태와 안내 문구
+var errorInfos = map[string]errorInfo{
+	ErrInvalidRequest:       {http.StatusBadRequest, "요청 값이 올바르지 않아요.", "The request is invalid."},
+	ErrUnauthorized:         {http.StatusUnauthorized, "로그인이 필요해요.", "Authentication is required."},
+	ErrForbidden:            {http.StatusForbidden, "권한이 없어요.", "You do not have permission."},
+	ErrNotFound:             {http.StatusNotFound, "요청한 정보를 찾을 수 없어요.", "The requested resource was not found."},
+	ErrConflict:             {http.StatusConflict, "이미 처리된 요청이에요.", "The request conflicts with the current state."},
+	ErrTooManyRequests:      {http.StatusTooManyRequests, "요청이 너무 많아요. 잠시 후 다시 시도해주세요.", "Too many requests. Please try again later."},
+	ErrDatabase:             {http.StatusInternalServerError, "일시적인 오류가 발생했어요. 잠시 후 다시 시도해주세요.", "A temporary error occurred. Please try again later."},
+	ErrInternal:             {http.StatusInternalServerError, "요청을 처리하지 못했어요.", "The request could not be processed."},
+	ErrServiceUnavailable:   {http.StatusBadGateway, "서비스에 연결할 수 없어요. 잠시 후 다시 시도해주세요.", "The service is unavailable. Please try again later."},
+	ErrVerificationRequired: {http.StatusForbidden, "휴대폰 번호 인증이 필요해요.", "Phone number verification is required."},
+	ErrAlreadyRegistered:    {http.StatusConflict, "이미 가입한 번호예요.", "This phone number is already registered."},
+	ErrVerificationMismatch: {http.StatusBadRequest, "인증번호가 일치하지 않아요.", "The verification code does not match."},
+	ErrVerificationExpired:  {http.StatusGone, "인증번호가 만료되었어요. 다시 받아주세요.", "The verification code has expired. Please request a new one."},
+}
+
+// 앱에 돌려줄 오류 - Detail 은 원인 확인용으로 직접 작성한 문구만 (DB, 외부 오류 원문은 Err 로만 보관)
+type AppError struct {
+	Code   string
+	Detail string
+	Err    error
+}
+
+func (e *AppError) Error() string {
+	if e.Detail == "" {
+		if e.Err != nil {
+			return e.Code + ": " + e.Err.Error()
+		}
+		return e.Code
+	}
+	return e.Code + ": " + e.Detail
+}
+
+func (e *AppError) Unwrap() error {
+	return e.Err
+}
+
+func NewError(code, detail string) *AppError {
+	return &AppError{Code: code, Detail: detail}
+}
+
+// err 를 code 로 감쌈 - err 가 AppError 면 그 Detail 만 유지하고, 아니면 원문은 응답에 넣지 않음
+func WrapError(code string, err error) *AppError {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return &AppError{Code: code, Detail: appErr.Detail, Err: err}
+	}
+	return &AppError{Code: code, Err: err}
+}
+
+// 오류 응답 (dto.ErrorResponse 와 같은 형태)
+type ErrorBody struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Detail  string `json:"detail,omitempty"`
+}
+
+// 서비스는 코드를 정한 AppError 를 반환 - 없는 기록은 NOT_FOUND, 그 밖의 오류는 INTERNAL (원문은 로그로만)
+func ToAppError(err error) *AppError {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &AppError{Code: ErrNotFound, Err: err}
+	}
+	return &AppError{Code: ErrInternal, Err: err}
+}
+
+// Accept-Language 가 en 으로 시작하면 영어, 아니면 한국어
+func ErrorMessage(code, language string) string {
+	info, ok := errorInfos[code]
+	if !ok {
+		info = errorInfos[ErrInternal]
+	}
+	if strings.HasPrefix(strings.ToLower(strings.TrimSpace(language)), "en") {
+		return info.en
+	}
+	return info.ko
+}
+
+func ErrorStatus(code string) int {
+	if info, ok := errorInfos[code]; ok {
+		return info.status
+	}
+	return http.StatusInternalServerError
+}
+
+// 오류 응답 후 중단 - 모든 핸들러, 미들웨어 공통
+func AbortError(c *gin.Context, err error) {
+	appErr := ToAppError(err)
+	if appErr.Err != nil && ErrorStatus(appErr.Code) >= http.StatusInternalServerError {
+		log.Printf("%s %s: %v\n", c.Request.Method, c.Request.URL.Path, appErr.Err)
+	}
+	c.AbortWithStatusJSON(ErrorStatus(appErr.Code), ErrorBody{Code: appErr.Code,
+		Message: ErrorMessage(appErr.Code, c.GetHeader("Accept-Language")), Detail: appErr.Detail})
+}
+
+// 요청 형식 오류 (바인딩, 파라미터)
+func AbortBadRequest(c *gin.Context, err error) {
+	AbortError(c, &AppError{Code: ErrInvalidRequest, Detail: err.Error(), Err: err})
+}
@@ -0,0 +1,79 @@
+// /common/util/errors_test.go
+package util
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func TestToAppError(t *testing.T) {
+	dbErr := errors.New(`ERROR: duplicate key value violates unique constraint "users_phone_num_key" (SQLSTATE 23505)`)
+	tests := []struct {
+		name       string
+		err        error
+		wantCode   string
+		wantDetail string
+	}{
+		{"typed error", NewError(ErrConflict, "already linked"), ErrConflict, "already linked"},
+		{"wrapped typed error", fmt.Errorf("save: %w", NewError(ErrInvalidRequest, "check tapers")), ErrInvalidRequest, "check tapers"},
+		{"record not found", gorm.ErrRecordNotFound, ErrNotFound, ""},
+		{"raw db error", dbErr, ErrInternal, ""},
+		{"message is not classified", errors.New("invalid something"), ErrInternal, ""},
+		{"wrapped db error", WrapError(ErrDatabase, dbErr), ErrDatabase, ""},
+		{"wrap keeps typed detail", WrapError(ErrUnauthorized, NewError(ErrInvalidRequest, "invalid token")), ErrUnauthorized, "invalid token"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ToAppError(tt.err)
+			if got.Code != tt.wantCode || got.Detail != tt.wantDetail {
+				t.Fatalf("ToAppError = %s: %q, want %s: %q", got.Code, got.Detail, tt.wantCode, tt.wantDetail)
+			}
+		})
+	}
+}
+
+func TestAbortError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name       string
+		err        error
+		language   string
+		wantStatus int
+		wantBody   ErrorBody
+	}{
+		{"typed error", NewError(ErrNotFound, "medicine not found"), "", http.StatusNotFound,
+			ErrorBody{Code: ErrNotFound, Message: ErrorMessage(ErrNotFound, ""), Detail: "medicine not found"}},
+		{"raw error hides detail", errors.New("pq: relation \"users\" does not exist"), "en", http.StatusInternalServerError,
+			ErrorBody{Code: ErrInternal, Message: ErrorMessage(ErrInternal, "en")}},
+		{"bad request keeps binding detail", nil, "", http.StatusBadRequest,
+			ErrorBody{Code: ErrInvalidRequest, Message: ErrorMessage(ErrInvalidRequest, ""), Detail: "EOF"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/test", nil)
+			c.Request.Header.Set("Accept-Language", tt.language)
+			if tt.err == nil {
+				AbortBadRequest(c, errors.New("EOF"))
+			} else {
+				AbortError(c, tt.err)
+			}
+
+			var body ErrorBody
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if w.Code != tt.wantStatus || body != tt.wantBody {
+				t.Fatalf("got %d %+v, want %d %+v", w.Code, body, tt.wantStatus, tt.wantBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+// /common/util/jwks.go
+package util
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"log"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+// user-service 가 공개하는 JWKS 로 토큰 서명 검증
+const defaultJWKSURL = "http://user:44409/.well-known/jwks.json"
+
+const (
+	jwksCacheTTL    = 10 * time.Minute
+	jwksMinInterval = 30 * time.Second // 모르는 kid 로 인한 과도한 재조회 방지
+)
+
+var (
+	jwksMu      sync.Mutex
+	jwksKeys    map[string]interface{}
+	jwksFetched time.Time
+	jwksClient  = &http.Client{Timeout: 5 * time.Second}
+)
+
+func fetchJWKS() (map[string]interface{}, error) {
+	url := os.Getenv("JWKS_URL")
+	if url == "" {
+		url = defaultJWKSURL
+	}
+	resp, err := jwksClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New("jwks status " + resp.Status)
+	}
+
+	var jwks JWKS
+	if err := json.NewDecoder(resp.Body).Decode(&jwks); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]interface{})
+	for _, jwk := range jwks.Keys {
+		key, err := parseJWK(jwk)
+		if err != nil {
+			log.Println("skip jwk", jwk.Kid, err)
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	return keys, nil
+}
+
+func parseJWK(jwk JWK) (interface{}, error) {
+	switch jwk.Kty {
+	case "RSA":
+		nBytes, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		eBytes, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: big.NewInt(0).SetBytes(nBytes), E: int(big.NewInt(0).SetBytes(eBytes).Int64())}, nil
+	case "OKP":
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		if jwk.Crv != "Ed25519" || len(x) != ed25519.PublicKeySize {
+			return nil, NewError(ErrUnauthorized, "unsupported okp key")
+		}
+		return ed25519.PublicKey(x), nil
+	}
+	return nil, errors.New("unsupported key type " + jwk.Kty)
+}
+
+// 캐시된 공개키 조회 - 만료됐거나 모르는 kid 면 다시 받아옴 (실패시 기존 캐시 사용)
+func publicKey(kid string) (interface{}, error) {
+	jwksMu.Lock()
+	defer jwksMu.Unlock()
+
+	key, ok := jwksKeys[kid]
+	elapsed := time.Since(jwksFetched)
+	if (ok && elapsed < jwksCacheTTL) || (!ok && elapsed < jwksMinInterval) {
+		if !ok {
+			return nil, NewError(ErrUnauthorized, "unknown kid")
+		}
+		return key, nil
+	}
+
+	keys, err := fetchJWKS()
+	jwksFetched = time.Now()
+	if err != nil {
+		log.Println("jwks fetch error:", err)
+		if ok {
+			return key, nil
+		}
+		return nil, NewError(ErrUnauthorized, "unknown kid")
+	}
+	jwksKeys = keys
+
+	if key, ok = jwksKeys[kid]; !ok {
+		return nil, NewError(ErrUnauthorized, "unknown kid")
+	}
+	return key, nil
+}
+
+// 토큰 헤더의 kid 로 공개키를 찾고 키 종류와 알고리즘이 맞는지 확인
+func jwksKeyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		return nil, NewError(ErrUnauthorized, "kid not found in token")
+	}
+	key, err := publicKey(kid)
+	if err != nil {
+		return nil, err
+	}
+	switch key.(type) {
+	case *rsa.PublicKey:
+		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, NewError(ErrUnauthorized, "unexpected signing method")
+		}
+	case ed25519.PublicKey:
+		if token.Method != SigningMethodEdDSA {
+			return nil, NewError(ErrUnauthorized, "unexpected signing method")
+		}
+	}
+	return key, nil
+}
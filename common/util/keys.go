@@ -0,0 +1,247 @@
+// /common/util/keys.go
+package util
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"log"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/disterbia/wellkinson/common/model"
+)
+
+// 토큰 서명키 설정
+// JWT_PRIVATE_KEY_PATH: 현재 서명키 (PEM, RSA 또는 Ed25519), JWT_KID: 현재 키 id
+// JWT_PREVIOUS_KEY_PATH, JWT_PREVIOUS_KID: 교체 전 키 (공개키 또는 개인키 PEM)
+// JWT_PREVIOUS_KEY_UNTIL: 이전 키 유예 기간 종료 시각 ("2006-01-02 15:04:05", 서버 기준 시간대)
+// JWT_EPHEMERAL_KEY=true: 개발용 - 키 설정 없이 임시 키로 서명 (재시작시 기존 토큰 무효, 복제본끼리 키가 다름)
+type signingKey struct {
+	Kid     string
+	Method  jwt.SigningMethod
+	Private crypto.Signer
+	Public  crypto.PublicKey
+	Until   time.Time // 0 이면 만료 없음
+}
+
+var (
+	currentKey  *signingKey
+	previousKey *signingKey
+)
+
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// 서명키 로드 - 키 설정이 없으면 개발용 설정이 있을때만 임시 키 생성
+func LoadSigningKeys() error {
+	path := os.Getenv("JWT_PRIVATE_KEY_PATH")
+	if path == "" {
+		if os.Getenv("JWT_EPHEMERAL_KEY") != "true" {
+			return errors.New("JWT_PRIVATE_KEY_PATH is required (set JWT_EPHEMERAL_KEY=true for development)")
+		}
+		log.Println("JWT_PRIVATE_KEY_PATH not set, using ephemeral signing key (development only)")
+		_, priv, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return err
+		}
+		kid, err := randomString(8)
+		if err != nil {
+			return err
+		}
+		currentKey = &signingKey{Kid: kid, Method: SigningMethodEdDSA, Private: priv, Public: priv.Public()}
+		return nil
+	}
+
+	key, err := loadKey(path, os.Getenv("JWT_KID"))
+	if err != nil {
+		return err
+	}
+	if key.Private == nil {
+		return errors.New("JWT_PRIVATE_KEY_PATH must be a private key")
+	}
+	currentKey = key
+
+	if path := os.Getenv("JWT_PREVIOUS_KEY_PATH"); path != "" {
+		key, err := loadKey(path, os.Getenv("JWT_PREVIOUS_KID"))
+		if err != nil {
+			return err
+		}
+		until, err := time.ParseInLocation("2006-01-02 15:04:05", os.Getenv("JWT_PREVIOUS_KEY_UNTIL"), model.ServerLocation)
+		if err != nil {
+			return errors.New("check JWT_PREVIOUS_KEY_UNTIL")
+		}
+		if key.Kid == currentKey.Kid {
+			return errors.New("JWT_PREVIOUS_KID must differ from JWT_KID")
+		}
+		key.Until = until
+		previousKey = key
+	}
+	return nil
+}
+
+func loadKey(path, kid string) (*signingKey, error) {
+	if kid == "" {
+		return nil, errors.New("kid is required for " + path)
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("invalid pem: " + path)
+	}
+
+	var parsed interface{}
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, errors.New("unsupported pem type: " + block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	key := &signingKey{Kid: kid}
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.Method, key.Private, key.Public = jwt.SigningMethodRS256, k, &k.PublicKey
+	case ed25519.PrivateKey:
+		key.Method, key.Private, key.Public = SigningMethodEdDSA, k, k.Public()
+	case *rsa.PublicKey:
+		key.Method, key.Public = jwt.SigningMethodRS256, k
+	case ed25519.PublicKey:
+		key.Method, key.Public = SigningMethodEdDSA, k
+	default:
+		return nil, errors.New("unsupported key type: " + path)
+	}
+	return key, nil
+}
+
+// 검증에 사용 가능한 키 (이전 키는 유예 기간 동안만)
+func activeKeys() []*signingKey {
+	var keys []*signingKey
+	if currentKey != nil {
+		keys = append(keys, currentKey)
+	}
+	if previousKey != nil && time.Now().Before(previousKey.Until) {
+		keys = append(keys, previousKey)
+	}
+	return keys
+}
+
+func signToken(claims jwt.MapClaims) (string, error) {
+	if currentKey == nil {
+		return "", NewError(ErrInternal, "signing key not loaded")
+	}
+	token := jwt.NewWithClaims(currentKey.Method, claims)
+	token.Header["kid"] = currentKey.Kid
+	return token.SignedString(currentKey.Private)
+}
+
+// 서명키를 가진 서비스(user-service)는 자신의 키로, 나머지 서비스는 JWKS 로 검증
+func tokenKeyFunc(token *jwt.Token) (interface{}, error) {
+	if currentKey != nil {
+		return keyFunc(token)
+	}
+	return jwksKeyFunc(token)
+}
+
+// 토큰 헤더의 kid 와 알고리즘으로 검증키 선택
+func keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	for _, key := range activeKeys() {
+		if key.Kid != kid {
+			continue
+		}
+		if token.Method.Alg() != key.Method.Alg() {
+			return nil, NewError(ErrUnauthorized, "unexpected signing method")
+		}
+		return key.Public, nil
+	}
+	return nil, NewError(ErrUnauthorized, "unknown kid")
+}
+
+// /.well-known/jwks.json 으로 공개하는 키 목록
+func GetJWKS() JWKS {
+	jwks := JWKS{Keys: []JWK{}}
+	for _, key := range activeKeys() {
+		jwk := JWK{Kid: key.Kid, Use: "sig", Alg: key.Method.Alg()}
+		switch k := key.Public.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(k.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(k)
+		}
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+	return jwks
+}
+
+// EdDSA(Ed25519) 서명 방식 - jwt-go v3 에 없어서 직접 등록
+type signingMethodEd25519 struct{}
+
+var SigningMethodEdDSA = &signingMethodEd25519{}
+
+func init() {
+	jwt.RegisterSigningMethod(SigningMethodEdDSA.Alg(), func() jwt.SigningMethod {
+		return SigningMethodEdDSA
+	})
+}
+
+func (m *signingMethodEd25519) Alg() string {
+	return "EdDSA"
+}
+
+func (m *signingMethodEd25519) Verify(signingString, signature string, key interface{}) error {
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return jwt.ErrInvalidKeyType
+	}
+	sig, err := jwt.DecodeSegment(signature)
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(publicKey, []byte(signingString), sig) {
+		return jwt.ErrSignatureInvalid
+	}
+	return nil
+}
+
+func (m *signingMethodEd25519) Sign(signingString string, key interface{}) (string, error) {
+	privateKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return "", jwt.ErrInvalidKeyType
+	}
+	return jwt.EncodeSegment(ed25519.Sign(privateKey, []byte(signingString))), nil
+}
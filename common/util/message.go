@@ -0,0 +1,236 @@
+// /common/util/message.go
+package util
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 문자/알림톡 발송 추상화 - MESSAGE_PROVIDER 로 선택
+type MessageSender interface {
+	Name() string
+	Send(receiver, text string) error
+}
+
+// 설정에 맞는 발송기 생성
+// MESSAGE_PROVIDER: alimtalk(기본), sms, file, memory
+// MESSAGE_FALLBACK: sms 로 설정하면 기본 발송 실패시 문자로 재발송
+func NewMessageSender() (MessageSender, error) {
+	var sender MessageSender
+	switch provider := os.Getenv("MESSAGE_PROVIDER"); provider {
+	case "", "alimtalk":
+		sender = NewAlimTalkSender()
+	case "sms":
+		sender = NewSMSSender()
+	case "file":
+		path := os.Getenv("MESSAGE_FILE_PATH")
+		if path == "" {
+			path = "messages.log"
+		}
+		sender = &FileSender{Path: path}
+	case "memory":
+		sender = &MemorySender{}
+	default:
+		return nil, errors.New("unknown message provider: " + provider)
+	}
+
+	if os.Getenv("MESSAGE_FALLBACK") == "sms" && sender.Name() != "sms" {
+		sender = &FallbackSender{Primary: sender, Fallback: NewSMSSender()}
+	}
+	return sender, nil
+}
+
+var messageHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// 알리고 카카오 알림톡
+type AlimTalkSender struct {
+	ApiKey    string
+	UserId    string
+	Token     string
+	SenderKey string
+	TplCode   string
+	Sender    string
+	Subject   string
+}
+
+func NewAlimTalkSender() *AlimTalkSender {
+	return &AlimTalkSender{
+		ApiKey:    os.Getenv("API_KEY"),
+		UserId:    os.Getenv("USER_ID"),
+		Token:     os.Getenv("TOKEN"),
+		SenderKey: os.Getenv("SENDER_KEY"),
+		TplCode:   os.Getenv("TPL_CODE"),
+		Sender:    os.Getenv("SENDER"),
+		Subject:   os.Getenv("SUBJECT_1"),
+	}
+}
+
+func (s *AlimTalkSender) Name() string {
+	return "alimtalk"
+}
+
+func (s *AlimTalkSender) Send(receiver, text string) error {
+	data := url.Values{}
+	data.Set("apikey", s.ApiKey)
+	data.Set("userid", s.UserId)
+	data.Set("token", s.Token)
+	data.Set("senderkey", s.SenderKey)
+	data.Set("tpl_code", s.TplCode)
+	data.Set("sender", s.Sender)
+	data.Set("subject_1", s.Subject)
+	data.Set("receiver_1", receiver)
+	data.Set("message_1", text)
+
+	// 응답 code 가 0 이면 성공
+	var result struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+	if err := postForm("https://kakaoapi.aligo.in/akv10/alimtalk/send/", data, &result); err != nil {
+		return err
+	}
+	if result.Code != 0 {
+		return fmt.Errorf("alimtalk error %d: %s", result.Code, result.Message)
+	}
+	return nil
+}
+
+// 알리고 문자 (SMS)
+type SMSSender struct {
+	ApiKey string
+	UserId string
+	Sender string
+}
+
+func NewSMSSender() *SMSSender {
+	return &SMSSender{ApiKey: os.Getenv("API_KEY"), UserId: os.Getenv("USER_ID"), Sender: os.Getenv("SENDER")}
+}
+
+func (s *SMSSender) Name() string {
+	return "sms"
+}
+
+func (s *SMSSender) Send(receiver, text string) error {
+	data := url.Values{}
+	data.Set("key", s.ApiKey)
+	data.Set("user_id", s.UserId)
+	data.Set("sender", s.Sender)
+	data.Set("receiver", receiver)
+	data.Set("msg", text)
+	data.Set("msg_type", "SMS")
+
+	// 응답 result_code 가 양수면 성공
+	var result struct {
+		ResultCode json.Number `json:"result_code"`
+		Message    string      `json:"message"`
+	}
+	if err := postForm("https://apis.aligo.in/send/", data, &result); err != nil {
+		return err
+	}
+	if code, err := strconv.Atoi(result.ResultCode.String()); err != nil || code <= 0 {
+		return fmt.Errorf("sms error %s: %s", result.ResultCode, result.Message)
+	}
+	return nil
+}
+
+func postForm(apiURL string, data url.Values, result interface{}) error {
+	resp, err := messageHTTPClient.PostForm(apiURL, data)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("server returned non-200 status: %d, body: %s", resp.StatusCode, string(body))
+	}
+	if err := json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("invalid response: %s", string(body))
+	}
+	return nil
+}
+
+// 기본 발송 실패시 대체 발송
+type FallbackSender struct {
+	Primary  MessageSender
+	Fallback MessageSender
+}
+
+func (s *FallbackSender) Name() string {
+	return s.Primary.Name() + "+" + s.Fallback.Name()
+}
+
+func (s *FallbackSender) Send(receiver, text string) error {
+	err := s.Primary.Send(receiver, text)
+	if err == nil {
+		return nil
+	}
+	if fallbackErr := s.Fallback.Send(receiver, text); fallbackErr != nil {
+		return fmt.Errorf("%s: %v, %s: %v", s.Primary.Name(), err, s.Fallback.Name(), fallbackErr)
+	}
+	return nil
+}
+
+// 개발용 - 파일에 발송 내용 기록
+type FileSender struct {
+	Path string
+	mu   sync.Mutex
+}
+
+func (s *FileSender) Name() string {
+	return "file"
+}
+
+func (s *FileSender) Send(receiver, text string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = fmt.Fprintf(f, "%s\t%s\t%s\n", time.Now().Format("2006-01-02 15:04:05"), receiver, strings.ReplaceAll(text, "\n", " "))
+	return err
+}
+
+// 테스트용 - 메모리에 발송 내용 보관
+type SentMessage struct {
+	Receiver string
+	Text     string
+}
+
+type MemorySender struct {
+	mu       sync.Mutex
+	messages []SentMessage
+}
+
+func (s *MemorySender) Name() string {
+	return "memory"
+}
+
+func (s *MemorySender) Send(receiver, text string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = append(s.messages, SentMessage{Receiver: receiver, Text: text})
+	return nil
+}
+
+func (s *MemorySender) Sent() []SentMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]SentMessage(nil), s.messages...)
+}
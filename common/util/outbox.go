@@ -0,0 +1,254 @@
+// /common/util/outbox.go
+package util
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"time"
+
+	"github.com/disterbia/wellkinson/common/model"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	_ "google.golang.org/grpc/health"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"gorm.io/gorm"
+)
+
+// 알람 변경 요청 상태
+const (
+	outboxPending = 0
+	outboxSent    = 1
+	outboxFailed  = 2
+)
+
+const (
+	outboxBatch          = 100
+	outboxMaxAttempts    = 10
+	outboxRetryBase      = 5 * time.Second // 5, 10, 20초... 최대 30분 간격으로 재시도
+	outboxRetryMax       = 30 * time.Minute
+	outboxSendTimeout    = 10 * time.Second // 재시도 포함
+	outboxLease          = 2 * time.Minute  // 가져간 요청을 다른 인스턴스가 다시 가져가지 않는 시간 (전달 중 종료되면 이후 다시 전달)
+	outboxDrainRounds    = 1000
+	idempotencyKeyHeader = "idempotency-key"
+	outboxTimeLayout     = "2006-01-02 15:04:05"
+)
+
+// 알람 변경 방법 - 각 서비스 proto 의 alarmservice.AlarmService 메서드
+const (
+	alarmServiceName    = "alarmservice.AlarmService"
+	ReplaceAlarmsMethod = "ReplaceAlarms"
+)
+
+// 알람 변경 요청 저장 - 도메인 변경과 같은 트랜잭션(tx)으로 호출해야 함께 저장/취소됨
+// source 는 요청한 서비스 (medicine, sleep, exercise)
+func EnqueueAlarm(tx *gorm.DB, source string, parentId, uid uint, method string, message proto.Message) error {
+	payload, err := protojson.Marshal(message)
+	if err != nil {
+		return err
+	}
+	key := make([]byte, 16)
+	if _, err := rand.Read(key); err != nil {
+		return err
+	}
+	return tx.Create(&model.AlarmOutbox{Source: source, ParentId: parentId, Uid: uid, Method: method, Payload: payload,
+		IdempotencyKey: source + ":" + hex.EncodeToString(key)}).Error
+}
+
+// alarm-service 호출 설정 - 시도마다 3초 제한, Unavailable 이면 최대 3번 더 시도 (멱등키가 있어 다시 보내도 한번만 적용)
+// 헬스 체크로 준비된 연결에만 요청
+const alarmServiceConfig = `{
+	"methodConfig": [{
+		"name": [{"service": "alarmservice.AlarmService"}],
+		"timeout": "3s",
+		"retryPolicy": {
+			"maxAttempts": 4,
+			"initialBackoff": "0.2s",
+			"maxBackoff": "2s",
+			"backoffMultiplier": 2,
+			"retryableStatusCodes": ["UNAVAILABLE"]
+		}
+	}],
+	"healthCheckConfig": {"serviceName": "alarmservice.AlarmService"}
+}`
+
+func DialAlarm(addr string) (*grpc.ClientConn, error) {
+	return grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithDefaultServiceConfig(alarmServiceConfig))
+}
+
+// 저장된 알람 변경 요청을 alarm-service 로 전달
+// 요청 메시지 타입은 서비스가 불러온 proto 패키지에서 찾으므로 각 서비스의 proto 를 import 한 곳에서 사용
+type AlarmRelay struct {
+	db     *gorm.DB
+	conn   *grpc.ClientConn
+	source string
+}
+
+func NewAlarmRelay(db *gorm.DB, conn *grpc.ClientConn, source string) *AlarmRelay {
+	return &AlarmRelay{db: db, conn: conn, source: source}
+}
+
+// 대기중인 요청을 저장 순서대로 전달하고 전달한 건수 반환
+// 보낼 요청을 가져가 표시(next_retry 를 lease 시각으로)하고 커밋한 뒤 트랜잭션 밖에서 전달, 결과는 요청마다 따로 저장
+// 실패한 요청은 늦춰서 재시도하고, 그동안 같은 기록의 다음 요청은 보내지 않음 (순서 유지)
+// 결과 저장 전에 종료되면 lease 가 지난 뒤 다시 보내지만 alarm-service 가 멱등키로 한번만 적용
+func (relay *AlarmRelay) Relay() (int, error) {
+	rows, lease, err := relay.claim()
+	if err != nil || len(rows) == 0 {
+		return 0, err
+	}
+
+	sent := 0
+	failed := make(map[uint]bool)
+	deadline := time.Now().Add(outboxLease - outboxSendTimeout)
+	for _, v := range rows {
+		// 앞 요청이 실패했거나 lease 가 끝나가면 다음 차례로 돌려놓음
+		if failed[v.ParentId] || time.Now().After(deadline) {
+			if err := relay.record(v, lease, map[string]interface{}{"next_retry": ""}); err != nil {
+				return sent, err
+			}
+			continue
+		}
+
+		now := time.Now().In(model.ServerLocation)
+		attempts := v.Attempts + 1
+		updates := map[string]interface{}{"attempts": attempts, "status": outboxSent, "error": "", "next_retry": ""}
+		if err := relay.deliver(v); err != nil {
+			failed[v.ParentId] = true
+			updates["error"] = err.Error()
+			if attempts < outboxMaxAttempts && retryableStatus(err) {
+				updates["status"] = outboxPending
+				updates["next_retry"] = now.Add(retryDelay(attempts)).Format(outboxTimeLayout)
+			} else {
+				updates["status"] = outboxFailed
+				log.Printf("alarm outbox %d failed after %d attempts: %v\n", v.Id, attempts, err)
+			}
+		} else {
+			sent++
+		}
+		if err := relay.record(v, lease, updates); err != nil {
+			return sent, err
+		}
+	}
+	return sent, nil
+}
+
+// 보낼 수 있는 요청을 가져가 lease 시각까지 다른 인스턴스가 가져가지 않도록 표시
+// 가져가는 동안만 advisory lock 으로 한 인스턴스씩 처리
+func (relay *AlarmRelay) claim() ([]model.AlarmOutbox, string, error) {
+	var claimed []model.AlarmOutbox
+	now := time.Now().In(model.ServerLocation)
+	lease := now.Add(outboxLease).Format(outboxTimeLayout)
+	err := relay.db.Transaction(func(tx *gorm.DB) error {
+		var locked bool
+		if err := tx.Raw("SELECT pg_try_advisory_xact_lock(hashtext(?))", "alarm_outbox:"+relay.source).Scan(&locked).Error; err != nil {
+			return err
+		}
+		if !locked {
+			return nil
+		}
+
+		var rows []model.AlarmOutbox
+		if err := tx.Where("source = ? AND status = ?", relay.source, outboxPending).Order("id").Limit(outboxBatch).Find(&rows).Error; err != nil {
+			return err
+		}
+		claimed = dueOutboxRows(rows, now.Format(outboxTimeLayout))
+		if len(claimed) == 0 {
+			return nil
+		}
+		ids := make([]uint, 0, len(claimed))
+		for _, v := range claimed {
+			ids = append(ids, v.Id)
+		}
+		return tx.Model(&model.AlarmOutbox{}).Where("id IN ?", ids).Update("next_retry", lease).Error
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return claimed, lease, nil
+}
+
+// 저장 순서(id)대로 정렬된 대기 요청 중 지금 보낼 요청 - 재시도 대기중이거나 전달 중인 요청이 있는 기록은 그 뒤 요청도 제외
+func dueOutboxRows(rows []model.AlarmOutbox, now string) []model.AlarmOutbox {
+	var due []model.AlarmOutbox
+	blocked := make(map[uint]bool)
+	for _, v := range rows {
+		if blocked[v.ParentId] {
+			continue
+		}
+		if v.NextRetry != "" && v.NextRetry > now {
+			blocked[v.ParentId] = true
+			continue
+		}
+		due = append(due, v)
+	}
+	return due
+}
+
+// 결과 저장 - lease 가 지나 다른 인스턴스가 다시 가져갔으면 저장하지 않음
+func (relay *AlarmRelay) record(row model.AlarmOutbox, lease string, updates map[string]interface{}) error {
+	return relay.db.Transaction(func(tx *gorm.DB) error {
+		return tx.Model(&model.AlarmOutbox{}).Where("id = ? AND status = ? AND next_retry = ?", row.Id, outboxPending, lease).Updates(updates).Error
+	})
+}
+
+// 보낼 수 있는 요청이 없을 때까지 전달 (재시도 대기중인 요청은 남음)
+func (relay *AlarmRelay) Drain() (int, error) {
+	total := 0
+	for i := 0; i < outboxDrainRounds; i++ {
+		sent, err := relay.Relay()
+		total += sent
+		if err != nil || sent == 0 {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// 잘못된 요청, 이미 있는 알람처럼 다시 보내도 실패하는 응답은 바로 실패 처리
+func retryableStatus(err error) bool {
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.AlreadyExists, codes.FailedPrecondition, codes.NotFound:
+		return false
+	}
+	return true
+}
+
+func retryDelay(attempts int) time.Duration {
+	delay := outboxRetryBase << (attempts - 1)
+	if delay <= 0 || delay > outboxRetryMax {
+		return outboxRetryMax
+	}
+	return delay
+}
+
+func (relay *AlarmRelay) deliver(row model.AlarmOutbox) error {
+	if row.Method != ReplaceAlarmsMethod {
+		return status.Error(codes.InvalidArgument, "unknown method "+row.Method)
+	}
+	messageType, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName("alarmservice." + row.Method + "Request"))
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	request := messageType.New().Interface()
+	if err := protojson.Unmarshal(row.Payload, request); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if field := request.ProtoReflect().Descriptor().Fields().ByName("idempotency_key"); field != nil {
+		request.ProtoReflect().Set(field, protoreflect.ValueOfString(row.IdempotencyKey))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), outboxSendTimeout)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, idempotencyKeyHeader, row.IdempotencyKey)
+	// 응답 내용은 쓰지 않음
+	return relay.conn.Invoke(ctx, "/"+alarmServiceName+"/"+row.Method, request, &emptypb.Empty{})
+}
@@ -0,0 +1,83 @@
+// /common/util/outbox_test.go
+package util
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/disterbia/wellkinson/common/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, 5 * time.Second},
+		{2, 10 * time.Second},
+		{4, 40 * time.Second},
+		{9, 1280 * time.Second},
+		{10, outboxRetryMax},
+		{100, outboxRetryMax},
+	}
+	for _, tt := range tests {
+		if got := retryDelay(tt.attempts); got != tt.want {
+			t.Errorf("retryDelay(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestRetryableStatus(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{status.Error(codes.Unavailable, "db unavailable"), true},
+		{status.Error(codes.Aborted, "db conflict"), true},
+		{status.Error(codes.DeadlineExceeded, "timeout"), true},
+		{status.Error(codes.Internal, "db error"), true},
+		{errors.New("connection reset"), true},
+		{status.Error(codes.InvalidArgument, "invalid alarm"), false},
+		{status.Error(codes.AlreadyExists, "already exists"), false},
+		{status.Error(codes.FailedPrecondition, "precondition"), false},
+		{status.Error(codes.NotFound, "not found"), false},
+	}
+	for _, tt := range tests {
+		if got := retryableStatus(tt.err); got != tt.want {
+			t.Errorf("retryableStatus(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestDueOutboxRows(t *testing.T) {
+	now := "2024-05-01 12:00:00"
+	later := "2024-05-01 12:01:00"
+	earlier := "2024-05-01 11:59:00"
+
+	tests := []struct {
+		name string
+		rows []model.AlarmOutbox
+		want []uint
+	}{
+		{"all due in order", []model.AlarmOutbox{{Id: 1, ParentId: 10}, {Id: 2, ParentId: 10}, {Id: 3, ParentId: 20}}, []uint{1, 2, 3}},
+		{"retry time passed", []model.AlarmOutbox{{Id: 1, ParentId: 10, NextRetry: earlier}, {Id: 2, ParentId: 10}}, []uint{1, 2}},
+		{"waiting blocks later rows of the same parent", []model.AlarmOutbox{{Id: 1, ParentId: 10, NextRetry: later}, {Id: 2, ParentId: 10}, {Id: 3, ParentId: 20}}, []uint{3}},
+		{"retry due now", []model.AlarmOutbox{{Id: 1, ParentId: 10, NextRetry: now}}, []uint{1}},
+		{"nothing due", []model.AlarmOutbox{{Id: 1, ParentId: 10, NextRetry: later}}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []uint
+			for _, v := range dueOutboxRows(tt.rows, now) {
+				got = append(got, v.Id)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("dueOutboxRows = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
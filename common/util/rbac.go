@@ -0,0 +1,105 @@
+// /common/util/rbac.go
+package util
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 역할
+const (
+	RolePatient      = "patient"
+	RoleCaregiver    = "caregiver"
+	RoleClinician    = "clinician"
+	RoleContentAdmin = "content-admin"
+	RoleSuperAdmin   = "super-admin"
+)
+
+// 권한
+const (
+	PermVideoManage   = "video:manage"   // 운동 영상 관리
+	PermInquireManage = "inquire:manage" // 전체 문의 조회, 답변
+	PermRoleManage    = "role:manage"    // 역할 부여, 회수
+)
+
+// 역할별 권한 (super-admin 은 모든 권한)
+var rolePermissions = map[string][]string{
+	RolePatient:      {},
+	RoleCaregiver:    {},
+	RoleClinician:    {},
+	RoleContentAdmin: {PermVideoManage, PermInquireManage},
+	RoleSuperAdmin:   {PermVideoManage, PermInquireManage, PermRoleManage},
+}
+
+func IsValidRole(role string) bool {
+	_, ok := rolePermissions[role]
+	return ok
+}
+
+func HasPermission(roles []string, permission string) bool {
+	for _, role := range roles {
+		for _, p := range rolePermissions[role] {
+			if p == permission {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// 요청자의 역할 조회 (게이트웨이 헤더 또는 토큰의 roles 클레임)
+func VerifyRoles(c *gin.Context) ([]string, error) {
+	if fromGateway(c) && c.GetHeader(UserIdHeader) != "" {
+		return splitRoles(c.GetHeader(UserRolesHeader)), nil
+	}
+
+	claims, err := parseClaims(c)
+	if err != nil {
+		return nil, err
+	}
+	return RolesFromClaims(claims), nil
+}
+
+// roles 클레임이 없는 이전 토큰은 is_admin 으로 판단
+func RolesFromClaims(claims map[string]interface{}) []string {
+	var roles []string
+	if list, ok := claims["roles"].([]interface{}); ok {
+		for _, v := range list {
+			if role, ok := v.(string); ok && IsValidRole(role) {
+				roles = append(roles, role)
+			}
+		}
+		return roles
+	}
+	if isAdmin, _ := claims["is_admin"].(bool); isAdmin {
+		roles = append(roles, RoleSuperAdmin)
+	}
+	return roles
+}
+
+func splitRoles(header string) []string {
+	var roles []string
+	for _, role := range strings.Split(header, ",") {
+		if role = strings.TrimSpace(role); IsValidRole(role) {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+// 라우트별 권한 검사 미들웨어
+func RequirePermission(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		roles, err := VerifyRoles(c)
+		if err != nil {
+			AbortError(c, WrapError(ErrUnauthorized, err))
+			return
+		}
+		if !HasPermission(roles, permission) {
+			AbortError(c, NewError(ErrForbidden, "forbidden"))
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,187 @@
+// /common/util/schedule.go
+package util
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// 알람 발송 시각 형식 (UTC, 분 단위) - 문자열 비교로 정렬되도록 고정 길이
+const FireLayout = "2006-01-02T15:04Z"
+
+// 유저 시간대 변경시 재계산 표시 (항상 발송 시각이 지난 값)
+const RecomputeFireAt = "0000-01-01T00:00Z"
+
+// 반복 방식 (약 interval_type 과 같은 값, 1 은 필요시 복용이라 알람 없음)
+const (
+	RepeatWeekly = 0 // 요일 반복
+	RepeatHours  = 2 // 기준 시각부터 N시간마다
+	RepeatDays   = 3 // 기준일부터 N일마다
+	RepeatCycle  = 4 // 기준일부터 N일 복용, Off일 휴약 반복
+)
+
+// 요일 반복 외의 반복 규칙 - Anchor 는 기준일 (YYYY-MM-DD, 없으면 시작일)
+type Repeat struct {
+	Type   uint
+	Every  uint
+	Off    uint
+	Anchor string
+}
+
+// after 이후(분 단위, after 가 속한 분 제외) 가장 가까운 발송 시각 (UTC)
+// 시작/종료일, 알람 시각, 요일, 기준일은 유저 시간대(loc) 기준
+// 기간이 끝났거나 반복 규칙이 잘못되었으면 "" 반환
+func NextFireAt(startAt, endAt, timestamp string, week json.RawMessage, repeat Repeat, loc *time.Location, after time.Time) string {
+	clock, err := time.Parse("15:04", timestamp)
+	if err != nil {
+		return ""
+	}
+	if repeat.Anchor == "" {
+		repeat.Anchor = startAt
+	}
+	var weekdays []int
+	if repeat.Type == RepeatWeekly {
+		if err := json.Unmarshal(week, &weekdays); err != nil || len(weekdays) == 0 {
+			return ""
+		}
+	} else if repeat.Every == 0 || repeat.Anchor == "" {
+		return ""
+	}
+
+	after = after.Truncate(time.Minute).In(loc)
+	if repeat.Type == RepeatHours {
+		return nextHourlyFireAt(startAt, endAt, clock, repeat, loc, after)
+	}
+
+	day := time.Date(after.Year(), after.Month(), after.Day(), 0, 0, 0, 0, loc)
+	if startAt != "" {
+		if start, err := time.ParseInLocation("2006-01-02", startAt, loc); err == nil && start.After(day) {
+			day = start
+		}
+	}
+
+	// 한 주기 안에 반드시 다음 시각이 있음 (주기 + 오늘)
+	span := 8
+	switch repeat.Type {
+	case RepeatDays:
+		span = int(repeat.Every) + 1
+	case RepeatCycle:
+		span = int(repeat.Every+repeat.Off) + 1
+	}
+	for i := 0; i < span; i++ {
+		date := day.AddDate(0, 0, i)
+		if endAt != "" && date.Format("2006-01-02") > endAt {
+			return ""
+		}
+		if !IsRepeatDay(date, weekdays, repeat) {
+			continue
+		}
+		fire := localClock(date, clock, loc)
+		if fire.After(after) {
+			return fire.UTC().Format(FireLayout)
+		}
+	}
+	return ""
+}
+
+// 해당 날짜가 반복 규칙상 알람(복용)일인지 - N시간마다는 매일로 취급
+func IsRepeatDay(date time.Time, weekdays []int, repeat Repeat) bool {
+	switch repeat.Type {
+	case RepeatWeekly:
+		for _, v := range weekdays {
+			if time.Weekday(v) == date.Weekday() {
+				return true
+			}
+		}
+		return false
+	case RepeatHours:
+		return true
+	}
+
+	days := daysSinceAnchor(date, repeat.Anchor)
+	if days < 0 || repeat.Every == 0 {
+		return false
+	}
+	if repeat.Type == RepeatDays {
+		return days%int(repeat.Every) == 0
+	}
+	return days%int(repeat.Every+repeat.Off) < int(repeat.Every)
+}
+
+// 해당 날짜의 N시간마다 알람 시각 (HH:mm) - 기준일의 clock 부터 벽시계 기준으로 계산
+func HourlyTimes(date time.Time, timestamp string, repeat Repeat) []string {
+	clock, err := time.Parse("15:04", timestamp)
+	if err != nil || repeat.Every == 0 {
+		return nil
+	}
+	base, err := time.Parse("2006-01-02", repeat.Anchor)
+	if err != nil {
+		return nil
+	}
+	base = base.Add(time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute)
+	dayStart := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	step := time.Duration(repeat.Every) * time.Hour
+
+	fire := firstStepAtOrAfter(base, step, dayStart)
+	var times []string
+	for ; fire.Before(dayStart.AddDate(0, 0, 1)); fire = fire.Add(step) {
+		times = append(times, fire.Format("15:04"))
+	}
+	return times
+}
+
+func nextHourlyFireAt(startAt, endAt string, clock time.Time, repeat Repeat, loc *time.Location, after time.Time) string {
+	base, err := time.Parse("2006-01-02", repeat.Anchor)
+	if err != nil {
+		return ""
+	}
+	base = base.Add(time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute)
+	step := time.Duration(repeat.Every) * time.Hour
+
+	// 벽시계 시각을 UTC 로 옮겨 계산 (서머타임 무시)
+	from := time.Date(after.Year(), after.Month(), after.Day(), after.Hour(), after.Minute(), 0, 0, time.UTC).Add(time.Minute)
+	if startAt != "" {
+		if start, err := time.Parse("2006-01-02", startAt); err == nil && start.After(from) {
+			from = start
+		}
+	}
+
+	// 서머타임으로 같은 벽시계 시각이 지난 시각일 수 있어 몇 번 더 확인
+	for fire := firstStepAtOrAfter(base, step, from); ; fire = fire.Add(step) {
+		if endAt != "" && fire.Format("2006-01-02") > endAt {
+			return ""
+		}
+		local := time.Date(fire.Year(), fire.Month(), fire.Day(), fire.Hour(), fire.Minute(), 0, 0, loc)
+		if local.After(after) {
+			return local.UTC().Format(FireLayout)
+		}
+	}
+}
+
+// 날짜의 clock 시각 (loc 기준)
+// 서머타임으로 건너뛴 시각 (예: 02:30) 은 time.Date 가 이전 오프셋으로 해석해 한 시간 이른 01:30 이 되므로 건너뛴 만큼 옮겨 03:30 으로 맞춤
+func localClock(date, clock time.Time, loc *time.Location) time.Time {
+	fire := time.Date(date.Year(), date.Month(), date.Day(), clock.Hour(), clock.Minute(), 0, 0, loc)
+	if gap := (clock.Hour()*60 + clock.Minute()) - (fire.Hour()*60 + fire.Minute()); gap != 0 {
+		fire = fire.Add(time.Duration((gap%1440+1440)%1440) * time.Minute)
+	}
+	return fire
+}
+
+// base + k*step (k>=0) 중 from 이상인 첫 시각
+func firstStepAtOrAfter(base time.Time, step time.Duration, from time.Time) time.Time {
+	if !from.After(base) {
+		return base
+	}
+	k := (from.Sub(base) + step - 1) / step
+	return base.Add(k * step)
+}
+
+func daysSinceAnchor(date time.Time, anchor string) int {
+	start, err := time.Parse("2006-01-02", anchor)
+	if err != nil {
+		return -1
+	}
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	return int(day.Sub(start).Hours() / 24)
+}
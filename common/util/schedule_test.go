@@ -0,0 +1,79 @@
+// /common/util/schedule_test.go
+package util
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNextFireAt(t *testing.T) {
+	seoul, err := time.LoadLocation("Asia/Seoul")
+	if err != nil {
+		t.Fatal(err)
+	}
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		startAt   string
+		endAt     string
+		timestamp string
+		week      string
+		repeat    Repeat
+		loc       *time.Location
+		after     time.Time
+		want      string
+	}{
+		{"next weekday", "2024-05-01", "", "09:00", "[1]", Repeat{}, seoul, time.Date(2024, 5, 1, 0, 0, 0, 0, seoul), "2024-05-06T00:00Z"},
+		{"later today", "2024-05-01", "", "09:00", "[3]", Repeat{}, seoul, time.Date(2024, 5, 1, 8, 0, 0, 0, seoul), "2024-05-01T00:00Z"},
+		{"fire minute excluded", "2024-05-01", "", "09:00", "[3]", Repeat{}, seoul, time.Date(2024, 5, 1, 9, 0, 30, 0, seoul), "2024-05-08T00:00Z"},
+		{"period ended", "2024-05-01", "2024-05-05", "09:00", "[1]", Repeat{}, seoul, time.Date(2024, 5, 1, 0, 0, 0, 0, seoul), ""},
+		{"no weekdays", "2024-05-01", "", "09:00", "[]", Repeat{}, seoul, time.Date(2024, 5, 1, 0, 0, 0, 0, seoul), ""},
+		{"invalid timestamp", "2024-05-01", "", "9시", "[1]", Repeat{}, seoul, time.Date(2024, 5, 1, 0, 0, 0, 0, seoul), ""},
+		{"future start", "2024-06-01", "", "09:00", "", Repeat{Type: RepeatDays, Every: 1}, seoul, time.Date(2024, 5, 1, 0, 0, 0, 0, seoul), "2024-06-01T00:00Z"},
+		{"every 3 days", "2024-05-01", "", "09:00", "", Repeat{Type: RepeatDays, Every: 3}, seoul, time.Date(2024, 5, 2, 0, 0, 0, 0, seoul), "2024-05-04T00:00Z"},
+		{"every 0 days", "2024-05-01", "", "09:00", "", Repeat{Type: RepeatDays}, seoul, time.Date(2024, 5, 2, 0, 0, 0, 0, seoul), ""},
+		{"cycle off days", "2024-05-01", "", "09:00", "", Repeat{Type: RepeatCycle, Every: 2, Off: 3}, seoul, time.Date(2024, 5, 2, 10, 0, 0, 0, seoul), "2024-05-06T00:00Z"},
+		{"every 8 hours", "2024-05-01", "", "06:00", "", Repeat{Type: RepeatHours, Every: 8}, seoul, time.Date(2024, 5, 1, 15, 0, 0, 0, seoul), "2024-05-01T13:00Z"},
+		// 02:30 은 없는 시각이므로 01:30 EST 가 아닌 03:30 EDT
+		{"skipped by dst", "2024-03-01", "", "02:30", "", Repeat{Type: RepeatDays, Every: 1}, newYork, time.Date(2024, 3, 10, 0, 0, 0, 0, newYork), "2024-03-10T07:30Z"},
+		{"after dst", "2024-03-01", "", "09:00", "", Repeat{Type: RepeatDays, Every: 1}, newYork, time.Date(2024, 3, 10, 0, 0, 0, 0, newYork), "2024-03-10T13:00Z"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NextFireAt(tt.startAt, tt.endAt, tt.timestamp, json.RawMessage(tt.week), tt.repeat, tt.loc, tt.after)
+			if got != tt.want {
+				t.Fatalf("NextFireAt = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHourlyTimes(t *testing.T) {
+	tests := []struct {
+		name      string
+		date      time.Time
+		timestamp string
+		repeat    Repeat
+		want      []string
+	}{
+		{"divides a day", time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC), "06:00", Repeat{Type: RepeatHours, Every: 8, Anchor: "2024-05-01"}, []string{"06:00", "14:00", "22:00"}},
+		{"anchor day", time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), "06:00", Repeat{Type: RepeatHours, Every: 8, Anchor: "2024-05-01"}, []string{"06:00", "14:00", "22:00"}},
+		{"carries over days", time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC), "22:00", Repeat{Type: RepeatHours, Every: 5, Anchor: "2024-05-01"}, []string{"03:00", "08:00", "13:00", "18:00", "23:00"}},
+		{"before anchor", time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC), "22:00", Repeat{Type: RepeatHours, Every: 5, Anchor: "2024-05-01"}, nil},
+		{"every 0 hours", time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC), "06:00", Repeat{Type: RepeatHours, Anchor: "2024-05-01"}, nil},
+		{"invalid anchor", time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC), "06:00", Repeat{Type: RepeatHours, Every: 8}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HourlyTimes(tt.date, tt.timestamp, tt.repeat); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("HourlyTimes = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
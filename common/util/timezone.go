@@ -0,0 +1,42 @@
+// /common/util/timezone.go
+package util
+
+import (
+	"sync"
+	"time"
+)
+
+// 시간대가 없는 기존 유저는 서울 기준
+const DefaultTimeZone = "Asia/Seoul"
+
+var locationCache sync.Map
+
+// IANA 시간대 이름 검사 (예: Asia/Seoul, America/New_York)
+func ValidateTimeZone(name string) error {
+	if name == "" || name == "Local" {
+		return NewError(ErrInvalidRequest, "invalid time zone")
+	}
+	if _, err := time.LoadLocation(name); err != nil {
+		return NewError(ErrInvalidRequest, "invalid time zone")
+	}
+	return nil
+}
+
+// 유저 시간대 - 비어있거나 잘못된 값이면 기본 시간대
+func LoadUserLocation(name string) *time.Location {
+	if name == "" {
+		name = DefaultTimeZone
+	}
+	if loc, ok := locationCache.Load(name); ok {
+		return loc.(*time.Location)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		if name == DefaultTimeZone {
+			return time.FixedZone("KST", 9*60*60)
+		}
+		return LoadUserLocation(DefaultTimeZone)
+	}
+	locationCache.Store(name, loc)
+	return loc
+}
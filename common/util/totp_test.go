@@ -0,0 +1,75 @@
+// /common/util/totp_test.go
+package util
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+)
+
+// RFC 6238 부록 B 의 SHA1 테스트 값 (8자리 중 뒤 6자리)
+var rfc6238Secret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestTOTPCode(t *testing.T) {
+	key := []byte("12345678901234567890")
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+	for _, tt := range tests {
+		if got := totpCode(key, tt.unix/totpPeriod); got != tt.want {
+			t.Errorf("totpCode(%d) = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestVerifyTOTP(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	current := now.Unix() / totpPeriod
+
+	tests := []struct {
+		name     string
+		secret   string
+		code     string
+		lastStep int64
+		wantStep int64
+		wantOk   bool
+	}{
+		{"current step", rfc6238Secret, "050471", 0, current, true},
+		{"lowercase secret", strings.ToLower(rfc6238Secret), "050471", 0, current, true},
+		{"previous step within skew", rfc6238Secret, totpCode([]byte("12345678901234567890"), current-1), 0, current - 1, true},
+		{"next step within skew", rfc6238Secret, totpCode([]byte("12345678901234567890"), current+1), 0, current + 1, true},
+		{"outside skew", rfc6238Secret, totpCode([]byte("12345678901234567890"), current-2), 0, 0, false},
+		{"step already used", rfc6238Secret, "050471", current, 0, false},
+		{"wrong code", rfc6238Secret, "000000", 0, 0, false},
+		{"wrong length", rfc6238Secret, "50471", 0, 0, false},
+		{"invalid secret", "not base32!", "050471", 0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := verifyTOTPAt(tt.secret, tt.code, tt.lastStep, now)
+			if ok != tt.wantOk || step != tt.wantStep {
+				t.Fatalf("verifyTOTPAt = (%d, %v), want (%d, %v)", step, ok, tt.wantStep, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestGenerateTOTPSecret(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil || len(key) != 20 {
+		t.Fatalf("secret %q decodes to %d bytes, %v", secret, len(key), err)
+	}
+}
@@ -0,0 +1,272 @@
+// /common/util/util.go
+package util
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/disterbia/wellkinson/common/model"
+	"github.com/gin-gonic/gin"
+)
+
+// 토큰 유효 기간
+const (
+	AccessTokenTTL  = 30 * time.Minute
+	RefreshTokenTTL = 30 * 24 * time.Hour
+)
+
+// 게이트웨이가 전달하는 신원 헤더
+const (
+	GatewayTokenHeader = "X-Gateway-Token"
+	UserIdHeader       = "X-User-Id"
+	UserEmailHeader    = "X-User-Email"
+	UserRolesHeader    = "X-User-Roles"
+	SessionIdHeader    = "X-Session-Id"
+)
+
+var (
+	gatewayOnce   sync.Once
+	trustGateway  bool
+	gatewaySecret string
+)
+
+// 폐기된 세션인지 확인하는 함수 (main 에서 등록)
+var revocationChecker func(sid uint) bool
+
+func SetRevocationChecker(checker func(sid uint) bool) {
+	revocationChecker = checker
+}
+
+// TRUST_GATEWAY=true 이고 GATEWAY_SECRET 이 일치하는 요청만 게이트웨이 요청으로 인정
+func fromGateway(c *gin.Context) bool {
+	gatewayOnce.Do(func() {
+		trustGateway = os.Getenv("TRUST_GATEWAY") == "true"
+		gatewaySecret = os.Getenv("GATEWAY_SECRET")
+	})
+	if !trustGateway || gatewaySecret == "" {
+		return false
+	}
+	token := c.GetHeader(GatewayTokenHeader)
+	return subtle.ConstantTimeCompare([]byte(token), []byte(gatewaySecret)) == 1
+}
+
+// 게이트웨이 내부 호출 여부
+func IsGatewayRequest(c *gin.Context) bool {
+	return fromGateway(c)
+}
+
+// 게이트웨이가 검증한 신원 헤더 조회
+func gatewayIdentity(c *gin.Context) (uint, string, uint, bool) {
+	if !fromGateway(c) {
+		return 0, "", 0, false
+	}
+	id, err := strconv.ParseUint(c.GetHeader(UserIdHeader), 10, 64)
+	email := c.GetHeader(UserEmailHeader)
+	if err != nil || id == 0 || email == "" {
+		return 0, "", 0, false
+	}
+	sid, _ := strconv.ParseUint(c.GetHeader(SessionIdHeader), 10, 64)
+	return uint(id), email, uint(sid), true
+}
+
+// 토큰 검증 후 유저 id, email 반환 - 보호자 위임 요청(DelegatedAccess)이면 환자 uid
+func VerifyJWT(c *gin.Context) (uint, string, error) {
+	id, email, _, err := VerifySession(c)
+	if err != nil {
+		return 0, "", err
+	}
+	return actingUid(c, id), email, nil
+}
+
+// 토큰 검증 후 유저 id, email, 세션 id 반환 (세션 없는 이전 토큰은 sid 0)
+func VerifySession(c *gin.Context) (uint, string, uint, error) {
+	// 게이트웨이를 거친 요청이면 게이트웨이가 검증한 신원을 사용
+	id, email, sid, ok := gatewayIdentity(c)
+	if ok {
+		if sid != 0 && revocationChecker != nil && revocationChecker(sid) {
+			return 0, "", 0, NewError(ErrUnauthorized, "revoked token")
+		}
+		return id, email, sid, nil
+	}
+
+	claims, err := parseClaims(c)
+	if err != nil {
+		return 0, "", 0, err
+	}
+
+	fid, _ := claims["id"].(float64)
+	fsid, _ := claims["sid"].(float64)
+	email, _ = claims["email"].(string)
+	id = uint(fid)
+	sid = uint(fsid)
+	if email == "" || id == 0 {
+		return 0, "", 0, NewError(ErrUnauthorized, "id or email not found in token")
+	}
+	return id, email, sid, nil
+}
+
+// Authorization 헤더의 JWT 서명 및 세션 폐기 여부 검증 후 claims 반환
+func parseClaims(c *gin.Context) (jwt.MapClaims, error) {
+	// 헤더에서 JWT 토큰 추출
+	return ParseToken(c.GetHeader("Authorization"))
+}
+
+// Authorization 헤더 값(Bearer 토큰)의 서명 및 세션 폐기 여부 검증 후 claims 반환 (게이트웨이도 사용)
+func ParseToken(tokenString string) (jwt.MapClaims, error) {
+	if tokenString == "" {
+		return nil, NewError(ErrUnauthorized, "authorization header is required")
+	}
+
+	// 'Bearer ' 접두사 제거
+	tokenString = strings.TrimPrefix(tokenString, "Bearer ")
+
+	claims := jwt.MapClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, tokenKeyFunc)
+
+	if err != nil || !token.Valid {
+		return nil, NewError(ErrUnauthorized, "invalid token")
+	}
+
+	fsid, _ := claims["sid"].(float64)
+	if sid := uint(fsid); sid != 0 && revocationChecker != nil && revocationChecker(sid) {
+		return nil, NewError(ErrUnauthorized, "revoked token")
+	}
+	return claims, nil
+}
+
+// 세션에 묶인 access 토큰 발급
+func GenerateJWT(user model.User, roles []string, sid uint) (string, error) {
+	jti, err := randomString(16)
+	if err != nil {
+		return "", err
+	}
+	now := time.Now()
+	tokenString, err := signToken(jwt.MapClaims{
+		"id":    user.Id,
+		"email": user.Email,
+		"roles": roles,
+		"sid":   sid,
+		"jti":   jti,
+		"iat":   now.Unix(),
+		"exp":   now.Add(AccessTokenTTL).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return tokenString, nil
+}
+
+// refresh 토큰 생성 ("세션id.랜덤값"), DB 에는 해시만 저장
+func GenerateRefreshToken(sid uint) (string, string, error) {
+	secret, err := randomString(32)
+	if err != nil {
+		return "", "", err
+	}
+	token := strconv.FormatUint(uint64(sid), 10) + "." + secret
+	return token, HashToken(token), nil
+}
+
+// refresh 토큰에서 세션 id 추출
+func ParseRefreshToken(token string) (uint, error) {
+	parts := strings.SplitN(token, ".", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return 0, NewError(ErrUnauthorized, "invalid refresh token")
+	}
+	sid, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil || sid == 0 {
+		return 0, NewError(ErrUnauthorized, "invalid refresh token")
+	}
+	return uint(sid), nil
+}
+
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func ValidateDate(dateStr string) error {
+	_, err := time.Parse("2006-01-02", dateStr)
+	if err != nil {
+		return NewError(ErrInvalidRequest, "invalid date format, should be YYYY-MM-DD")
+	}
+	return nil
+}
+
+func ValidateTime(timeStr string) error {
+	if len(timeStr) != 5 {
+		return NewError(ErrInvalidRequest, "invalid time format, should be HH:MM")
+	}
+	_, err := time.Parse("15:04", timeStr)
+	if err != nil {
+		return NewError(ErrInvalidRequest, "invalid time format, should be HH:MM")
+	}
+	return nil
+}
+
+func ValidatePhoneNumber(phone string) error {
+	// 정규 표현식 패턴: 010으로 시작하며 총 11자리 숫자
+	pattern := `^010\d{8}$`
+	matched, err := regexp.MatchString(pattern, phone)
+	if err != nil || !matched {
+		return NewError(ErrInvalidRequest, "invalid phone format, should be 01000000000")
+	}
+	return nil
+}
+
+func CopyStruct(input interface{}, output interface{}) error {
+	jsonData, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(jsonData, output)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func DecodeJwt(tokenString string) string {
+	token, _, err := new(jwt.Parser).ParseUnverified(tokenString, jwt.MapClaims{})
+	if err != nil {
+		log.Println(err)
+	}
+
+	// MapClaims 타입으로 주장(claims) 확인
+	if claims, ok := token.Claims.(jwt.MapClaims); ok {
+		// 'iss' 주장 확인
+		if iss, ok := claims["iss"].(string); ok {
+			fmt.Println("Issuer (iss):", iss)
+			return iss
+		} else {
+			fmt.Println("'iss' 주장이 없습니다.")
+			return ""
+		}
+	} else {
+		log.Println("주장을 MapClaims로 변환할 수 없습니다.")
+		return ""
+	}
+}
@@ -0,0 +1,7 @@
+// /common/version.go
+
+// 서비스 공통 모듈 (GORM 모델, JWT, 검증, 공통 오류 응답)
+// 각 서비스 go.mod 에서 버전을 지정해 사용 - 변경시 Version 을 올리고 common/vX.Y.Z 태그
+package common
+
+const Version = "v1.0.0"
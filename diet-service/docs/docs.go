@@ -62,6 +62,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "요청 처리 실패시 오류 메시지 반환",
                         "schema": {
@@ -124,6 +130,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "요청 처리 실패시 오류 메시지 반환",
                         "schema": {
@@ -135,7 +147,7 @@ const docTemplate = `{
         },
         "/remove-diet": {
             "post": {
-                "description": "추가한 식단 삭제시 호출",
+                "description": "식단 삭제시 호출",
                 "consumes": [
                     "application/json"
                 ],
@@ -145,7 +157,7 @@ const docTemplate = `{
                 "tags": [
                     "식단 /diet"
                 ],
-                "summary": "추가한 식단 삭제",
+                "summary": "식단 삭제",
                 "parameters": [
                     {
                         "type": "string",
@@ -180,6 +192,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "요청 처리 실패시 오류 메시지 반환",
                         "schema": {
@@ -189,7 +207,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/remove-preset": {
+        "/remove-presets": {
             "post": {
                 "description": "추가한 식단 삭제시 호출",
                 "consumes": [
@@ -236,6 +254,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "요청 처리 실패시 오류 메시지 반환",
                         "schema": {
@@ -286,6 +310,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "TOO_MANY_REQUESTS - 같은 사용자의 요청이 처리중",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "요청 처리 실패시 오류 메시지 반환",
                         "schema": {
@@ -336,6 +372,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "TOO_MANY_REQUESTS - 같은 사용자의 요청이 처리중",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "요청 처리 실패시 오류 메시지 반환",
                         "schema": {
@@ -355,6 +403,47 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.DietCopy": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "string",
+                    "example": "YYYY-mm-ddTHH:mm:ss "
+                },
+                "date": {
+                    "type": "string",
+                    "example": "YYYY-MM-DD"
+                },
+                "foods": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ImageResponse"
+                    }
+                },
+                "memo": {
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "string",
+                    "example": "YYYY-mm-ddTHH:mm:ss "
+                }
+            }
+        },
         "dto.DietPresetRequest": {
             "type": "object",
             "properties": {
@@ -400,6 +489,10 @@ const docTemplate = `{
         "dto.DietRequest": {
             "type": "object",
             "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "YYYY-MM-DD"
+                },
                 "foods": {
                     "type": "array",
                     "items": {
@@ -418,7 +511,7 @@ const docTemplate = `{
                         "base64 encoding string"
                     ]
                 },
-                "name": {
+                "memo": {
                     "type": "string"
                 },
                 "time": {
@@ -433,45 +526,35 @@ const docTemplate = `{
         "dto.DietResponse": {
             "type": "object",
             "properties": {
-                "created": {
+                "date": {
                     "type": "string",
-                    "example": "YYYY-mm-ddTHH:mm:ss "
+                    "example": "YYYY-MM-DD"
                 },
-                "foods": {
+                "diets": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/dto.DietCopy"
                     }
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "images": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.ImageResponse"
-                    }
-                },
-                "name": {
-                    "type": "string"
-                },
-                "time": {
-                    "type": "string"
-                },
-                "type": {
-                    "type": "integer"
-                },
-                "updated": {
-                    "type": "string",
-                    "example": "YYYY-mm-ddTHH:mm:ss "
                 }
             }
         },
         "dto.ErrorResponse": {
             "type": "object",
             "properties": {
-                "err": {
-                    "type": "string"
+                "code": {
+                    "description": "INVALID_REQUEST(400), UNAUTHORIZED(401), FORBIDDEN(403), NOT_FOUND(404), CONFLICT(409), TOO_MANY_REQUESTS(429), DATABASE_ERROR(500), INTERNAL_ERROR(500)",
+                    "type": "string",
+                    "example": "INVALID_REQUEST"
+                },
+                "detail": {
+                    "description": "원인 (디버깅용, 변경될 수 있음)",
+                    "type": "string",
+                    "example": "invalid date format, should be YYYY-MM-DD"
+                },
+                "message": {
+                    "description": "사용자 안내 문구",
+                    "type": "string",
+                    "example": "요청 값이 올바르지 않아요."
                 }
             }
         },
@@ -14,6 +14,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/disterbia/wellkinson/common/util"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 	swaggerFiles "github.com/swaggo/files"
@@ -60,6 +61,9 @@ func main() {
 	removeDietsEndpoint := endpoint.RemoveDietsEndpoint(svc)
 
 	router := gin.Default()
+	// 보호자 위임 접근 (X-Acting-For)
+	util.SetDelegationStore(util.NewDelegationStore(database))
+	router.Use(util.DelegatedAccess(util.DomainDiet))
 	router.POST("/save-preset", transport.SavePresetHandler(savePresetEndpoint))
 	router.POST("/remove-presets", transport.RemovePresetHandler(removePresetsEndpoint))
 	router.POST("/save-diet", transport.SaveDietHandler(saveDietEndpoint))
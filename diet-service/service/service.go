@@ -2,8 +2,6 @@
 package service
 
 import (
-	"diet-service/common/model"
-	"diet-service/common/util"
 	"diet-service/dto"
 	"encoding/base64"
 	"errors"
@@ -18,6 +16,8 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/s3"
 
+	"github.com/disterbia/wellkinson/common/model"
+	"github.com/disterbia/wellkinson/common/util"
 	"gorm.io/gorm"
 )
 
@@ -174,13 +174,13 @@ func (service *dietService) SaveDiet(dietRequest dto.DietRequest) (string, error
 
 			imgData, err := base64.StdEncoding.DecodeString(imgStr)
 			if err != nil {
-				errorsChan <- fmt.Errorf("base64 decoding error: %v", err)
+				errorsChan <- util.NewError(util.ErrInvalidRequest, "invalid image")
 				return
 			}
 
 			contentType, ext, err := getImageFormat(imgData)
 			if err != nil {
-				errorsChan <- fmt.Errorf("invalid image format: %v", err)
+				errorsChan <- err
 				return
 			}
 
@@ -224,14 +224,16 @@ func (service *dietService) SaveDiet(dietRequest dto.DietRequest) (string, error
 	close(errorsChan)
 	close(uploadedFiles)
 
-	// 업로드 중 에러 확인 및 처리
-	var uploadErrorOccurred bool
+	// 업로드 중 에러 확인 및 처리 - 잘못된 이미지는 요청 오류로 응답
+	var uploadErr error
 	for err := range errorsChan {
-		uploadErrorOccurred = true
 		fmt.Println(err) // 에러 로깅
+		if uploadErr == nil || util.ToAppError(err).Code == util.ErrInvalidRequest {
+			uploadErr = err
+		}
 	}
 
-	if uploadErrorOccurred {
+	if uploadErr != nil {
 		tx.Rollback()
 
 		// 이미 업로드된 파일들을 S3에서 삭제
@@ -240,7 +242,10 @@ func (service *dietService) SaveDiet(dietRequest dto.DietRequest) (string, error
 				deleteFromS3(file, service.s3svc, service.bucket, service.bucketUrl)
 			}
 		}()
-		return "", fmt.Errorf("error occurred during image upload")
+		if util.ToAppError(uploadErr).Code == util.ErrInvalidRequest {
+			return "", uploadErr
+		}
+		return "", util.NewError(util.ErrInternal, "image upload error")
 	}
 
 	// Diet 객체에 이미지 정보 추가
@@ -272,7 +277,7 @@ func (service *dietService) SaveDiet(dietRequest dto.DietRequest) (string, error
 						deleteFromS3(file, service.s3svc, service.bucket, service.bucketUrl)
 					}
 				}()
-				return "", errors.New("db error2")
+				return "", util.NewError(util.ErrDatabase, "db error2")
 			}
 		}
 
@@ -287,7 +292,7 @@ func (service *dietService) SaveDiet(dietRequest dto.DietRequest) (string, error
 						deleteFromS3(file, service.s3svc, service.bucket, service.bucketUrl)
 					}
 				}()
-				return "", errors.New("db error")
+				return "", util.NewError(util.ErrDatabase, "db error")
 			}
 
 			//이미지 레코드 재 생성
@@ -302,7 +307,7 @@ func (service *dietService) SaveDiet(dietRequest dto.DietRequest) (string, error
 						deleteFromS3(file, service.s3svc, service.bucket, service.bucketUrl)
 					}
 				}()
-				return "", errors.New("db error2")
+				return "", util.NewError(util.ErrDatabase, "db error2")
 			}
 		}
 
@@ -331,14 +336,14 @@ func (service *dietService) RemoveDiets(ids []uint, uid uint) (string, error) {
 
 	if result.Error != nil {
 		tx.Rollback()
-		return "", errors.New("db error")
+		return "", util.NewError(util.ErrDatabase, "db error")
 	}
 
 	result = tx.Model(&model.Image{}).Where("parent_id IN (?)", ids).Select("level").Updates(map[string]interface{}{"level": 10})
 
 	if result.Error != nil {
 		tx.Rollback()
-		return "", errors.New("db error2")
+		return "", util.NewError(util.ErrDatabase, "db error2")
 	}
 
 	tx.Commit()
@@ -389,7 +394,7 @@ func (service *dietService) SavePreset(presetRequest dto.DietPresetRequest) (str
 			return "", err
 		}
 	} else if result.Error != nil {
-		return "", errors.New("db error")
+		return "", util.NewError(util.ErrDatabase, "db error")
 	} else {
 		// 레코드가 존재하면 업데이트
 		if err := service.db.Model(&dietPreset).Updates(dietPreset).Error; err != nil {
@@ -404,7 +409,7 @@ func (service *dietService) RemovePresets(ids []uint, uid uint) (string, error)
 	result := service.db.Where("id IN (?) AND uid= ?", ids, uid).Delete(&model.DietPreset{})
 
 	if result.Error != nil {
-		return "", errors.New("db error")
+		return "", util.NewError(util.ErrDatabase, "db error")
 	}
 	return "200", nil
 }
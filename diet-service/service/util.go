@@ -12,6 +12,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/disterbia/wellkinson/common/util"
 	"github.com/google/uuid"
 	"github.com/nfnt/resize"
 )
@@ -147,7 +148,7 @@ func createThumbnail(data []byte) ([]byte, error) {
 func getImageFormat(imgData []byte) (contentType, extension string, err error) {
 	_, format, err := image.DecodeConfig(bytes.NewReader(imgData))
 	if err != nil {
-		return "", "", err
+		return "", "", util.NewError(util.ErrInvalidRequest, "invalid image")
 	}
 	switch format {
 	case "jpeg":
@@ -163,7 +164,7 @@ func getImageFormat(imgData []byte) (contentType, extension string, err error) {
 		contentType = "image/wepb"
 		extension = ".wepb"
 	default:
-		return "", "", fmt.Errorf("unsupported image format: %s", format)
+		return "", "", util.NewError(util.ErrInvalidRequest, "unsupported image format: "+format)
 	}
 
 	return contentType, extension, nil
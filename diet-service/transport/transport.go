@@ -2,11 +2,11 @@
 package transport
 
 import (
-	"diet-service/common/util"
 	"diet-service/dto"
 	"net/http"
 	"sync"
 
+	"github.com/disterbia/wellkinson/common/util"
 	"github.com/gin-gonic/gin"
 	kitEndpoint "github.com/go-kit/kit/endpoint"
 )
@@ -21,33 +21,35 @@ var userLocks sync.Map
 // @Param request body dto.DietPresetRequest true "요청 DTO - 추가한 식단 데이터"
 // @Success 200 {object} dto.BasicResponse "성공시 200 반환"
 // @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
+// @Failure 429 {object} dto.ErrorResponse "TOO_MANY_REQUESTS - 같은 사용자의 요청이 처리중"
 // @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
 // @Router /save-preset [post]
 func SavePresetHandler(savePresetEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		id, _, err := util.VerifyJWT(c)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
 			return
 		}
 
 		// 사용자별 잠금 시작
 		if _, loaded := userLocks.LoadOrStore(id, true); loaded {
-			c.JSON(http.StatusTooManyRequests, gin.H{"error": "Concurrent request detected"})
+			util.AbortError(c, util.NewError(util.ErrTooManyRequests, "concurrent request detected"))
 			return
 		}
 		defer userLocks.Delete(id)
 
 		var req dto.DietPresetRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			util.AbortBadRequest(c, err)
 			return
 		}
 
 		req.Uid = id
 		response, err := savePresetEndpoint(c.Request.Context(), req)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, err)
 			return
 		}
 
@@ -66,19 +68,20 @@ func SavePresetHandler(savePresetEndpoint kitEndpoint.Endpoint) gin.HandlerFunc
 // @Param  end_date  query string  false  "종료날짜 yyyy-mm-dd"
 // @Success 200 {object} []dto.DietPresetResponse "식단정보"
 // @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
 // @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
 // @Router /get-presets [get]
 func GetPresetsHandler(getEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		id, _, err := util.VerifyJWT(c)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
 			return
 		}
 
 		var queryParams dto.GetPresetParams
 		if err := c.ShouldBindQuery(&queryParams); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			util.AbortBadRequest(c, err)
 			return
 		}
 
@@ -88,7 +91,7 @@ func GetPresetsHandler(getEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 			"queryParams": queryParams,
 		})
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, err)
 			return
 		}
 
@@ -106,18 +109,19 @@ func GetPresetsHandler(getEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 // @Param request body []uint true "삭제할 id 배열"
 // @Success 200 {object} dto.BasicResponse "성공시 200 반환"
 // @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
 // @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
 // @Router /remove-presets [post]
 func RemovePresetHandler(removeEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		uid, _, err := util.VerifyJWT(c)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
 			return
 		}
 		var ids []uint // 삭제할 ID 배열
 		if err := c.ShouldBindJSON(&ids); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			util.AbortBadRequest(c, err)
 			return
 		}
 		response, err := removeEndpoint(c.Request.Context(), map[string]interface{}{
@@ -125,7 +129,7 @@ func RemovePresetHandler(removeEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 			"ids": ids,
 		})
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, err)
 			return
 		}
 
@@ -143,33 +147,35 @@ func RemovePresetHandler(removeEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 // @Param request body dto.DietRequest true "요청 DTO - 식단데이터 type - 아침/점심/저녁/간식 1/2/3/4"
 // @Success 200 {object} dto.BasicResponse "성공시 200 반환"
 // @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
+// @Failure 429 {object} dto.ErrorResponse "TOO_MANY_REQUESTS - 같은 사용자의 요청이 처리중"
 // @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
 // @Router /save-diet [post]
 func SaveDietHandler(saveEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		id, _, err := util.VerifyJWT(c)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
 			return
 		}
 
 		// 사용자별 잠금 시작
 		if _, loaded := userLocks.LoadOrStore(id, true); loaded {
-			c.JSON(http.StatusTooManyRequests, gin.H{"error": "Concurrent request detected"})
+			util.AbortError(c, util.NewError(util.ErrTooManyRequests, "concurrent request detected"))
 			return
 		}
 		defer userLocks.Delete(id)
 
 		var req dto.DietRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			util.AbortBadRequest(c, err)
 			return
 		}
 
 		req.Uid = id
 		response, err := saveEndpoint(c.Request.Context(), req)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, err)
 			return
 		}
 
@@ -187,19 +193,20 @@ func SaveDietHandler(saveEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 // @Param  end_date  query string  false  "종료날짜 yyyy-mm-dd"
 // @Success 200 {object} []dto.DietResponse "식단정보 type - 아침/점심/저녁/간식 1/2/3/4"
 // @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
 // @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
 // @Router /get-diets [get]
 func GetDietsHandler(getEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		id, _, err := util.VerifyJWT(c)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
 			return
 		}
 
 		var queryParams dto.GetPresetParams
 		if err := c.ShouldBindQuery(&queryParams); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			util.AbortBadRequest(c, err)
 			return
 		}
 
@@ -209,7 +216,7 @@ func GetDietsHandler(getEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 			"queryParams": queryParams,
 		})
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, err)
 			return
 		}
 
@@ -227,19 +234,20 @@ func GetDietsHandler(getEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 // @Param request body []uint true "삭제할 id 배열"
 // @Success 200 {object} dto.BasicResponse "성공시 200 반환"
 // @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
 // @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
 // @Router /remove-diet [post]
 func RemoveDietHandler(removeEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		uid, _, err := util.VerifyJWT(c)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
 			return
 		}
 
 		var ids []uint // 삭제할 ID 배열
 		if err := c.ShouldBindJSON(&ids); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			util.AbortBadRequest(c, err)
 			return
 		}
 
@@ -248,7 +256,7 @@ func RemoveDietHandler(removeEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 			"ids": ids,
 		})
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, err)
 			return
 		}
 
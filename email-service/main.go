@@ -9,6 +9,8 @@ import (
 
 	"github.com/joho/godotenv"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 func main() {
@@ -22,6 +24,10 @@ func main() {
 	}
 	grpcServer := grpc.NewServer()
 	pb.RegisterEmailServiceServer(grpcServer, &service.EmailServer{})
+	// 헬스 체크 (compose 준비 확인, 클라이언트 연결 확인)
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("emailservice.EmailService", healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
 
 	if err := grpcServer.Serve(lis); err != nil {
 		log.Fatalf("failed to serve: %v", err)
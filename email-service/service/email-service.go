@@ -4,11 +4,16 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/smtp"
+	"net/textproto"
 	"os"
 
 	pb "email-service/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // 필요한 import 선언
@@ -18,6 +23,9 @@ type EmailServer struct {
 }
 
 func (s *EmailServer) SendEmail(ctx context.Context, req *pb.EmailRequest) (*pb.EmailResponse, error) {
+	if req.Email == "" {
+		return nil, status.Error(codes.InvalidArgument, "email required")
+	}
 	// SMTP 설정
 	email := os.Getenv("WELLKINSON_SMTP_EMAIL")
 	password := os.Getenv("WELLKINSON_SMTP_PASSWORD")
@@ -46,8 +54,22 @@ func (s *EmailServer) SendEmail(ctx context.Context, req *pb.EmailRequest) (*pb.
 	// 이메일 전송
 	err := smtp.SendMail(smtpHost+":"+smtpPort, auth, email, []string{req.Email}, msg)
 	if err != nil {
-		return nil, err
+		return nil, smtpStatus(err)
 	}
 
 	return &pb.EmailResponse{Status: "Success"}, nil
 }
+
+// SMTP 오류를 gRPC 상태로 - 4xx 응답과 연결 실패만 다시 시도할 수 있도록 Unavailable
+// 5xx 는 다시 보내도 실패하므로 받는 주소 오류는 InvalidArgument, 인증/정책 오류 등은 FailedPrecondition
+func smtpStatus(err error) error {
+	var smtpErr *textproto.Error
+	if !errors.As(err, &smtpErr) || smtpErr.Code < 500 {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	switch smtpErr.Code {
+	case 501, 550, 551, 553: // 잘못된 주소, 없는 메일함
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return status.Error(codes.FailedPrecondition, err.Error())
+}
@@ -0,0 +1,35 @@
+// /email-service/service/email-service_test.go
+
+package service
+
+import (
+	"errors"
+	"net/textproto"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSmtpStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{"connection refused", errors.New("dial tcp: connection refused"), codes.Unavailable},
+		{"mailbox busy", &textproto.Error{Code: 450, Msg: "mailbox unavailable"}, codes.Unavailable},
+		{"rate limited", &textproto.Error{Code: 421, Msg: "try again later"}, codes.Unavailable},
+		{"no such user", &textproto.Error{Code: 550, Msg: "no such user"}, codes.InvalidArgument},
+		{"bad address", &textproto.Error{Code: 553, Msg: "mailbox name not allowed"}, codes.InvalidArgument},
+		{"auth failed", &textproto.Error{Code: 535, Msg: "authentication failed"}, codes.FailedPrecondition},
+		{"rejected", &textproto.Error{Code: 554, Msg: "transaction failed"}, codes.FailedPrecondition},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := status.Code(smtpStatus(tt.err)); got != tt.want {
+				t.Fatalf("smtpStatus(%v) = %s, want %s", tt.err, got, tt.want)
+			}
+		})
+	}
+}
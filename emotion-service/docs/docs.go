@@ -17,7 +17,7 @@ const docTemplate = `{
     "paths": {
         "/get-emotions": {
             "get": {
-                "description": "기분 조회시 호출 (10개씩)",
+                "description": "기분 조회시 호출",
                 "produces": [
                     "application/json"
                 ],
@@ -62,6 +62,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "요청 처리 실패시 오류 메시지 반환",
                         "schema": {
@@ -118,6 +124,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "요청 처리 실패시 오류 메시지 반환",
                         "schema": {
@@ -168,6 +180,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "TOO_MANY_REQUESTS - 같은 사용자의 요청이 처리중",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "요청 처리 실패시 오류 메시지 반환",
                         "schema": {
@@ -191,8 +215,7 @@ const docTemplate = `{
             "type": "object",
             "properties": {
                 "emotion": {
-                    "type": "string",
-                    "example": "기분명"
+                    "type": "integer"
                 },
                 "id": {
                     "type": "integer"
@@ -211,8 +234,7 @@ const docTemplate = `{
                     "example": "YYYY-mm-ddTHH:mm:ss "
                 },
                 "emotion": {
-                    "type": "string",
-                    "example": "기분명"
+                    "type": "integer"
                 },
                 "id": {
                     "type": "integer"
@@ -230,8 +252,20 @@ const docTemplate = `{
         "dto.ErrorResponse": {
             "type": "object",
             "properties": {
-                "err": {
-                    "type": "string"
+                "code": {
+                    "description": "INVALID_REQUEST(400), UNAUTHORIZED(401), FORBIDDEN(403), NOT_FOUND(404), CONFLICT(409), TOO_MANY_REQUESTS(429), DATABASE_ERROR(500), INTERNAL_ERROR(500)",
+                    "type": "string",
+                    "example": "INVALID_REQUEST"
+                },
+                "detail": {
+                    "description": "원인 (디버깅용, 변경될 수 있음)",
+                    "type": "string",
+                    "example": "invalid date format, should be YYYY-MM-DD"
+                },
+                "message": {
+                    "description": "사용자 안내 문구",
+                    "type": "string",
+                    "example": "요청 값이 올바르지 않아요."
                 }
             }
         }
@@ -10,6 +10,7 @@ import (
 	"log"
 	"os"
 
+	"github.com/disterbia/wellkinson/common/util"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 	swaggerFiles "github.com/swaggo/files"
@@ -38,6 +39,9 @@ func main() {
 	removeEmotionsEndpoint := endpoint.RemoveEmotionsEndpoint(svc)
 
 	router := gin.Default()
+	// 보호자 위임 접근 (X-Acting-For)
+	util.SetDelegationStore(util.NewDelegationStore(database))
+	router.Use(util.DelegatedAccess(util.DomainEmotion))
 	router.POST("/save-emotion", transport.SaveEmotionHandler(saveEmotionEndpoint))
 	router.POST("/remove-emotions", transport.RemoveEmotionsHandler(removeEmotionsEndpoint))
 	router.GET("/get-emotions", transport.GetEmotionsHandler(getEmotionsEndpoint))
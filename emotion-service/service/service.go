@@ -2,12 +2,12 @@
 package service
 
 import (
-	"emotion-service/common/model"
-	"emotion-service/common/util"
 	"emotion-service/dto"
 	"errors"
 	"reflect"
 
+	"github.com/disterbia/wellkinson/common/model"
+	"github.com/disterbia/wellkinson/common/util"
 	"gorm.io/gorm"
 )
 
@@ -43,7 +43,7 @@ func (service *emotionService) SaveEmotion(emotionRequest dto.EmotionRequest) (s
 			return "", err
 		}
 	} else if result.Error != nil {
-		return "", errors.New("db error")
+		return "", util.NewError(util.ErrDatabase, "db error")
 	} else {
 		updateFields := make(map[string]interface{})
 
@@ -96,7 +96,7 @@ func (service *emotionService) RemoveEmotions(ids []uint, uid uint) (string, err
 	result := service.db.Where("id IN (?) AND uid= ?", ids, uid).Delete(&model.Emotion{})
 
 	if result.Error != nil {
-		return "", errors.New("db error")
+		return "", util.NewError(util.ErrDatabase, "db error")
 	}
 	return "200", nil
 }
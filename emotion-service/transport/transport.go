@@ -2,11 +2,11 @@
 package transport
 
 import (
-	"emotion-service/common/util"
 	"emotion-service/dto"
 	"net/http"
 	"sync"
 
+	"github.com/disterbia/wellkinson/common/util"
 	"github.com/gin-gonic/gin"
 	kitEndpoint "github.com/go-kit/kit/endpoint"
 )
@@ -21,31 +21,33 @@ var userLocks sync.Map
 // @Param request body dto.EmotionRequest true "요청 DTO - 기분 데이터"
 // @Success 200 {object} dto.BasicResponse "성공시 200 반환"
 // @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
+// @Failure 429 {object} dto.ErrorResponse "TOO_MANY_REQUESTS - 같은 사용자의 요청이 처리중"
 // @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
 // @Router /save-emotion [post]
 func SaveEmotionHandler(saveEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		id, _, err := util.VerifyJWT(c)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
 			return
 		}
 		// 사용자별 잠금 시작
 		if _, loaded := userLocks.LoadOrStore(id, true); loaded {
-			c.JSON(http.StatusTooManyRequests, gin.H{"error": "Concurrent request detected"})
+			util.AbortError(c, util.NewError(util.ErrTooManyRequests, "concurrent request detected"))
 			return
 		}
 		defer userLocks.Delete(id)
 		var req dto.EmotionRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			util.AbortBadRequest(c, err)
 			return
 		}
 
 		req.Uid = id
 		response, err := saveEndpoint(c.Request.Context(), req)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, err)
 			return
 		}
 
@@ -63,19 +65,20 @@ func SaveEmotionHandler(saveEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 // @Param  end_date  query string  false  "종료날짜 yyyy-mm-dd"
 // @Success 200 {object} []dto.EmotionResponse "기분정보"
 // @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
 // @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
 // @Router /get-emotions [get]
 func GetEmotionsHandler(getEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		id, _, err := util.VerifyJWT(c)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
 			return
 		}
 
 		var queryParams dto.GetEmotionsParams
 		if err := c.ShouldBindQuery(&queryParams); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			util.AbortBadRequest(c, err)
 			return
 		}
 
@@ -85,7 +88,7 @@ func GetEmotionsHandler(getEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 			"queryParams": queryParams,
 		})
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, err)
 			return
 		}
 
@@ -103,19 +106,20 @@ func GetEmotionsHandler(getEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 // @Param request body []uint true "삭제할 id 배열"
 // @Success 200 {object} dto.BasicResponse "성공시 200 반환"
 // @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
 // @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
 // @Router /remove-emotion [post]
 func RemoveEmotionsHandler(removeEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		uid, _, err := util.VerifyJWT(c)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
 			return
 		}
 
 		var ids []uint // 삭제할 ID 배열
 		if err := c.ShouldBindJSON(&ids); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			util.AbortBadRequest(c, err)
 			return
 		}
 
@@ -124,7 +128,7 @@ func RemoveEmotionsHandler(removeEndpoint kitEndpoint.Endpoint) gin.HandlerFunc
 			"ids": ids,
 		})
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, err)
 			return
 		}
 
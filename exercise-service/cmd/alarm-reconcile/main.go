@@ -0,0 +1,51 @@
+// /exercise-service/cmd/alarm-reconcile/main.go
+// 운동 기록 기준으로 alarm-service 의 알람 다시 맞추기
+//
+//	alarm-reconcile [-uid 12] [-alarm alarm:50051]
+package main
+
+import (
+	"exercise-service/db"
+	"exercise-service/service"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/disterbia/wellkinson/common/model"
+	"github.com/disterbia/wellkinson/common/util"
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	uid := flag.Uint("uid", 0, "이 유저만 (0 이면 전체)")
+	alarmAddr := flag.String("alarm", "alarm:50051", "alarm-service 주소")
+	flag.Parse()
+
+	if err := godotenv.Load(".env"); err != nil {
+		log.Fatalln("Error loading .env file")
+	}
+	database, err := db.NewDB(os.Getenv("DB_PATH"))
+	if err != nil {
+		log.Fatalln("Database connection error:", err)
+	}
+	if err := database.AutoMigrate(&model.AlarmOutbox{}); err != nil {
+		log.Fatalln("migration error:", err)
+	}
+	conn, err := util.DialAlarm(*alarmAddr)
+	if err != nil {
+		log.Fatalf("failed to connect to alarm service: %v", err)
+	}
+	defer conn.Close()
+
+	count, err := service.ReconcileAlarms(database, uint(*uid))
+	if err != nil {
+		log.Fatalf("reconcile error after %d requests: %v", count, err)
+	}
+	log.Printf("queued %d alarm requests", count)
+
+	sent, err := util.NewAlarmRelay(database, conn, service.AlarmSource).Drain()
+	if err != nil {
+		log.Fatalf("relay error after %d requests: %v", sent, err)
+	}
+	log.Printf("delivered %d alarm requests (failed requests are retried by the service)", sent)
+}
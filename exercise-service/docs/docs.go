@@ -59,6 +59,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "TOO_MANY_REQUESTS - 같은 사용자의 요청이 처리중",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "요청 처리 실패시 오류 메시지 반환",
                         "schema": {
@@ -90,15 +102,13 @@ const docTemplate = `{
                         "type": "string",
                         "description": "시작날짜 yyyy-mm-dd",
                         "name": "start_date",
-                        "in": "query",
-                        "required": true
+                        "in": "query"
                     },
                     {
                         "type": "string",
                         "description": "종료날짜 yyyy-mm-dd",
                         "name": "end_date",
-                        "in": "query",
-                        "required": true
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -117,6 +127,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "요청 처리 실패시 오류 메시지 반환",
                         "schema": {
@@ -161,6 +177,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "요청 처리 실패시 오류 메시지 반환",
                         "schema": {
@@ -218,6 +240,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "요청 처리 실패시 오류 메시지 반환",
                         "schema": {
@@ -274,6 +302,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "요청 처리 실패시 오류 메시지 반환",
                         "schema": {
@@ -324,6 +358,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "TOO_MANY_REQUESTS - 같은 사용자의 요청이 처리중",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "요청 처리 실패시 오류 메시지 반환",
                         "schema": {
@@ -346,8 +392,20 @@ const docTemplate = `{
         "dto.ErrorResponse": {
             "type": "object",
             "properties": {
-                "err": {
-                    "type": "string"
+                "code": {
+                    "description": "INVALID_REQUEST(400), UNAUTHORIZED(401), FORBIDDEN(403), NOT_FOUND(404), CONFLICT(409), TOO_MANY_REQUESTS(429), DATABASE_ERROR(500), INTERNAL_ERROR(500)",
+                    "type": "string",
+                    "example": "INVALID_REQUEST"
+                },
+                "detail": {
+                    "description": "원인 (디버깅용, 변경될 수 있음)",
+                    "type": "string",
+                    "example": "invalid date format, should be YYYY-MM-DD"
+                },
+                "message": {
+                    "description": "사용자 안내 문구",
+                    "type": "string",
+                    "example": "요청 값이 올바르지 않아요."
                 }
             }
         },
@@ -451,6 +509,12 @@ const docTemplate = `{
                     "type": "string",
                     "example": "YYYY-MM-DD"
                 },
+                "repeat": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
                 "updated": {
                     "type": "string",
                     "example": "YYYY-mm-ddTHH:mm:ss "
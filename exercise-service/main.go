@@ -9,13 +9,14 @@ import (
 	"exercise-service/transport"
 	"log"
 	"os"
+	"time"
 
+	"github.com/disterbia/wellkinson/common/model"
+	"github.com/disterbia/wellkinson/common/util"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 func main() {
@@ -32,14 +33,29 @@ func main() {
 		log.Println("Database connection error:", err)
 		return
 	}
+	if err := database.AutoMigrate(&model.AlarmOutbox{}); err != nil {
+		log.Println("alarm outbox migration error:", err)
+	}
 	// gRPC 클라이언트 연결 생성
-	conn, err := grpc.Dial("alarm:50051", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := util.DialAlarm("alarm:50051")
 	if err != nil {
-		log.Fatalf("failed to connect to email service: %v", err)
+		log.Fatalf("failed to connect to alarm service: %v", err)
 	}
 	defer conn.Close()
 
-	svc := service.NewExerciseService(database, conn)
+	svc := service.NewExerciseService(database)
+
+	// 저장된 알람 변경 요청을 alarm-service 로 전달
+	relay := util.NewAlarmRelay(database, conn, service.AlarmSource)
+	go func() {
+		ticker := time.NewTicker(2 * time.Second)
+		defer ticker.Stop()
+		for range ticker.C {
+			if _, err := relay.Relay(); err != nil {
+				log.Println("alarm relay error:", err)
+			}
+		}
+	}()
 
 	saveExerciseEndpoint := endpoint.SaveExerciseEndpoint(svc)
 	getExercisesEndpoint := endpoint.GetExercisesEndpoint(svc)
@@ -49,6 +65,9 @@ func main() {
 	getVideosEndpoint := endpoint.GetVideosEndpoint(svc)
 
 	router := gin.Default()
+	// 보호자 위임 접근 (X-Acting-For)
+	util.SetDelegationStore(util.NewDelegationStore(database))
+	router.Use(util.DelegatedAccess(util.DomainExercise))
 	router.POST("/save-exercise", transport.SaveExerciseHandler(saveExerciseEndpoint))
 	router.POST("/remove-exercises", transport.RemoveExercisesHandler(removeExercisesEndpoint))
 	router.POST("/do-exercise", transport.DoExerciseHandler(doExerciseEndpoint))
@@ -0,0 +1,103 @@
+// /exercise-service/service/alarm.go
+package service
+
+import (
+	"encoding/json"
+	pb "exercise-service/proto"
+
+	"github.com/disterbia/wellkinson/common/model"
+	"github.com/disterbia/wellkinson/common/util"
+	"gorm.io/gorm"
+)
+
+const reconcileBatch = 500
+
+// 알람 변경 요청 출처 (relay 가 이 출처의 요청만 전달)
+const AlarmSource = "exercise"
+
+// parent 의 알람을 ars 로 교체 (비어 있으면 삭제) - 도메인 변경과 같은 트랜잭션(tx)으로 호출
+func enqueueReplaceAlarms(tx *gorm.DB, parentId, uid uint, alarmType int, ars []*pb.AlarmRequest) error {
+	return util.EnqueueAlarm(tx, AlarmSource, parentId, uid, util.ReplaceAlarmsMethod,
+		&pb.ReplaceAlarmsRequest{ParentId: int32(parentId), Uid: int32(uid), Type: int32(alarmType), Alarms: ars})
+}
+
+// 알람 삭제 - 기록마다 순서를 지키도록 parent 별로 저장
+func enqueueRemoveAlarms(tx *gorm.DB, parentIds []uint, uid uint, alarmType int) error {
+	for _, v := range parentIds {
+		if err := enqueueReplaceAlarms(tx, v, uid, alarmType, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 운동 기록대로 알람 교체 요청 - 알람을 쓰지 않으면 삭제
+func enqueueExerciseAlarm(tx *gorm.DB, exercise model.Exercise) error {
+	if !exercise.UseAlarm {
+		return enqueueRemoveAlarms(tx, []uint{exercise.Id}, exercise.Uid, util.ExerciseType)
+	}
+	var week []int32
+	if err := json.Unmarshal(exercise.Weekdays, &week); err != nil {
+		return err
+	}
+	return enqueueReplaceAlarms(tx, exercise.Id, exercise.Uid, util.ExerciseType, []*pb.AlarmRequest{{
+		ParentId:  int32(exercise.Id),
+		Uid:       int32(exercise.Uid),
+		Body:      "운동 할 시간입니다.",
+		Type:      int32(util.ExerciseType),
+		StartAt:   exercise.PlanStartAt,
+		EndAt:     exercise.PlanEndAt,
+		Timestamp: exercise.ExerciseStartAt,
+		Week:      week,
+	}})
+}
+
+// 운동 기록(삭제 제외) 기준으로 알람 다시 맞추기 (uid 0 이면 전체) - 기록마다 교체/삭제 요청, 기록이 없는 알람은 삭제 요청
+// 요청 건수 반환, 전달은 AlarmRelay 가 처리
+func ReconcileAlarms(db *gorm.DB, uid uint) (int, error) {
+	count := 0
+	query := db.Where("is_delete = false").Order("id")
+	if uid != 0 {
+		query = query.Where("uid = ?", uid)
+	}
+	var exercises []model.Exercise
+	err := query.FindInBatches(&exercises, reconcileBatch, func(_ *gorm.DB, batch int) error {
+		return db.Transaction(func(tx *gorm.DB) error {
+			for _, v := range exercises {
+				if err := enqueueExerciseAlarm(tx, v); err != nil {
+					return err
+				}
+			}
+			count += len(exercises)
+			return nil
+		})
+	}).Error
+	if err != nil {
+		return count, err
+	}
+
+	var orphans []struct {
+		Uid      uint
+		ParentId uint
+	}
+	query = db.Table("alarms").Distinct("uid", "parent_id").
+		Where("type = ? AND NOT EXISTS (SELECT 1 FROM exercises WHERE exercises.id = alarms.parent_id AND exercises.uid = alarms.uid AND exercises.is_delete = false)", util.ExerciseType)
+	if uid != 0 {
+		query = query.Where("uid = ?", uid)
+	}
+	if err := query.Scan(&orphans).Error; err != nil {
+		return count, err
+	}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		for _, v := range orphans {
+			if err := enqueueRemoveAlarms(tx, []uint{v.ParentId}, v.Uid, util.ExerciseType); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return count, err
+	}
+	return count + len(orphans), nil
+}
@@ -2,18 +2,14 @@
 package service
 
 import (
-	"context"
 	"encoding/json"
 	"errors"
-	"exercise-service/common/model"
-	"exercise-service/common/util"
 	"exercise-service/dto"
-	pb "exercise-service/proto"
-	"log"
 	"reflect"
 	"time"
 
-	"google.golang.org/grpc"
+	"github.com/disterbia/wellkinson/common/model"
+	"github.com/disterbia/wellkinson/common/util"
 	"gorm.io/gorm"
 )
 
@@ -27,17 +23,24 @@ type ExerciseService interface {
 }
 
 type exerciseService struct {
-	db          *gorm.DB
-	alarmClient pb.AlarmServiceClient
+	db *gorm.DB
 }
 
-func NewExerciseService(db *gorm.DB, conn *grpc.ClientConn) ExerciseService {
-	alarmClient := pb.NewAlarmServiceClient(conn)
-	return &exerciseService{db: db, alarmClient: alarmClient}
+// 알람은 outbox 에 저장하고 AlarmRelay 가 alarm-service 로 전달
+func NewExerciseService(db *gorm.DB) ExerciseService {
+	return &exerciseService{db: db}
 }
 
 func (service *exerciseService) GetExercises(id uint, startDateStr, endDateStr string) ([]dto.ExerciseDateInfo, error) {
 
+	// 날짜가 없으면 유저 시간대 기준 오늘
+	if startDateStr == "" {
+		startDateStr = userToday(service.db, id)
+	}
+	if endDateStr == "" {
+		endDateStr = startDateStr
+	}
+
 	// 문자열을 time.Time 타입으로 변환
 	startDate, err := time.Parse("2006-01-02", startDateStr)
 	if err != nil {
@@ -55,7 +58,7 @@ func (service *exerciseService) GetExercises(id uint, startDateStr, endDateStr s
 	err = service.db.Debug().Where("uid = ? AND plan_start_at <= ? AND plan_end_at >= ? AND is_delete != ? ",
 		id, endDate.Format("2006-01-02"), startDate.Format("2006-01-02"), true).Find(&exercises).Error
 	if err != nil {
-		return nil, errors.New("db error")
+		return nil, util.NewError(util.ErrDatabase, "db error")
 	}
 
 	if err := util.CopyStruct(exercises, &exerciseResponse); err != nil {
@@ -122,7 +125,7 @@ func (service *exerciseService) DoExercise(exerciseDo dto.ExerciseDo) (string, e
 	var info model.ExerciseInfo
 	datePerformed, err := time.Parse("2006-01-02", exerciseDo.PerformedDate)
 	if err != nil {
-		return "", errors.New("must YYYY-MM-DD")
+		return "", util.NewError(util.ErrInvalidRequest, "must YYYY-MM-DD")
 	}
 	result := service.db.Debug().Where("exercise_id = ? AND uid=? AND date_performed = ?", exerciseDo.ExerciseId, exerciseDo.Uid, datePerformed.Format("2006-01-02")).First(&info)
 
@@ -134,12 +137,12 @@ func (service *exerciseService) DoExercise(exerciseDo dto.ExerciseDo) (string, e
 			DatePerformed: exerciseDo.PerformedDate,
 		}
 		if err := service.db.Create(&newInfo).Error; err != nil {
-			return "", errors.New("db error")
+			return "", util.NewError(util.ErrDatabase, "db error")
 		}
 	} else {
 		result := service.db.Debug().Where("exercise_id = ? AND uid=? AND date_performed = ?", exerciseDo.ExerciseId, exerciseDo.Uid, exerciseDo.PerformedDate).Delete(&model.ExerciseInfo{})
 		if result.Error != nil {
-			return "", errors.New("db error2")
+			return "", util.NewError(util.ErrDatabase, "db error2")
 		}
 	}
 	return "200", nil
@@ -165,7 +168,7 @@ func (service *exerciseService) SaveExercise(exerciseRequest dto.ExerciseRequest
 
 	exercise.Uid = exerciseRequest.Uid //  json: "-" 이라서
 
-	newWeekdays, unique, err := validateWeek(exercise.Weekdays)
+	newWeekdays, _, err := validateWeek(exercise.Weekdays)
 	if err != nil {
 		return "", err
 	}
@@ -175,24 +178,17 @@ func (service *exerciseService) SaveExercise(exerciseRequest dto.ExerciseRequest
 		// 레코드가 존재하지 않으면 새 레코드 생성
 		exercise.Id = 0
 
-		if err := service.db.Create(&exercise).Error; err != nil {
+		err := service.db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Create(&exercise).Error; err != nil {
+				return err
+			}
+			return enqueueExerciseAlarm(tx, exercise)
+		})
+		if err != nil {
 			return "", err
 		}
-		ar := &pb.AlarmRequest{
-			ParentId:  int32(exercise.Id),
-			Uid:       int32(exercise.Uid),
-			Body:      "운동 할 시간입니다.",
-			Type:      int32(util.ExerciseType),
-			StartAt:   exercise.PlanStartAt,
-			EndAt:     exercise.PlanEndAt,
-			Timestamp: exercise.ExerciseStartAt,
-			Week:      unique,
-		}
-		if exercise.UseAlarm {
-			go sendAlarm(service, ar)
-		}
 	} else if result.Error != nil {
-		return "", errors.New("db error")
+		return "", util.NewError(util.ErrDatabase, "db error")
 	} else {
 		// 레코드가 존재하면 업데이트
 		updateFields := make(map[string]interface{})
@@ -215,31 +211,19 @@ func (service *exerciseService) SaveExercise(exerciseRequest dto.ExerciseRequest
 			}
 		}
 
-		if err := service.db.Model(&exercise).Updates(updateFields).Error; err != nil {
-			return "", err
-		}
-		ar := &pb.AlarmRequest{
-			ParentId:  int32(exercise.Id),
-			Uid:       int32(exercise.Uid),
-			Body:      "운동 할 시간입니다.",
-			Type:      int32(util.ExerciseType),
-			StartAt:   exercise.PlanStartAt,
-			EndAt:     exercise.PlanEndAt,
-			Timestamp: exercise.ExerciseStartAt,
-			Week:      unique,
-		}
-		if exercise.UseAlarm {
-			go updateAlarm(service, ar)
-		} else {
-			b := make([]int32, 1)
-
-			b[0] = int32(exercise.Id)
-
-			arr := &pb.AlarmRemoveRequest{
-				ParentIds: b,
-				Uid:       int32(exercise.Uid),
+		err := service.db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Model(&exercise).Updates(updateFields).Error; err != nil {
+				return err
 			}
-			go removeAlarm(service, arr)
+			// 요청에 없는 필드는 기존 값이므로 저장된 기록으로 알람 요청
+			var saved model.Exercise
+			if err := tx.Where("id = ?", exercise.Id).First(&saved).Error; err != nil {
+				return err
+			}
+			return enqueueExerciseAlarm(tx, saved)
+		})
+		if err != nil {
+			return "", err
 		}
 	}
 
@@ -247,24 +231,15 @@ func (service *exerciseService) SaveExercise(exerciseRequest dto.ExerciseRequest
 }
 
 func (service *exerciseService) RemoveExercises(ids []uint, uid uint) (string, error) {
-	result := service.db.Model(&model.Exercise{}).Where("id IN (?) AND uid= ?", ids, uid).Select("is_delete").Updates(map[string]interface{}{"is_delete": true})
-
-	if result.Error != nil {
-		return "", errors.New("db error")
-	}
-
-	b := make([]int32, len(ids))
-
-	for i, v := range ids {
-		b[i] = int32(v)
-	}
-	arr := &pb.AlarmRemoveRequest{
-		ParentIds: b,
-		Uid:       int32(uid),
-		Type:      int32(util.ExerciseType),
+	err := service.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Exercise{}).Where("id IN (?) AND uid= ?", ids, uid).Select("is_delete").Updates(map[string]interface{}{"is_delete": true}).Error; err != nil {
+			return err
+		}
+		return enqueueRemoveAlarms(tx, ids, uid, util.ExerciseType)
+	})
+	if err != nil {
+		return "", util.NewError(util.ErrDatabase, "db error")
 	}
-
-	go removeAlarm(service, arr)
 	return "200", nil
 }
 
@@ -305,27 +280,3 @@ func (service *exerciseService) GetVideos(projectId string, page uint) ([]dto.Vi
 
 	return VideoResponses, nil
 }
-
-func sendAlarm(service *exerciseService, ar *pb.AlarmRequest) {
-	reponse, err := service.alarmClient.SetAlarm(context.Background(), ar)
-	if err != nil {
-		log.Printf("Failed to set Alarm: %v", err)
-	}
-	log.Printf("set Alarm: %v", reponse)
-}
-
-func removeAlarm(service *exerciseService, arr *pb.AlarmRemoveRequest) {
-	reponse, err := service.alarmClient.RemoveAlarm(context.Background(), arr)
-	if err != nil {
-		log.Printf("Failed to remove Alarm: %v", err)
-	}
-	log.Printf("remove Alarm: %v", reponse)
-}
-
-func updateAlarm(service *exerciseService, ar *pb.AlarmRequest) {
-	reponse, err := service.alarmClient.UpdateAlarm(context.Background(), ar)
-	if err != nil {
-		log.Printf("Failed to update Alarm: %v", err)
-	}
-	log.Printf("update Alarm: %v", reponse)
-}
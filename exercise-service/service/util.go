@@ -3,9 +3,12 @@ package service
 
 import (
 	"encoding/json"
-	"errors"
-	"exercise-service/common/util"
 	"exercise-service/dto"
+	"time"
+
+	"github.com/disterbia/wellkinson/common/model"
+	"github.com/disterbia/wellkinson/common/util"
+	"gorm.io/gorm"
 )
 
 func validateExercise(exercise dto.ExerciseRequest) error {
@@ -32,7 +35,7 @@ func validateWeek(weekdays json.RawMessage) (json.RawMessage, []int32, error) {
 	}
 
 	if len(weekdaySlice) == 0 {
-		return nil, nil, errors.New("must weekday")
+		return nil, nil, util.NewError(util.ErrInvalidRequest, "must weekday")
 	}
 
 	seen := make(map[int32]bool)
@@ -55,3 +58,11 @@ func validateWeek(weekdays json.RawMessage) (json.RawMessage, []int32, error) {
 	}
 	return newWeekdays, unique, nil
 }
+
+// 유저 시간대 기준 오늘 날짜
+func userToday(db *gorm.DB, uid uint) string {
+	var user model.User
+	// 조회 실패시 기본 시간대
+	db.Select("time_zone").Where("id = ?", uid).First(&user)
+	return time.Now().In(util.LoadUserLocation(user.TimeZone)).Format("2006-01-02")
+}
@@ -2,11 +2,11 @@
 package transport
 
 import (
-	"exercise-service/common/util"
 	"exercise-service/dto"
 	"net/http"
 	"sync"
 
+	"github.com/disterbia/wellkinson/common/util"
 	"github.com/gin-gonic/gin"
 	kitEndpoint "github.com/go-kit/kit/endpoint"
 )
@@ -21,33 +21,35 @@ var userLocks sync.Map
 // @Param request body dto.ExerciseRequest true "요청 DTO - 운동 데이터"
 // @Success 200 {object} dto.BasicResponse "성공시 200 반환"
 // @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
+// @Failure 429 {object} dto.ErrorResponse "TOO_MANY_REQUESTS - 같은 사용자의 요청이 처리중"
 // @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
 // @Router /save-exercise [post]
 func SaveExerciseHandler(saveEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		id, _, err := util.VerifyJWT(c)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
 			return
 		}
 
 		// 사용자별 잠금 시작
 		if _, loaded := userLocks.LoadOrStore(id, true); loaded {
-			c.JSON(http.StatusTooManyRequests, gin.H{"error": "Concurrent request detected"})
+			util.AbortError(c, util.NewError(util.ErrTooManyRequests, "concurrent request detected"))
 			return
 		}
 		defer userLocks.Delete(id)
 
 		var req dto.ExerciseRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			util.AbortBadRequest(c, err)
 			return
 		}
 
 		req.Uid = id
 		response, err := saveEndpoint(c.Request.Context(), req)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, err)
 			return
 		}
 
@@ -65,19 +67,20 @@ func SaveExerciseHandler(saveEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 // @Param  end_date  query string  false  "종료날짜 yyyy-mm-dd"
 // @Success 200 {object} []dto.ExerciseDateInfo "운동정보"
 // @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
 // @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
 // @Router /get-exercises [get]
 func GetExercisesHandler(getEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		id, _, err := util.VerifyJWT(c)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
 			return
 		}
 
 		var queryParams dto.GetParams
 		if err := c.ShouldBindQuery(&queryParams); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			util.AbortBadRequest(c, err)
 			return
 		}
 
@@ -87,7 +90,7 @@ func GetExercisesHandler(getEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 			"queryParams": queryParams,
 		})
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, err)
 			return
 		}
 
@@ -105,18 +108,19 @@ func GetExercisesHandler(getEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 // @Param request body []uint true "삭제할 id 배열"
 // @Success 200 {object} dto.BasicResponse "성공시 200 반환"
 // @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
 // @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
 // @Router /remove-exercise [post]
 func RemoveExercisesHandler(removeEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		uid, _, err := util.VerifyJWT(c)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
 			return
 		}
 		var ids []uint // 삭제할 ID 배열
 		if err := c.ShouldBindJSON(&ids); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			util.AbortBadRequest(c, err)
 			return
 		}
 		response, err := removeEndpoint(c.Request.Context(), map[string]interface{}{
@@ -124,7 +128,7 @@ func RemoveExercisesHandler(removeEndpoint kitEndpoint.Endpoint) gin.HandlerFunc
 			"ids": ids,
 		})
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, err)
 			return
 		}
 
@@ -143,32 +147,34 @@ func RemoveExercisesHandler(removeEndpoint kitEndpoint.Endpoint) gin.HandlerFunc
 // @Param request body dto.ExerciseDo true "운동 완료/취소 데이터"
 // @Success 200 {object} dto.BasicResponse "성공시 200 반환"
 // @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
+// @Failure 429 {object} dto.ErrorResponse "TOO_MANY_REQUESTS - 같은 사용자의 요청이 처리중"
 // @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
 // @Router /do-exercises [post]
 func DoExerciseHandler(doEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		uid, _, err := util.VerifyJWT(c)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
 			return
 		}
 
 		// 사용자별 잠금 시작
 		if _, loaded := userLocks.LoadOrStore(uid, true); loaded {
-			c.JSON(http.StatusTooManyRequests, gin.H{"error": "Concurrent request detected"})
+			util.AbortError(c, util.NewError(util.ErrTooManyRequests, "concurrent request detected"))
 			return
 		}
 		defer userLocks.Delete(uid)
 
 		var param dto.ExerciseDo
 		if err := c.ShouldBindJSON(&param); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			util.AbortBadRequest(c, err)
 			return
 		}
 		param.Uid = uid
 		response, err := doEndpoint(c.Request.Context(), param)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, err)
 			return
 		}
 
@@ -185,18 +191,19 @@ func DoExerciseHandler(doEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 // @Param Authorization header string true "Bearer {jwt_token}"
 // @Success 200 {object} []dto.ProjectResponse "카테고리 정보"
 // @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
 // @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
 // @Router /get-projects [get]
 func GetProjectsHandler(getEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		_, _, err := util.VerifyJWT(c)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
 			return
 		}
 		response, err := getEndpoint(c.Request.Context(), nil)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, err)
 			return
 		}
 
@@ -214,25 +221,26 @@ func GetProjectsHandler(getEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 // @Param  page  query uint  false  "페이지 default 0"
 // @Success 200 {object} []dto.VideoResponse "동영상 정보"
 // @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
 // @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
 // @Router /get-videos [get]
 func GetVideosHandler(getEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		_, _, err := util.VerifyJWT(c)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
 			return
 		}
 
 		var queryParams dto.GetVideoParams
 		if err := c.ShouldBindQuery(&queryParams); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			util.AbortBadRequest(c, err)
 			return
 		}
 
 		response, err := getEndpoint(c.Request.Context(), queryParams)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, err)
 			return
 		}
 
@@ -2,7 +2,7 @@
 package dto
 
 import (
-	"face-service/common/model"
+	"github.com/disterbia/wellkinson/common/model"
 )
 
 type GetParams struct {
@@ -54,8 +54,12 @@ type SwaggerResponse struct {
 type SuccessResponse struct {
 	Jwt string `json:"jwt"`
 }
+
+// 오류 응답 - code 로 분기하고 message 는 사용자에게 표시 (Accept-Language: en 이면 영어)
 type ErrorResponse struct {
-	Err string `json:"err"`
+	Code    string `json:"code" example:"INVALID_REQUEST"`                                       // INVALID_REQUEST(400), UNAUTHORIZED(401), FORBIDDEN(403), NOT_FOUND(404), CONFLICT(409), TOO_MANY_REQUESTS(429), DATABASE_ERROR(500), INTERNAL_ERROR(500)
+	Message string `json:"message" example:"요청 값이 올바르지 않아요."`                                    // 사용자 안내 문구
+	Detail  string `json:"detail,omitempty" example:"invalid date format, should be YYYY-MM-DD"` // 원인 (디버깅용, 변경될 수 있음)
 }
 
 type BasicResponse struct {
@@ -2,11 +2,10 @@
 package service
 
 import (
-	"errors"
-	"face-service/common/model"
-	"face-service/common/util"
 	"face-service/dto"
 
+	"github.com/disterbia/wellkinson/common/model"
+	"github.com/disterbia/wellkinson/common/util"
 	"gorm.io/gorm"
 )
 
@@ -30,7 +29,7 @@ func (service *faceService) GetFaceExams() ([]dto.FaceExamResponse, error) {
 
 	err := service.db.Find(&faceExams).Error
 	if err != nil {
-		return nil, errors.New("db error")
+		return nil, util.NewError(util.ErrDatabase, "db error")
 	}
 	if err := util.CopyStruct(faceExams, &faceExamResponses); err != nil {
 		return nil, err
@@ -63,7 +62,7 @@ func (service *faceService) GetFaceExercises() ([]dto.FaceExerciseResponse, erro
 
 	err = service.db.Find(&faceExercises).Error
 	if err != nil {
-		return nil, errors.New("db error")
+		return nil, util.NewError(util.ErrDatabase, "db error")
 	}
 
 	faceMap := make(map[uint][]model.FaceExercise)
@@ -93,7 +92,7 @@ func (service *faceService) GetFaceScores(id uint, startDate, endDate string) ([
 
 	err := query.Find(&faceScores).Error
 	if err != nil {
-		return nil, errors.New("db error")
+		return nil, util.NewError(util.ErrDatabase, "db error")
 	}
 
 	if err := util.CopyStruct(faceScores, &faceScoreResponses); err != nil {
@@ -5,6 +5,7 @@ go 1.21.5
 require (
 	firebase.google.com/go v3.13.0+incompatible
 	github.com/dgrijalva/jwt-go v3.2.0+incompatible
+	github.com/disterbia/wellkinson/common v1.0.0
 	github.com/gin-gonic/gin v1.9.1
 	github.com/joho/godotenv v1.5.1
 	github.com/robfig/cron/v3 v3.0.1
@@ -78,3 +79,6 @@ require (
 	google.golang.org/protobuf v1.31.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+// 로컬 공통 모듈 사용 (go.work 밖에서 빌드할때, Docker 는 저장소 루트를 context 로 빌드)
+replace github.com/disterbia/wellkinson/common => ../common
@@ -7,6 +7,7 @@ import (
 	"log"
 	"os"
 
+	"github.com/disterbia/wellkinson/common/model"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 )
@@ -20,6 +21,8 @@ func main() {
 	database, err := db.NewDB(dbPath)
 	if err != nil {
 		log.Println("Database connection error:", err)
+	} else if err := database.AutoMigrate(&model.AlarmDispatch{}, &model.DispatchWatermark{}, &model.PushDelivery{}, &model.DoseEscalation{}); err != nil {
+		log.Println("migration error:", err)
 	}
 	service.StartCentralCronScheduler(database)
 
@@ -0,0 +1,231 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/disterbia/wellkinson/common/model"
+	"github.com/disterbia/wellkinson/common/util"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 복용 누락 단계
+const (
+	escalationWaiting   = 0 // 알람 발송 후 복용 대기
+	escalationReminded  = 1 // 환자에게 재알림
+	escalationMissed    = 2 // 보호자에게 알림 (누락 확정)
+	escalationCompleted = 3 // 복용했거나 약이 삭제됨
+)
+
+const escalationBatch = 500
+
+// 알람 후 재알림까지, 재알림 후 보호자 알림까지 대기 시간 (분)
+// DOSE_REMIND_MINUTES, DOSE_ESCALATE_MINUTES 로 설정 (기본 30분)
+func doseRemindWindow() time.Duration {
+	return envMinutes("DOSE_REMIND_MINUTES", 30)
+}
+
+func doseEscalateWindow() time.Duration {
+	return envMinutes("DOSE_ESCALATE_MINUTES", 30)
+}
+
+func envMinutes(key string, def int) time.Duration {
+	minutes, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || minutes <= 0 {
+		minutes = def
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// 약 알람 발송시 복용 확인 대기 기록 생성 (다시 알림은 원래 알람 시각 기록이 이미 있으므로 무시됨)
+func createEscalations(tx *gorm.DB, claimed []claimedAlarm) error {
+	var escalations []model.DoseEscalation
+	for _, v := range claimed {
+		alarm := v.alarm
+		if alarm.Type != uint(util.MedicineType) || alarm.ParentId == 0 {
+			continue
+		}
+		scheduledAt := v.fireAt
+		if alarm.ScheduledAt != "" {
+			if t, err := time.Parse(minuteLayout, alarm.ScheduledAt); err == nil {
+				scheduledAt = t
+			}
+		}
+		local := scheduledAt.In(util.LoadUserLocation(alarm.User.TimeZone))
+		escalations = append(escalations, model.DoseEscalation{Uid: alarm.Uid, MedicineId: alarm.ParentId,
+			DateTaken: local.Format("2006-01-02"), TimeTaken: local.Format("15:04"), ScheduledAt: scheduledAt.Format(minuteLayout),
+			Stage: escalationWaiting, NextCheckAt: scheduledAt.Add(doseRemindWindow()).Format(minuteLayout)})
+	}
+	if len(escalations) == 0 {
+		return nil
+	}
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&escalations).Error
+}
+
+// 확인 시각이 된 기록 처리: 복용 기록이 없으면 환자 재알림 -> 보호자 알림
+func processEscalations(db *gorm.DB, now time.Time) {
+	current := now.UTC().Truncate(time.Minute).Format(minuteLayout)
+	var escalations []model.DoseEscalation
+	if err := db.Where("stage IN ? AND next_check_at <= ?", []uint{escalationWaiting, escalationReminded}, current).
+		Order("id").Limit(escalationBatch).Find(&escalations).Error; err != nil {
+		log.Printf("error loading dose escalations: %v\n", err)
+		return
+	}
+
+	var deliveries []model.PushDelivery
+	for _, v := range escalations {
+		result, err := escalate(db, v, now)
+		if err != nil {
+			log.Printf("error escalating dose %d: %v\n", v.Id, err)
+			continue
+		}
+		deliveries = append(deliveries, result...)
+	}
+	sendPushes(db, deliveries)
+}
+
+func escalate(db *gorm.DB, escalation model.DoseEscalation, now time.Time) ([]model.PushDelivery, error) {
+	var deliveries []model.PushDelivery
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var medicine model.Medicine
+		medicineErr := escalationMedicine(tx, escalation).First(&medicine).Error
+		if medicineErr != nil && !errors.Is(medicineErr, gorm.ErrRecordNotFound) {
+			return medicineErr
+		}
+		var taken int64
+		if err := tx.Model(&model.MedicineTake{}).Where("uid = ? AND medicine_id = ? AND date_taken = ? AND time_taken = ?",
+			escalation.Uid, escalation.MedicineId, escalation.DateTaken, escalation.TimeTaken).Count(&taken).Error; err != nil {
+			return err
+		}
+
+		updates := escalationUpdates(escalation, taken > 0, medicineErr == nil, now)
+
+		// 여러 인스턴스가 같은 기록을 처리하지 않도록 이전 단계를 조건으로 갱신
+		result := tx.Model(&model.DoseEscalation{}).Where("id = ? AND stage = ? AND next_check_at = ?", escalation.Id, escalation.Stage, escalation.NextCheckAt).
+			Updates(updates)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		switch updates["stage"] {
+		case escalationReminded:
+			body := fmt.Sprintf("%s %s 복용 시간이 지났어요. 복용 후 체크해주세요.", medicine.Name, escalation.TimeTaken)
+			var err error
+			deliveries, err = notifyUsers(tx, []uint{escalation.Uid}, escalation, "remind", body)
+			return err
+		case escalationMissed:
+			var patient model.User
+			if err := tx.Select("id", "name").Where("id = ?", escalation.Uid).First(&patient).Error; err != nil {
+				return err
+			}
+			caregivers, err := medicineCaregivers(tx, escalation.Uid)
+			if err != nil {
+				return err
+			}
+			body := fmt.Sprintf("%s님이 %s %s %s 복용을 하지 않았어요.", patient.Name, escalation.DateTaken, escalation.TimeTaken, medicine.Name)
+			deliveries, err = notifyUsers(tx, caregivers, escalation, "missed", body)
+			return err
+		}
+		return nil
+	})
+	return deliveries, err
+}
+
+// 다음 단계 - 복용했거나 약이 없거나 삭제됐으면 완료, 아니면 재알림 -> 보호자 알림
+func escalationUpdates(escalation model.DoseEscalation, taken, medicineFound bool, now time.Time) map[string]interface{} {
+	updates := map[string]interface{}{}
+	switch {
+	case taken || !medicineFound:
+		updates["stage"] = escalationCompleted
+	case escalation.Stage == escalationWaiting:
+		updates["stage"] = escalationReminded
+		updates["next_check_at"] = now.UTC().Add(doseEscalateWindow()).Truncate(time.Minute).Format(minuteLayout)
+	default:
+		updates["stage"] = escalationMissed
+		updates["missed"] = true
+	}
+	return updates
+}
+
+// 누락 확인할 약 - 삭제된 약은 찾지 않아 복용 확인을 끝냄
+func escalationMedicine(tx *gorm.DB, escalation model.DoseEscalation) *gorm.DB {
+	return tx.Model(&model.Medicine{}).Select("id", "name").Where("id = ? AND uid = ? AND is_delete = false", escalation.MedicineId, escalation.Uid)
+}
+
+// 약 도메인 권한(read/write)이 있는 연결된 보호자
+func medicineCaregivers(db *gorm.DB, patientUid uint) ([]uint, error) {
+	var links []model.CareLink
+	if err := db.Where("patient_uid = ? AND status = 1", patientUid).Find(&links).Error; err != nil {
+		return nil, err
+	}
+	var caregivers []uint
+	for _, v := range links {
+		scopes := make(map[string]string)
+		if err := json.Unmarshal(v.Scopes, &scopes); err != nil {
+			continue
+		}
+		if scopes["medicine"] != "" {
+			caregivers = append(caregivers, v.CaregiverUid)
+		}
+	}
+	return caregivers, nil
+}
+
+// 알림함 저장 후 유저별 활성 기기로 발송할 기록 생성
+func notifyUsers(tx *gorm.DB, uids []uint, escalation model.DoseEscalation, stage, body string) ([]model.PushDelivery, error) {
+	if len(uids) == 0 {
+		return nil, nil
+	}
+	notifications := make([]model.Notification, 0, len(uids))
+	for _, uid := range uids {
+		notifications = append(notifications, model.Notification{Uid: uid, Type: uint(util.MedicineType), Body: body,
+			ParentId: escalation.MedicineId, Timestamp: escalation.TimeTaken})
+	}
+	if err := tx.Create(&notifications).Error; err != nil {
+		return nil, err
+	}
+
+	notificationCounts := getUnreadNotificationCounts(tx, uids)
+	deviceTokens, err := pushTokens(tx, uids)
+	if err != nil {
+		return nil, err
+	}
+	scheduledAt, _ := time.Parse(minuteLayout, escalation.ScheduledAt)
+
+	var deliveries []model.PushDelivery
+	for _, uid := range uids {
+		payload, err := json.Marshal(pushPayload{
+			Data: map[string]string{
+				"uid":                strconv.FormatUint(uint64(uid), 10),
+				"type":               strconv.Itoa(util.MedicineType),
+				"notification_count": strconv.FormatUint(uint64(notificationCounts[uid]), 10),
+				"timestamp":          time.Now().Format(time.RFC3339),
+				"scheduled_at":       scheduledAt.Format(time.RFC3339),
+				"parent_id":          strconv.FormatUint(uint64(escalation.MedicineId), 10),
+				"patient_uid":        strconv.FormatUint(uint64(escalation.Uid), 10),
+				"escalation":         stage,
+			},
+			Title: getNotificationTitle(uint(util.MedicineType)),
+			Body:  body,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, token := range deviceTokens[uid] {
+			deliveries = append(deliveries, model.PushDelivery{Uid: uid, Token: token, Payload: payload, Status: pushPending})
+		}
+	}
+	if len(deliveries) == 0 {
+		return nil, nil
+	}
+	if err := tx.Create(&deliveries).Error; err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
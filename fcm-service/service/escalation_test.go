@@ -0,0 +1,51 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/disterbia/wellkinson/common/model"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestEscalationUpdates(t *testing.T) {
+	now := time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name          string
+		stage         uint
+		taken         bool
+		medicineFound bool
+		want          int
+	}{
+		{"taken", escalationWaiting, true, true, escalationCompleted},
+		{"deleted medicine", escalationWaiting, false, false, escalationCompleted},
+		{"deleted after remind", escalationReminded, false, false, escalationCompleted},
+		{"remind patient", escalationWaiting, false, true, escalationReminded},
+		{"notify caregivers", escalationReminded, false, true, escalationMissed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updates := escalationUpdates(model.DoseEscalation{Stage: tt.stage}, tt.taken, tt.medicineFound, now)
+			if got := updates["stage"]; got != tt.want {
+				t.Fatalf("stage = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// 삭제된 약은 찾지 않아야 escalationUpdates 에서 완료 처리됨
+func TestEscalationMedicineSkipsDeleted(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var medicine model.Medicine
+		return escalationMedicine(tx, model.DoseEscalation{Uid: 1, MedicineId: 2}).First(&medicine)
+	})
+	if !strings.Contains(sql, "is_delete = false") {
+		t.Fatalf("medicine lookup must skip deleted medicines: %s", sql)
+	}
+}
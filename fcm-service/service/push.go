@@ -0,0 +1,278 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"firebase.google.com/go/messaging"
+	"github.com/disterbia/wellkinson/common/model"
+	"gorm.io/gorm"
+)
+
+// 푸시 발송 상태
+const (
+	pushPending      = 0
+	pushSent         = 1
+	pushRetry        = 2
+	pushFailed       = 3
+	pushInvalidToken = 4
+	pushQueued       = 5 // 다른 서비스가 요청한 발송 (토큰 없이 유저만 지정, 기기별로 나눠 발송)
+)
+
+const (
+	pushMaxAttempts  = 5
+	pushRetryBase    = time.Minute // 1, 2, 4, 8분 간격으로 재시도
+	pushStaleTimeout = 5 * time.Minute
+	pushWorkers      = 10
+	pushSendTimeout  = 10 * time.Second
+	pushRetryBatch   = 500
+	deviceActiveDays = 60 // 마지막 접속 후 이 기간이 지난 기기는 발송 제외
+	timeLayout       = "2006-01-02 15:04:05"
+)
+
+type pushPayload struct {
+	Data  map[string]string `json:"data"`
+	Title string            `json:"title"`
+	Body  string            `json:"body"`
+}
+
+// 유저별 활성 기기 토큰 (같은 토큰 중복 제외)
+func activeDeviceTokens(db *gorm.DB, uids []uint) (map[uint][]string, error) {
+	var devices []model.Device
+	since := time.Now().In(model.ServerLocation).AddDate(0, 0, -deviceActiveDays).Format(timeLayout)
+	if err := db.Select("uid", "fcm_token").Where("uid IN ? AND fcm_token <> '' AND last_seen >= ?", uids, since).
+		Order("last_seen DESC").Find(&devices).Error; err != nil {
+		return nil, err
+	}
+
+	tokens := make(map[uint][]string)
+	seen := make(map[string]bool)
+	for _, v := range devices {
+		if seen[v.FcmToken] {
+			continue
+		}
+		seen[v.FcmToken] = true
+		tokens[v.Uid] = append(tokens[v.Uid], v.FcmToken)
+	}
+	return tokens, nil
+}
+
+// 유저별 발송 토큰 - 활성 기기가 없는 유저는 기기 등록 전 유저로 보고 users.fcm_token 사용
+// (fcm_token 도 없으면 빈 토큰으로 발송 실패 기록)
+func pushTokens(db *gorm.DB, uids []uint) (map[uint][]string, error) {
+	tokens, err := activeDeviceTokens(db, uids)
+	if err != nil {
+		return nil, err
+	}
+	var missing []uint
+	for _, uid := range uids {
+		if len(tokens[uid]) == 0 {
+			missing = append(missing, uid)
+		}
+	}
+	if len(missing) == 0 {
+		return tokens, nil
+	}
+	var users []model.User
+	if err := db.Select("id", "fcm_token").Where("id IN ?", missing).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	for _, u := range users {
+		tokens[u.Id] = []string{u.FCMToken}
+	}
+	return tokens, nil
+}
+
+// 메시지별 발송 후 결과 기록 (SendAll 대신 메시지마다 Send 를 병렬 호출)
+func sendPushes(db *gorm.DB, deliveries []model.PushDelivery) {
+	if len(deliveries) == 0 {
+		return
+	}
+
+	jobs := make(chan *model.PushDelivery)
+	var wg sync.WaitGroup
+	for i := 0; i < pushWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for delivery := range jobs {
+				messageId, err := sendPush(delivery)
+				recordPushResult(db, delivery, messageId, err)
+			}
+		}()
+	}
+	for i := range deliveries {
+		jobs <- &deliveries[i]
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+func sendPush(delivery *model.PushDelivery) (string, error) {
+	if delivery.Token == "" {
+		return "", errNoToken
+	}
+	var payload pushPayload
+	if err := json.Unmarshal(delivery.Payload, &payload); err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), pushSendTimeout)
+	defer cancel()
+	return firebaseClient.Send(ctx, &messaging.Message{
+		Data:         payload.Data,
+		Notification: &messaging.Notification{Title: payload.Title, Body: payload.Body},
+		Token:        delivery.Token,
+	})
+}
+
+var errNoToken = errors.New("no fcm token")
+
+// 결과에 따라 성공/재시도/실패 처리, 무효 토큰은 유저와 기기에서 삭제
+func recordPushResult(db *gorm.DB, delivery *model.PushDelivery, messageId string, sendErr error) {
+	now := time.Now().In(model.ServerLocation)
+	attempts := delivery.Attempts + 1
+	updates := map[string]interface{}{"attempts": attempts, "updated": now.Format(timeLayout)}
+
+	status := pushSent
+	switch {
+	case sendErr == nil:
+		updates["message_id"] = messageId
+		updates["error"] = ""
+	case errors.Is(sendErr, errNoToken):
+		status = pushFailed
+	case messaging.IsRegistrationTokenNotRegistered(sendErr):
+		status = pushInvalidToken
+		// 같은 토큰일 때만 삭제 (그 사이 앱에서 새 토큰을 등록했을 수 있음)
+		if err := db.Model(&model.User{}).Where("id = ? AND fcm_token = ?", delivery.Uid, delivery.Token).Update("fcm_token", "").Error; err != nil {
+			log.Printf("error clearing fcm token: %v\n", err)
+		}
+		if err := db.Where("uid = ? AND fcm_token = ?", delivery.Uid, delivery.Token).Delete(&model.Device{}).Error; err != nil {
+			log.Printf("error removing device: %v\n", err)
+		}
+	case isTransientPushError(sendErr) && attempts < pushMaxAttempts:
+		status = pushRetry
+		updates["next_retry"] = now.Add(pushRetryBase << (attempts - 1)).Format(timeLayout)
+	default:
+		status = pushFailed
+	}
+	if sendErr != nil {
+		updates["error"] = sendErr.Error()
+	}
+	updates["status"] = status
+
+	if err := db.Model(&model.PushDelivery{}).Where("id = ?", delivery.Id).Updates(updates).Error; err != nil {
+		log.Printf("error updating push delivery: %v\n", err)
+	}
+
+	// 최종 결과를 알람 발송 기록에 반영 - 기기 중 하나라도 성공하면 발송 성공
+	if delivery.DispatchId == 0 {
+		return
+	}
+	var result *gorm.DB
+	switch status {
+	case pushSent:
+		result = db.Model(&model.AlarmDispatch{}).Where("id = ?", delivery.DispatchId).Update("status", dispatchSent)
+	case pushFailed, pushInvalidToken:
+		result = db.Model(&model.AlarmDispatch{}).Where("id = ? AND status <> ?", delivery.DispatchId, dispatchSent).Update("status", dispatchFailed)
+	default:
+		return
+	}
+	if result.Error != nil {
+		log.Printf("error updating dispatch status: %v\n", result.Error)
+	}
+}
+
+// 서버 오류, 일시 중단, 요청 한도 초과만 재시도 (잘못된 요청이나 인증 정보 오류 등은 재시도해도 실패)
+func isTransientPushError(err error) bool {
+	return messaging.IsServerUnavailable(err) || messaging.IsInternal(err) || messaging.IsMessageRateExceeded(err)
+}
+
+// 재시도 시각이 된 발송과 발송 중 멈춘 발송 (인스턴스 종료 등) 재발송
+func retryPushes(db *gorm.DB) {
+	now := time.Now().In(model.ServerLocation)
+	var deliveries []model.PushDelivery
+	if err := db.Where("(status = ? AND next_retry <= ?) OR (status = ? AND updated <= ?)",
+		pushRetry, now.Format(timeLayout), pushPending, now.Add(-pushStaleTimeout).Format(timeLayout)).
+		Order("id").Limit(pushRetryBatch).Find(&deliveries).Error; err != nil {
+		log.Printf("error loading push retries: %v\n", err)
+		return
+	}
+
+	// 여러 인스턴스가 같은 발송을 재시도하지 않도록 선점
+	var claimed []model.PushDelivery
+	for _, v := range deliveries {
+		result := db.Model(&model.PushDelivery{}).Where("id = ? AND status = ? AND updated = ?", v.Id, v.Status, v.Updated).
+			Updates(map[string]interface{}{"status": pushPending, "updated": now.Format(timeLayout)})
+		if result.Error != nil || result.RowsAffected == 0 {
+			continue
+		}
+		claimed = append(claimed, v)
+	}
+	sendPushes(db, claimed)
+}
+
+// 다른 서비스(약 재고 알림 등)가 넣은 발송 요청을 유저의 활성 기기별 발송으로 나눠 발송
+func expandQueuedPushes(db *gorm.DB) {
+	var queued []model.PushDelivery
+	if err := db.Where("status = ?", pushQueued).Order("id").Limit(pushRetryBatch).Find(&queued).Error; err != nil {
+		log.Printf("error loading queued pushes: %v\n", err)
+		return
+	}
+
+	var deliveries []model.PushDelivery
+	for _, v := range queued {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			// 여러 인스턴스가 같은 요청을 나누지 않도록 삭제로 선점
+			result := tx.Where("id = ? AND status = ?", v.Id, pushQueued).Delete(&model.PushDelivery{})
+			if result.Error != nil || result.RowsAffected == 0 {
+				return result.Error
+			}
+
+			var payload pushPayload
+			if err := json.Unmarshal(v.Payload, &payload); err != nil {
+				return err
+			}
+			if payload.Data == nil {
+				payload.Data = map[string]string{}
+			}
+			payload.Data["notification_count"] = strconv.FormatUint(uint64(getUnreadNotificationCounts(tx, []uint{v.Uid})[v.Uid]), 10)
+			encoded, err := json.Marshal(payload)
+			if err != nil {
+				return err
+			}
+
+			deviceTokens, err := activeDeviceTokens(tx, []uint{v.Uid})
+			if err != nil {
+				return err
+			}
+			tokens := deviceTokens[v.Uid]
+			if len(tokens) == 0 {
+				// 기기 등록 전 유저
+				var user model.User
+				if err := tx.Select("fcm_token").Where("id = ?", v.Uid).First(&user).Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+					return err
+				}
+				tokens = []string{user.FCMToken}
+			}
+			expanded := make([]model.PushDelivery, 0, len(tokens))
+			for _, token := range tokens {
+				expanded = append(expanded, model.PushDelivery{Uid: v.Uid, Token: token, Payload: encoded, Status: pushPending})
+			}
+			if err := tx.Create(&expanded).Error; err != nil {
+				return err
+			}
+			deliveries = append(deliveries, expanded...)
+			return nil
+		})
+		if err != nil {
+			log.Printf("error expanding queued push %d: %v\n", v.Id, err)
+		}
+	}
+	sendPushes(db, deliveries)
+}
@@ -3,21 +3,41 @@ package service
 import (
 	"context"
 	"encoding/json"
-	"fcm-service/common/model"
-	"fcm-service/common/util"
 	"log"
 	"strconv"
 	"time"
 
 	firebase "firebase.google.com/go"
 	"firebase.google.com/go/messaging"
+	"github.com/disterbia/wellkinson/common/model"
+	"github.com/disterbia/wellkinson/common/util"
 	"github.com/robfig/cron/v3"
 	"google.golang.org/api/option"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 var firebaseClient *messaging.Client
 
+const (
+	minuteLayout = util.FireLayout
+
+	// 재시작 후 따라잡을 최대 분 (이보다 오래된 알람은 발송하지 않음)
+	maxCatchUpMinutes = 60
+	// 알람 발송 기록 보관 기간
+	dispatchRetention = 7 * 24 * time.Hour
+
+	dispatchWatermarkName = "alarm"
+	dispatchLockName      = "fcm-alarm-dispatch"
+)
+
+// 알람 발송 상태
+const (
+	dispatchPending = 0
+	dispatchSent    = 1
+	dispatchFailed  = 2
+)
+
 func StartCentralCronScheduler(db *gorm.DB) {
 	initializeFirebase()
 
@@ -48,77 +68,215 @@ func initializeFirebase() {
 	firebaseClient = client
 }
 
+type claimedAlarm struct {
+	dispatchId uint
+	alarm      model.Alarm
+	fireAt     time.Time
+}
+
 func sendPendingNotifications(db *gorm.DB) {
-	now := time.Now()
+	deliveries, err := claimDueAlarms(db, time.Now())
+	if err != nil {
+		log.Printf("error claiming alarms: %v\n", err)
+	} else {
+		sendPushes(db, deliveries)
+	}
 
-	var alarms []model.Alarm
-	if err := db.Preload("User").Where("(start_at = '' OR start_at <= ?) AND (end_at = '' OR end_at >= ?)",
-		now.Format("2006-01-02"), now.Format("2006-01-02")).Find(&alarms).Error; err != nil {
-		return
+	// 복용 기록이 없는 약 알람 재알림/보호자 알림
+	processEscalations(db, time.Now())
+
+	// 다른 서비스가 요청한 푸시 발송
+	expandQueuedPushes(db)
+
+	// 일시적으로 실패한 푸시 재시도
+	retryPushes(db)
+}
+
+// 선점한 알람의 알림함 저장 및 푸시 발송 대기 기록 생성 (알림함은 푸시 성공 여부와 무관하게 저장)
+func createDeliveries(tx *gorm.DB, claimed []claimedAlarm) ([]model.PushDelivery, error) {
+	if len(claimed) == 0 {
+		return nil, nil
+	}
+
+	// 1. 알림함 저장
+	newNotifications := make([]model.Notification, 0, len(claimed))
+	var uids []uint
+	for _, v := range claimed {
+		newNotifications = append(newNotifications, model.Notification{
+			Uid:       v.alarm.Uid,
+			Type:      v.alarm.Type,
+			Body:      v.alarm.Body,
+			ParentId:  v.alarm.ParentId,
+			IsRead:    false,
+			Timestamp: v.alarm.Timestamp,
+		})
+		uids = append(uids, v.alarm.Uid)
+	}
+	if err := tx.Create(&newNotifications).Error; err != nil {
+		return nil, err
 	}
 
 	// 2. 사용자별 미확인 알림 카운트 조회
-	notificationCounts := getUnreadNotificationCounts(db)
-
-	// 3. 메시지와 저장할 알림을 준비
-	var messages []*messaging.Message
-	var newNotifications []model.Notification
-
-	for _, alarm := range alarms {
-
-		if shouldSendNotification(now, alarm) {
-			notificationCount := notificationCounts[alarm.Uid]
-
-			// FCM 메시지 생성
-			message := &messaging.Message{
-				Data: map[string]string{
-					"uid":                strconv.FormatUint(uint64(alarm.Uid), 10),
-					"type":               strconv.FormatUint(uint64(alarm.Type), 10),
-					"notification_count": strconv.FormatUint(uint64(notificationCount), 10),
-					"timestamp":          time.Now().Format(time.RFC3339),
-					"parent_id":          strconv.FormatUint(uint64(alarm.ParentId), 10),
-				},
-				Notification: &messaging.Notification{
-					Title: getNotificationTitle(alarm.Type),
-					Body:  alarm.Body,
-				},
-				Token: alarm.User.FCMToken,
+	notificationCounts := getUnreadNotificationCounts(tx, uids)
+
+	// 3. 기기별 발송 기록 생성 (유저의 활성 기기 모두에 발송)
+	deviceTokens, err := pushTokens(tx, uids)
+	if err != nil {
+		return nil, err
+	}
+	deliveries := make([]model.PushDelivery, 0, len(claimed))
+	for _, v := range claimed {
+		alarm := v.alarm
+		// 알림 액션(확인/다시 알림/복용)에서 사용할 원래 알람 시각
+		scheduledAt := v.fireAt
+		if alarm.ScheduledAt != "" {
+			if t, err := time.Parse(minuteLayout, alarm.ScheduledAt); err == nil {
+				scheduledAt = t
 			}
-			messages = append(messages, message)
-
-			// DB에 저장할 알림 준비
-			newNotifications = append(newNotifications, model.Notification{
-				Uid:       alarm.Uid,
-				Type:      alarm.Type,
-				Body:      alarm.Body,
-				ParentId:  alarm.ParentId,
-				IsRead:    false,
-				Timestamp: alarm.Timestamp,
-			})
 		}
+		payload, err := json.Marshal(pushPayload{
+			Data: map[string]string{
+				"uid":                strconv.FormatUint(uint64(alarm.Uid), 10),
+				"type":               strconv.FormatUint(uint64(alarm.Type), 10),
+				"notification_count": strconv.FormatUint(uint64(notificationCounts[alarm.Uid]), 10),
+				"timestamp":          time.Now().Format(time.RFC3339),
+				"fire_at":            v.fireAt.Format(time.RFC3339),
+				"scheduled_at":       scheduledAt.Format(time.RFC3339),
+				"parent_id":          strconv.FormatUint(uint64(alarm.ParentId), 10),
+				"alarm_id":           strconv.FormatUint(uint64(alarm.Id), 10),
+			},
+			Title: getNotificationTitle(alarm.Type),
+			Body:  alarm.Body,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, token := range deviceTokens[alarm.Uid] {
+			deliveries = append(deliveries, model.PushDelivery{DispatchId: v.dispatchId, Uid: alarm.Uid, Token: token,
+				Payload: payload, Status: pushPending})
+		}
+	}
+	if err := tx.Create(&deliveries).Error; err != nil {
+		return nil, err
 	}
+	return deliveries, nil
+}
+
+// 마지막 처리 분 이후 현재 분까지 발송할 알람을 선점
+// 여러 인스턴스가 동시에 실행되어도 advisory lock 과 (알람, 분) 유니크 기록으로 한번만 발송
+func claimDueAlarms(db *gorm.DB, now time.Time) ([]model.PushDelivery, error) {
+	// 발송 시각은 UTC 로 비교 (알람 시각은 유저 시간대로 계산되어 저장됨)
+	current := now.UTC().Truncate(time.Minute)
+	var deliveries []model.PushDelivery
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var locked bool
+		if err := tx.Raw("SELECT pg_try_advisory_xact_lock(hashtext(?))", dispatchLockName).Scan(&locked).Error; err != nil {
+			return err
+		}
+		if !locked {
+			// 다른 인스턴스가 처리중
+			return nil
+		}
+
+		watermark := model.DispatchWatermark{Name: dispatchWatermarkName}
+		if err := tx.Where(model.DispatchWatermark{Name: dispatchWatermarkName}).
+			Attrs(model.DispatchWatermark{LastMinute: current.Add(-time.Minute).Format(minuteLayout)}).
+			FirstOrCreate(&watermark).Error; err != nil {
+			return err
+		}
+
+		from := current
+		if last, err := time.Parse(minuteLayout, watermark.LastMinute); err == nil {
+			from = last.Add(time.Minute)
+		}
+		if oldest := current.Add(-(maxCatchUpMinutes - 1) * time.Minute); from.Before(oldest) {
+			log.Printf("skipping alarms from %s to %s\n", from.Format(minuteLayout), oldest.Add(-time.Minute).Format(minuteLayout))
+			from = oldest
+		}
+		if from.After(current) {
+			return nil
+		}
+
+		var claimed []claimedAlarm
+		// next_fire_at 인덱스로 발송 시각이 지난 알람만 조회
+		var alarms []model.Alarm
+		if err := tx.Preload("User").Where("next_fire_at <> '' AND next_fire_at <= ?", current.Format(minuteLayout)).
+			Find(&alarms).Error; err != nil {
+			return err
+		}
 
-	// 4. FCM 메시지 일괄 전송
-	if result := sendBatchFCMMessages(messages); result {
-		// 5. 새 알림을 DB에 일괄 저장
-		if len(newNotifications) > 0 {
-			if err := db.Create(&newNotifications).Error; err != nil {
-				log.Printf("error creating notifications: %v\n", err)
+		for _, alarm := range alarms {
+			loc := util.LoadUserLocation(alarm.User.TimeZone)
+			repeat := util.Repeat{Type: alarm.RepeatType, Every: alarm.RepeatEvery, Off: alarm.RepeatOff, Anchor: alarm.AnchorAt}
+			fireAt, err := time.Parse(minuteLayout, alarm.NextFireAt)
+			// 예전 형식, 시간대 변경 표시, 따라잡기 범위를 벗어난 오래된 시각이면 처리할 첫 분부터 다시 계산
+			if err != nil || fireAt.Before(from) {
+				fireAt, err = time.Parse(minuteLayout, util.NextFireAt(alarm.StartAt, alarm.EndAt, alarm.Timestamp, alarm.Week, repeat, loc, from.Add(-time.Minute)))
+			}
+			for err == nil && !fireAt.After(current) {
+				dispatch := model.AlarmDispatch{AlarmId: alarm.Id, FireMinute: fireAt.Format(minuteLayout), Uid: alarm.Uid, Status: dispatchPending}
+				result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&dispatch)
+				if result.Error != nil {
+					return result.Error
+				}
+				if result.RowsAffected > 0 {
+					claimed = append(claimed, claimedAlarm{dispatchId: dispatch.Id, alarm: alarm, fireAt: fireAt})
+				}
+				fireAt, err = time.Parse(minuteLayout, util.NextFireAt(alarm.StartAt, alarm.EndAt, alarm.Timestamp, alarm.Week, repeat, loc, fireAt))
+			}
+
+			// 현재 분 이후의 다음 발송 시각으로 갱신 (기간이 끝나면 "")
+			next := util.NextFireAt(alarm.StartAt, alarm.EndAt, alarm.Timestamp, alarm.Week, repeat, loc, current)
+			// 그 사이 alarm-service 에서 알람이 수정됐으면 새로 계산된 값을 유지
+			query := tx.Where("id = ? AND next_fire_at = ?", alarm.Id, alarm.NextFireAt)
+			if next == "" && alarm.ScheduledAt != "" {
+				// 발송이 끝난 다시 알림은 삭제
+				if err := query.Delete(&model.Alarm{}).Error; err != nil {
+					return err
+				}
+			} else if err := query.Model(&model.Alarm{}).Update("next_fire_at", next).Error; err != nil {
+				return err
 			}
 		}
-	}
 
+		var err error
+		if deliveries, err = createDeliveries(tx, claimed); err != nil {
+			return err
+		}
+		if err := createEscalations(tx, claimed); err != nil {
+			return err
+		}
+
+		if err := tx.Model(&watermark).Update("last_minute", current.Format(minuteLayout)).Error; err != nil {
+			return err
+		}
+		// 오래된 발송 기록 정리
+		expired := now.Add(-dispatchRetention).In(model.ServerLocation).Format(timeLayout)
+		if err := tx.Where("created < ?", expired).Delete(&model.AlarmDispatch{}).Error; err != nil {
+			return err
+		}
+		// 누락 기록은 복용 달력에서 조회하므로 유지
+		if err := tx.Where("stage = ? AND created < ?", escalationCompleted, expired).Delete(&model.DoseEscalation{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("created < ?", expired).Delete(&model.PushDelivery{}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
 }
 
 // 사용자별 읽지 않은 알림 수 조회
-func getUnreadNotificationCounts(db *gorm.DB) map[uint]uint {
+func getUnreadNotificationCounts(db *gorm.DB, uids []uint) map[uint]uint {
 	var results []struct {
 		Uid   uint
 		Count uint
 	}
 	db.Model(&model.Notification{}).
 		Select("uid, COUNT(*) as count").
-		Where("is_read = ?", false).
+		Where("uid IN ? AND is_read = ?", uids, false).
 		Group("uid").
 		Scan(&results)
 
@@ -129,22 +287,6 @@ func getUnreadNotificationCounts(db *gorm.DB) map[uint]uint {
 	return counts
 }
 
-// FCM 메시지를 일괄 전송
-func sendBatchFCMMessages(messages []*messaging.Message) bool {
-	if len(messages) == 0 {
-		return false
-	}
-	ctx := context.Background()
-	br, err := firebaseClient.SendAll(ctx, messages)
-	if err != nil {
-		log.Printf("error sending batch messages: %v\n", err)
-		return false
-	} else {
-		log.Printf("Successfully sent %d messages\n", br.SuccessCount)
-		return true
-	}
-}
-
 // 알람 유형에 따른 제목 생성
 func getNotificationTitle(notificationType uint) string {
 	switch notificationType {
@@ -158,22 +300,3 @@ func getNotificationTitle(notificationType uint) string {
 		return "알림"
 	}
 }
-
-// 알람이 전송될 조건인지 확인
-func shouldSendNotification(now time.Time, alarm model.Alarm) bool {
-	currentWeekday := int(now.Weekday())
-
-	var alarmWeekdays []int
-	if err := json.Unmarshal(alarm.Week, &alarmWeekdays); err != nil {
-		return false
-	}
-
-	alarmTime, _ := time.Parse("15:04", alarm.Timestamp)
-
-	for _, weekday := range alarmWeekdays {
-		if weekday == currentWeekday && now.Format("15:04") == alarmTime.Format("15:04") {
-			return true
-		}
-	}
-	return false
-}
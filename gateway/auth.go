@@ -0,0 +1,130 @@
+// /gateway/auth.go
+
+package main
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/disterbia/wellkinson/common/util"
+	"github.com/gin-gonic/gin"
+)
+
+// 토큰 없이 호출 가능한 경로 (로그인, 인증번호, 공통 조회, swagger)
+var publicPaths = map[string]bool{
+	"/user/admin-login":           true,
+	"/user/admin-send-code":       true,
+	"/user/admin-change-password": true,
+	"/user/sns-login":             true,
+	"/user/verify-code":           true,
+	"/user/get-polices":           true,
+	"/user/get-version":           true,
+	"/user/get-services":          true,
+	"/user/refresh-token":         true,
+	"/user/auto-login":            true,
+	"/user/.well-known/jwks.json": true,
+	"/face/get-face-exams":        true,
+	"/face/get-face-exercises":    true,
+	"/medicine/search-medicines":  true,
+	"/vocal/get-voice-tables":     true,
+}
+
+var publicPrefixes = []string{
+	"/user/send-code/",
+}
+
+// 서비스 간 내부 호출 전용 경로 (외부 노출 차단)
+var internalPaths = map[string]bool{
+	"/user/revoked-sessions": true,
+}
+
+type identity struct {
+	Id    uint
+	Email string
+	Roles []string
+	Sid   uint
+}
+
+func isPublicPath(path string) bool {
+	if publicPaths[path] {
+		return true
+	}
+	for _, prefix := range publicPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	// 각 서비스의 swagger 문서
+	parts := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 3)
+	return len(parts) >= 2 && parts[1] == "swagger"
+}
+
+// 서명은 user-service 의 JWKS 로, 세션 폐기 여부는 폴링한 목록으로 검증 (util.SetRevocationChecker)
+func verifyToken(tokenString string) (identity, error) {
+	claims, err := util.ParseToken(tokenString)
+	if err != nil {
+		return identity{}, err
+	}
+
+	id, _ := claims["id"].(float64)
+	email, _ := claims["email"].(string)
+	if email == "" || id == 0 {
+		return identity{}, util.NewError(util.ErrUnauthorized, "id or email not found in token")
+	}
+	sid, _ := claims["sid"].(float64)
+
+	return identity{Id: uint(id), Email: email, Roles: util.RolesFromClaims(claims), Sid: uint(sid)}, nil
+}
+
+// 클라이언트가 보낸 X-Forwarded-For, X-Real-IP 는 버리고 실제 접속 주소로 교체
+// (X-Forwarded-For 는 ReverseProxy 가 접속 주소로 다시 채움) - 백엔드는 게이트웨이 주소만 프록시로 신뢰
+func ClientIPMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request.Header.Del("X-Forwarded-For")
+		c.Request.Header.Set("X-Real-IP", c.RemoteIP())
+		c.Next()
+	}
+}
+
+// 토큰을 한번만 검증하고 검증된 신원을 헤더로 백엔드에 전달
+func AuthMiddleware() gin.HandlerFunc {
+	gatewaySecret := os.Getenv("GATEWAY_SECRET")
+
+	return func(c *gin.Context) {
+		// 클라이언트가 직접 보낸 신원 헤더는 신뢰하지 않음
+		c.Request.Header.Del(util.GatewayTokenHeader)
+		c.Request.Header.Del(util.UserIdHeader)
+		c.Request.Header.Del(util.UserEmailHeader)
+		c.Request.Header.Del(util.UserRolesHeader)
+		c.Request.Header.Del(util.SessionIdHeader)
+
+		if internalPaths[c.Request.URL.Path] {
+			util.AbortError(c, util.NewError(util.ErrNotFound, ""))
+			return
+		}
+
+		if isPublicPath(c.Request.URL.Path) {
+			c.Next()
+			return
+		}
+
+		user, err := verifyToken(c.GetHeader("Authorization"))
+		if err != nil {
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
+			return
+		}
+
+		c.Request.Header.Set(util.UserIdHeader, strconv.FormatUint(uint64(user.Id), 10))
+		c.Request.Header.Set(util.UserEmailHeader, user.Email)
+		c.Request.Header.Set(util.UserRolesHeader, strings.Join(user.Roles, ","))
+		if user.Sid != 0 {
+			c.Request.Header.Set(util.SessionIdHeader, strconv.FormatUint(uint64(user.Sid), 10))
+		}
+		if gatewaySecret != "" {
+			c.Request.Header.Set(util.GatewayTokenHeader, gatewaySecret)
+		}
+
+		c.Next()
+	}
+}
@@ -3,9 +3,13 @@
 package main
 
 import (
+	"encoding/json"
+	"log"
+	"net/http"
 	"net/http/httputil"
 	"net/url"
 
+	"github.com/disterbia/wellkinson/common/util"
 	"github.com/gin-gonic/gin"
 )
 
@@ -65,11 +69,17 @@ import (
 //	}
 func main() {
 	router := gin.Default()
+	// 게이트웨이가 맨 앞이므로 클라이언트가 보낸 전달 헤더는 신뢰하지 않음
+	router.SetTrustedProxies(nil)
 	// router.Use(IPRateLimitMiddleware())
+	StartRevocationPoller()
+	router.Use(ClientIPMiddleware())
+	router.Use(AuthMiddleware())
 
 	//서비스로의 리버스 프록시 설정
 	adminServiceURL, _ := url.Parse("http://admin:44400")
 	adminProxy := httputil.NewSingleHostReverseProxy(adminServiceURL)
+	adminProxy.ErrorHandler = proxyErrorHandler
 	router.Any("/admin/*path", func(c *gin.Context) {
 		c.Request.URL.Path = c.Param("path")
 		adminProxy.ServeHTTP(c.Writer, c.Request)
@@ -77,6 +87,7 @@ func main() {
 
 	alarmServiceURL, _ := url.Parse("http://alarm:44401")
 	alarmProxy := httputil.NewSingleHostReverseProxy(alarmServiceURL)
+	alarmProxy.ErrorHandler = proxyErrorHandler
 	router.Any("/alarm/*path", func(c *gin.Context) {
 		c.Request.URL.Path = c.Param("path")
 		alarmProxy.ServeHTTP(c.Writer, c.Request)
@@ -84,6 +95,7 @@ func main() {
 
 	dietServiceURL, _ := url.Parse("http://diet:44402")
 	dietProxy := httputil.NewSingleHostReverseProxy(dietServiceURL)
+	dietProxy.ErrorHandler = proxyErrorHandler
 	router.Any("/diet/*path", func(c *gin.Context) {
 		c.Request.URL.Path = c.Param("path")
 		dietProxy.ServeHTTP(c.Writer, c.Request)
@@ -91,6 +103,7 @@ func main() {
 
 	emotionServiceURL, _ := url.Parse("http://emotion:44403")
 	emotionProxy := httputil.NewSingleHostReverseProxy(emotionServiceURL)
+	emotionProxy.ErrorHandler = proxyErrorHandler
 	router.Any("/emotion/*path", func(c *gin.Context) {
 		c.Request.URL.Path = c.Param("path")
 		emotionProxy.ServeHTTP(c.Writer, c.Request)
@@ -98,6 +111,7 @@ func main() {
 
 	exerciseServiceURL, _ := url.Parse("http://exercise:44404")
 	exerciseProxy := httputil.NewSingleHostReverseProxy(exerciseServiceURL)
+	exerciseProxy.ErrorHandler = proxyErrorHandler
 	router.Any("/exercise/*path", func(c *gin.Context) {
 		c.Request.URL.Path = c.Param("path")
 		exerciseProxy.ServeHTTP(c.Writer, c.Request)
@@ -105,6 +119,7 @@ func main() {
 
 	faceServiceURL, _ := url.Parse("http://face:44405")
 	faceProxy := httputil.NewSingleHostReverseProxy(faceServiceURL)
+	faceProxy.ErrorHandler = proxyErrorHandler
 	router.Any("/face/*path", func(c *gin.Context) {
 		c.Request.URL.Path = c.Param("path")
 		faceProxy.ServeHTTP(c.Writer, c.Request)
@@ -112,6 +127,7 @@ func main() {
 
 	inquireServiceURL, _ := url.Parse("http://inquire:44406")
 	inquireProxy := httputil.NewSingleHostReverseProxy(inquireServiceURL)
+	inquireProxy.ErrorHandler = proxyErrorHandler
 	router.Any("/inquire/*path", func(c *gin.Context) {
 		c.Request.URL.Path = c.Param("path")
 		inquireProxy.ServeHTTP(c.Writer, c.Request)
@@ -119,6 +135,7 @@ func main() {
 
 	medicineServiceURL, _ := url.Parse("http://medicine:44407")
 	medicineProxy := httputil.NewSingleHostReverseProxy(medicineServiceURL)
+	medicineProxy.ErrorHandler = proxyErrorHandler
 	router.Any("/medicine/*path", func(c *gin.Context) {
 		c.Request.URL.Path = c.Param("path")
 		medicineProxy.ServeHTTP(c.Writer, c.Request)
@@ -126,6 +143,7 @@ func main() {
 
 	sleepServiceURL, _ := url.Parse("http://sleep:44408")
 	sleepProxy := httputil.NewSingleHostReverseProxy(sleepServiceURL)
+	sleepProxy.ErrorHandler = proxyErrorHandler
 	router.Any("/sleep/*path", func(c *gin.Context) {
 		c.Request.URL.Path = c.Param("path")
 		sleepProxy.ServeHTTP(c.Writer, c.Request)
@@ -133,6 +151,7 @@ func main() {
 
 	userServiceURL, _ := url.Parse("http://user:44409")
 	userProxy := httputil.NewSingleHostReverseProxy(userServiceURL)
+	userProxy.ErrorHandler = proxyErrorHandler
 	router.Any("/user/*path", func(c *gin.Context) {
 		c.Request.URL.Path = c.Param("path") // '/user' 접두사 제거
 		userProxy.ServeHTTP(c.Writer, c.Request)
@@ -140,6 +159,7 @@ func main() {
 
 	vocalServiceURL, _ := url.Parse("http://vocal:44410")
 	vocalProxy := httputil.NewSingleHostReverseProxy(vocalServiceURL)
+	vocalProxy.ErrorHandler = proxyErrorHandler
 	router.Any("/vocal/*path", func(c *gin.Context) {
 		c.Request.URL.Path = c.Param("path")
 		vocalProxy.ServeHTTP(c.Writer, c.Request)
@@ -172,3 +192,12 @@ func setupSwaggerUIProxy(router *gin.Engine, path string, target string) {
 		proxy.ServeHTTP(c.Writer, c.Request)
 	})
 }
+
+// 백엔드 서비스 연결 실패시 응답 (ReverseProxy.ErrorHandler)
+func proxyErrorHandler(w http.ResponseWriter, r *http.Request, err error) {
+	log.Printf("proxy %s: %v", r.URL.Path, err)
+	code := util.ErrServiceUnavailable
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(util.ErrorStatus(code))
+	json.NewEncoder(w).Encode(util.ErrorBody{Code: code, Message: util.ErrorMessage(code, r.Header.Get("Accept-Language"))})
+}
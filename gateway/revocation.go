@@ -0,0 +1,81 @@
+// /gateway/revocation.go
+
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/disterbia/wellkinson/common/util"
+)
+
+// user-service 에서 주기적으로 받아오는 폐기된 세션 목록
+var (
+	revokedSessions   = make(map[uint]bool)
+	revokedSessionsMu sync.RWMutex
+)
+
+const revokedSessionsURL = "http://user:44409/revoked-sessions"
+const revocationPollInterval = 30 * time.Second
+
+func isSessionRevoked(sid uint) bool {
+	revokedSessionsMu.RLock()
+	defer revokedSessionsMu.RUnlock()
+	return revokedSessions[sid]
+}
+
+// 폐기 목록 갱신 - 실패하면 이전 목록을 그대로 사용
+func refreshRevokedSessions(client *http.Client, gatewaySecret string) {
+	req, err := http.NewRequest(http.MethodGet, revokedSessionsURL, nil)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	req.Header.Set(util.GatewayTokenHeader, gatewaySecret)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		log.Println("revoked sessions poll error:", err)
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		log.Println("revoked sessions poll status:", resp.StatusCode)
+		return
+	}
+
+	var ids []uint
+	if err := json.NewDecoder(resp.Body).Decode(&ids); err != nil {
+		log.Println("revoked sessions decode error:", err)
+		return
+	}
+
+	revoked := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		revoked[id] = true
+	}
+	revokedSessionsMu.Lock()
+	revokedSessions = revoked
+	revokedSessionsMu.Unlock()
+}
+
+func StartRevocationPoller() {
+	gatewaySecret := os.Getenv("GATEWAY_SECRET")
+	if gatewaySecret == "" {
+		log.Println("GATEWAY_SECRET not set, session revocation is checked by services only")
+		return
+	}
+	client := &http.Client{Timeout: 5 * time.Second}
+	util.SetRevocationChecker(isSessionRevoked)
+
+	go func() {
+		for {
+			refreshRevokedSessions(client, gatewaySecret)
+			time.Sleep(revocationPollInterval)
+		}
+	}()
+}
@@ -72,10 +72,8 @@ func GetEndpoint(s service.InquireService) endpoint.Endpoint {
 
 func GetAllEndpoint(s service.InquireService) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
-		reqMap := request.(map[string]interface{})
-		id := reqMap["id"].(uint)
-		queryParams := reqMap["queryParams"].(dto.GetInquireParams)
-		inquires, err := s.GetAllInquires(id, queryParams.Page, queryParams.StartDate, queryParams.EndDate)
+		queryParams := request.(dto.GetInquireParams)
+		inquires, err := s.GetAllInquires(queryParams.Page, queryParams.StartDate, queryParams.EndDate)
 		if err != nil {
 			return dto.BasicResponse{Code: err.Error()}, err
 		}
@@ -14,11 +14,10 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 	ginSwagger "github.com/swaggo/gin-swagger"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 
 	_ "inquire-service/docs"
 
+	"github.com/disterbia/wellkinson/common/util"
 	swaggerFiles "github.com/swaggo/files"
 )
 
@@ -34,7 +33,7 @@ func main() {
 	}
 
 	// gRPC 클라이언트 연결 생성
-	conn, err := grpc.Dial("email:50052", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := service.DialEmail("email:50052")
 	if err != nil {
 		log.Fatalf("failed to connect to email service: %v", err)
 	}
@@ -61,7 +60,7 @@ func main() {
 	router.POST("/remove-inquire/:id", transport.RemoveInquireHandler(removeInquireEndpoint))
 	router.POST("/remove-reply/:id", transport.RemoveReplyHandler(reomoveReplyEndpoint))
 	router.GET("/get-inquires", transport.GetHandler(getEndpoint))
-	router.GET("/all-inquires", transport.GetAllHandler(allEndpoint))
+	router.GET("/all-inquires", util.RequirePermission(util.PermInquireManage), transport.GetAllHandler(allEndpoint))
 
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
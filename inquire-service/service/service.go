@@ -4,15 +4,18 @@ package service
 
 import (
 	"context"
-	"errors"
-	"inquire-service/common/model"
-	"inquire-service/common/util"
 	"log"
+	"time"
 
 	"inquire-service/dto"
 	pb "inquire-service/proto"
 
+	"github.com/disterbia/wellkinson/common/model"
+	"github.com/disterbia/wellkinson/common/util"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	_ "google.golang.org/grpc/health"
+	"google.golang.org/grpc/status"
 	"gorm.io/gorm"
 )
 
@@ -20,7 +23,7 @@ type InquireService interface {
 	AnswerInquire(answer dto.InquireReplyRequest) (string, error)
 	SendInquire(inquire dto.InquireRequest) (string, error)
 	GetMyInquires(id uint, page uint, startDate, endDate string) ([]dto.InquireResponse, error)
-	GetAllInquires(id uint, page uint, startDate, endDate string) ([]dto.InquireResponse, error)
+	GetAllInquires(page uint, startDate, endDate string) ([]dto.InquireResponse, error)
 	RemoveInquire(id uint, uid uint) (string, error)
 	RemoveReply(id uint, uid uint) (string, error)
 }
@@ -30,6 +33,29 @@ type inquireService struct {
 	emailClient pb.EmailServiceClient
 }
 
+// email-service 호출 설정 - 시도마다 10초 제한, Unavailable(연결 실패, SMTP 오류)이면 최대 2번 더 시도
+// 헬스 체크로 준비된 연결에만 요청
+const emailServiceConfig = `{
+	"methodConfig": [{
+		"name": [{"service": "emailservice.EmailService"}],
+		"timeout": "10s",
+		"retryPolicy": {
+			"maxAttempts": 3,
+			"initialBackoff": "1s",
+			"maxBackoff": "5s",
+			"backoffMultiplier": 2,
+			"retryableStatusCodes": ["UNAVAILABLE"]
+		}
+	}],
+	"healthCheckConfig": {"serviceName": "emailservice.EmailService"}
+}`
+
+const emailSendTimeout = 40 * time.Second // 재시도 포함
+
+func DialEmail(addr string) (*grpc.ClientConn, error) {
+	return grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithDefaultServiceConfig(emailServiceConfig))
+}
+
 func NewInquireService(db *gorm.DB, conn *grpc.ClientConn) InquireService {
 	emailClient := pb.NewEmailServiceClient(conn)
 	return &inquireService{
@@ -77,7 +103,8 @@ func (service *inquireService) GetMyInquires(id uint, page uint, startDate, endD
 	return inquireResponses, nil
 }
 
-func (service *inquireService) GetAllInquires(id uint, page uint, startDate, endDate string) ([]dto.InquireResponse, error) {
+// 권한 검사는 라우트의 RequirePermission(inquire:manage) 에서 처리
+func (service *inquireService) GetAllInquires(page uint, startDate, endDate string) ([]dto.InquireResponse, error) {
 
 	if startDate != "" {
 		if err := util.ValidateDate(startDate); err != nil {
@@ -94,17 +121,6 @@ func (service *inquireService) GetAllInquires(id uint, page uint, startDate, end
 	var inquires []model.Inquire
 	offset := page * pageSize
 
-	var user model.User
-	result := service.db.First(&user, id)
-
-	if result.Error != nil {
-		return nil, errors.New("db error")
-	}
-
-	if !user.IsAdmin {
-		return nil, errors.New("unauthorized: user is not an admin")
-	}
-
 	query := service.db.Model(&model.Inquire{})
 
 	if startDate != "" {
@@ -114,7 +130,7 @@ func (service *inquireService) GetAllInquires(id uint, page uint, startDate, end
 		query = query.Where("created <= ?", endDate)
 	}
 	query = query.Order("id DESC")
-	result = query.Offset(int(offset)).Limit(int(pageSize)).Preload("Replies", "level= 0").Find(&inquires)
+	result := query.Offset(int(offset)).Limit(int(pageSize)).Preload("Replies", "level= 0").Find(&inquires)
 
 	if result.Error != nil {
 		return nil, result.Error
@@ -138,35 +154,28 @@ func (service *inquireService) SendInquire(inquireRequest dto.InquireRequest) (s
 	result := service.db.Create(&inquire)
 
 	if result.Error != nil {
-		return "", errors.New("db error")
+		return "", util.NewError(util.ErrDatabase, "db error")
 	}
 	return "200", nil
 }
 
 func (service *inquireService) AnswerInquire(inquireReplyRequest dto.InquireReplyRequest) (string, error) {
-	var user model.User
 	var inquire model.Inquire
 	var inquireReply model.InquireReply
 
-	result := service.db.First(&user, inquireReplyRequest.Uid)
-
-	if result.Error != nil {
-		return "", errors.New("db error")
-	}
-
 	result2 := service.db.First(&inquire, inquireReplyRequest.InquireId)
 
 	if result2.Error != nil {
-		return "", errors.New("db error2")
+		return "", util.NewError(util.ErrDatabase, "db error2")
 	}
 
 	if inquireReplyRequest.ReplyType { // true = 답변
-		if !user.IsAdmin {
-			return "", errors.New("unauthorized: user is not an admin")
+		if !inquireReplyRequest.IsManager {
+			return "", util.NewError(util.ErrForbidden, "unauthorized: user is not an admin")
 		}
 	} else { // 추가문의
-		if user.Id != inquire.Uid {
-			return "", errors.New("unauthorized: illegal user")
+		if inquireReplyRequest.Uid != inquire.Uid {
+			return "", util.NewError(util.ErrForbidden, "unauthorized: illegal user")
 		}
 	}
 
@@ -174,14 +183,16 @@ func (service *inquireService) AnswerInquire(inquireReplyRequest dto.InquireRepl
 		return "", err
 	}
 	inquireReply.Uid = inquireReplyRequest.Uid
-	result = service.db.Create(&inquireReply)
+	result := service.db.Create(&inquireReply)
 
 	if result.Error != nil {
-		return "", errors.New("db error")
+		return "", util.NewError(util.ErrDatabase, "db error")
 	}
 
 	go func() {
-		reponse, err := service.emailClient.SendEmail(context.Background(), &pb.EmailRequest{
+		ctx, cancel := context.WithTimeout(context.Background(), emailSendTimeout)
+		defer cancel()
+		reponse, err := service.emailClient.SendEmail(ctx, &pb.EmailRequest{
 			Email:        inquire.Email,        // 받는 사람의 이메일
 			Created:      inquire.Created,      // 문의 생성 날짜
 			Title:        inquire.Title,        // 이메일 제목
@@ -190,7 +201,7 @@ func (service *inquireService) AnswerInquire(inquireReplyRequest dto.InquireRepl
 			ReplyCreated: inquireReply.Created, // 답변 생성 날짜
 		})
 		if err != nil {
-			log.Printf("Failed to send email: %v", err)
+			log.Printf("Failed to send email (%s): %v", status.Code(err), err)
 		}
 		log.Printf(" send email: %v", reponse)
 	}()
@@ -203,7 +214,7 @@ func (service *inquireService) RemoveInquire(id uint, uid uint) (string, error)
 	var inquire model.Inquire
 	result := service.db.Model(&inquire).Where("id = ? AND uid = ?", id, uid).Select("level").Updates(map[string]interface{}{"level": 10})
 	if result.Error != nil {
-		return "", errors.New("db error")
+		return "", util.NewError(util.ErrDatabase, "db error")
 	}
 	return "200", nil
 }
@@ -214,7 +225,7 @@ func (service *inquireService) RemoveReply(id uint, uid uint) (string, error) {
 	result := service.db.Model(&inquireReply).Where("id = ? AND uid = ?", id, uid).Select("level").Updates(map[string]interface{}{"level": 10})
 
 	if result.Error != nil {
-		return "", errors.New("db error")
+		return "", util.NewError(util.ErrDatabase, "db error")
 	}
 	return "200", nil
 }
@@ -0,0 +1,63 @@
+// /medicine-service/cmd/drug-import/main.go
+// 의약품 목록 가져오기
+//
+//	drug-import -products 의약품허가정보.csv -interactions 병용금기.csv
+package main
+
+import (
+	"flag"
+	"io"
+	"log"
+	"medicine-service/db"
+	"medicine-service/service"
+	"os"
+
+	"github.com/disterbia/wellkinson/common/model"
+	"github.com/joho/godotenv"
+	"gorm.io/gorm"
+)
+
+func main() {
+	products := flag.String("products", "", "식약처 의약품 제품 허가정보 CSV")
+	interactions := flag.String("interactions", "", "DUR 병용금기 CSV")
+	flag.Parse()
+	if *products == "" && *interactions == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := godotenv.Load(".env"); err != nil {
+		log.Fatalln("Error loading .env file")
+	}
+	database, err := db.NewDB(os.Getenv("DB_PATH"))
+	if err != nil {
+		log.Fatalln("Database connection error:", err)
+	}
+	if err := database.AutoMigrate(&model.DrugProduct{}, &model.DrugIngredient{}, &model.DrugInteraction{}); err != nil {
+		log.Fatalln("migration error:", err)
+	}
+
+	if *products != "" {
+		count, err := importFile(database, *products, service.ImportDrugProducts)
+		if err != nil {
+			log.Fatalf("product import error after %d rows: %v", count, err)
+		}
+		log.Printf("imported %d products", count)
+	}
+	if *interactions != "" {
+		count, err := importFile(database, *interactions, service.ImportDrugInteractions)
+		if err != nil {
+			log.Fatalf("interaction import error after %d rows: %v", count, err)
+		}
+		log.Printf("imported %d interactions", count)
+	}
+}
+
+func importFile(database *gorm.DB, path string, importer func(*gorm.DB, io.Reader) (int, error)) (int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	return importer(database, file)
+}
@@ -15,16 +15,16 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/get-medicines": {
+        "/check-drug-warnings": {
             "get": {
-                "description": "등록 약물 조회시 호출",
+                "description": "약물 등록 전 복용중인 약과 중복 성분, 상호작용 확인시 호출 - severity 1:주의 2:병용금기",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "약물 /medicine"
                 ],
-                "summary": "등록 약물 조회",
+                "summary": "의약품 상호작용 확인",
                 "parameters": [
                     {
                         "type": "string",
@@ -32,15 +32,22 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "의약품 id",
+                        "name": "drug_product_id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "등록 약물 정보",
+                        "description": "경고 목록",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/dto.MedicineResponse"
+                                "$ref": "#/definitions/dto.DrugWarning"
                             }
                         }
                     },
@@ -50,6 +57,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "요청 처리 실패시 오류 메시지 반환",
                         "schema": {
@@ -59,16 +72,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/get-takens": {
+        "/get-adherence": {
             "get": {
-                "description": "약물 복용내역 조회시 호출",
+                "description": "기간의 약물별/전체 복용률 조회시 호출 (날짜가 없으면 유저 시간대 기준 최근 30일, 최대 366일)\n예정 시각이 지난 복용만 계산 (필요시 복용, 중지, 삭제된 약 제외) - on_time: 예정 시각 1시간 안에 복용, late: 1시간 후 복용\ncurrent_streak/longest_streak: 예정된 약을 모두 복용한 연속 일수 (예정이 없는 날은 건너뜀) / weekly: 월요일 시작 주별, monthly: 월별 합계",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "약물 /medicine"
                 ],
-                "summary": "약물 복용내역 조회",
+                "summary": "약물 복용률 조회",
                 "parameters": [
                     {
                         "type": "string",
@@ -81,25 +94,20 @@ const docTemplate = `{
                         "type": "string",
                         "description": "시작날짜 yyyy-mm-dd",
                         "name": "start_date",
-                        "in": "query",
-                        "required": true
+                        "in": "query"
                     },
                     {
                         "type": "string",
                         "description": "종료날짜 yyyy-mm-dd",
                         "name": "end_date",
-                        "in": "query",
-                        "required": true
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "운동정보",
+                        "description": "복용률",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.MedicineDateInfo"
-                            }
+                            "$ref": "#/definitions/dto.AdherenceResponse"
                         }
                     },
                     "400": {
@@ -108,6 +116,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "요청 처리 실패시 오류 메시지 반환",
                         "schema": {
@@ -117,19 +131,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/remove-medicines": {
-            "post": {
-                "description": "약물 삭제시 호출",
-                "consumes": [
-                    "application/json"
-                ],
+        "/get-medicines": {
+            "get": {
+                "description": "등록 약물 조회시 호출",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "약물 /medicine"
                 ],
-                "summary": "약물 삭제",
+                "summary": "등록 약물 조회",
                 "parameters": [
                     {
                         "type": "string",
@@ -137,25 +148,16 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "description": "삭제할 id 배열",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "integer"
-                            }
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "성공시 200 반환",
+                        "description": "등록 약물 정보",
                         "schema": {
-                            "$ref": "#/definitions/dto.BasicResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.MedicineOriginResponse"
+                            }
                         }
                     },
                     "400": {
@@ -164,6 +166,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "요청 처리 실패시 오류 메시지 반환",
                         "schema": {
@@ -173,16 +181,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/save-medicine": {
-            "post": {
-                "description": "약물등록 및 수정시 호출",
+        "/get-refill-forecasts": {
+            "get": {
+                "description": "재고 관리(use_least_store) 중인 약물의 소진 예상 조회시 호출\n복용 일정과 기간별 용량으로 남은 재고가 떨어지는 날 계산 (1년 안에 떨어지지 않거나 필요시 복용이면 days_left -1)\nneed_refill: 최소 재고 미만이거나 곧 떨어지는 약 (하루 9시~21시 사이 보충 알림 발송, 보충 전까지 3일마다 다시 알림)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "약물 /medicine"
                 ],
-                "summary": "약물 저장",
+                "summary": "약물 재고 소진 예상",
                 "parameters": [
                     {
                         "type": "string",
@@ -190,22 +198,16 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "description": "요청 DTO - 약물데이터",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.MedicineRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "성공시 200 반환",
+                        "description": "약물별 소진 예상",
                         "schema": {
-                            "$ref": "#/definitions/dto.BasicResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.RefillForecastResponse"
+                            }
                         }
                     },
                     "400": {
@@ -214,6 +216,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "요청 처리 실패시 오류 메시지 반환",
                         "schema": {
@@ -223,32 +231,44 @@ const docTemplate = `{
                 }
             }
         },
-        "/search-medicines": {
+        "/get-takens": {
             "get": {
-                "description": "약물 검색 키워드 입력시 호출",
+                "description": "약물 복용내역 조회시 호출 (날짜가 없으면 유저 시간대 기준 오늘) - missed: 알람 후 복용하지 않아 보호자에게 알린 시각",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "약물 /medicine"
                 ],
-                "summary": "약물 찾기",
+                "summary": "약물 복용내역 조회",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "키워드",
-                        "name": "keyword",
-                        "in": "query",
+                        "description": "Bearer {jwt_token}",
+                        "name": "Authorization",
+                        "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "시작날짜 yyyy-mm-dd",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "종료날짜 yyyy-mm-dd",
+                        "name": "end_date",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "약물명",
+                        "description": "운동정보",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "type": "string"
+                                "$ref": "#/definitions/dto.MedicineDateInfo"
                             }
                         }
                     },
@@ -258,6 +278,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "요청 처리 실패시 오류 메시지 반환",
                         "schema": {
@@ -267,9 +293,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/take-medicine": {
+        "/refill-medicine": {
             "post": {
-                "description": "약물 복용시 호출",
+                "description": "약을 보충했을때 호출 - 재고(store)에 amount 만큼 더하고 재고 부족 알림 초기화",
                 "consumes": [
                     "application/json"
                 ],
@@ -279,7 +305,7 @@ const docTemplate = `{
                 "tags": [
                     "약물 /medicine"
                 ],
-                "summary": "약물 복용",
+                "summary": "약물 보충",
                 "parameters": [
                     {
                         "type": "string",
@@ -289,12 +315,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "약물 복용 데이터",
+                        "description": "약물 보충 데이터",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.TakeMedicine"
+                            "$ref": "#/definitions/dto.RefillRequest"
                         }
                     }
                 ],
@@ -311,6 +337,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "요청 처리 실패시 오류 메시지 반환",
                         "schema": {
@@ -320,9 +352,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/untake-medicine": {
+        "/remove-medicines": {
             "post": {
-                "description": "약물 복용취소시 호출",
+                "description": "약물 삭제시 호출",
                 "consumes": [
                     "application/json"
                 ],
@@ -332,7 +364,7 @@ const docTemplate = `{
                 "tags": [
                     "약물 /medicine"
                 ],
-                "summary": "약물 복용취소",
+                "summary": "약물 삭제",
                 "parameters": [
                     {
                         "type": "string",
@@ -342,12 +374,15 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "약물 취소 데이터",
+                        "description": "삭제할 id 배열",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UnTakeMedicine"
+                            "type": "array",
+                            "items": {
+                                "type": "integer"
+                            }
                         }
                     }
                 ],
@@ -364,6 +399,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "요청 처리 실패시 오류 메시지 반환",
                         "schema": {
@@ -372,45 +413,581 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dto.BasicResponse": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "string"
-                }
-            }
         },
-        "dto.ErrorResponse": {
-            "type": "object",
-            "properties": {
-                "err": {
-                    "type": "string"
+        "/save-medicine": {
+            "post": {
+                "description": "약물등록 및 수정시 호출 - interval_type 0:요일+시각 1:필요시 2:start_at 의 timestamp 부터 interval 시간마다 3:start_at 부터 interval 일마다 4:start_at 부터 interval 일 복용, off_days 일 휴약 반복 / tapers: 기간별 용량\ndrug_product_id: 의약품 검색(search-drugs)에서 선택한 약 - 복용중인 다른 약과 성분이 겹치거나 상호작용이 있으면 warnings 반환 (저장은 됨)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "약물 /medicine"
+                ],
+                "summary": "약물 저장",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {jwt_token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "요청 DTO - 약물데이터",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MedicineRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "성공시 200 과 경고 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SaveMedicineResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "TOO_MANY_REQUESTS - 같은 사용자의 요청이 처리중",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
                 }
             }
         },
-        "dto.MedicineDateInfo": {
-            "type": "object",
-            "properties": {
-                "date": {
-                    "type": "string",
-                    "example": "YYYY-MM-DD"
-                },
-                "medicines": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.MedicineTakenInfo"
+        "/search-drugs": {
+            "get": {
+                "description": "의약품 목록(식약처 허가정보)에서 제품명, 성분명으로 검색시 호출 - 약물 저장시 drug_product_id 로 연결\n약물 찾기와 같이 초성, 오타 검색 (성분명으로만 찾은 제품은 뒤에)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "약물 /medicine"
+                ],
+                "summary": "의약품 검색",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "키워드",
+                        "name": "keyword",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "페이지 (1부터, 기본 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "페이지 크기 (기본 20, 최대 50)",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "의약품 정보",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.DrugProductResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "dto.MedicineRequest": {
-            "type": "object",
-            "properties": {
-                "dose": {
-                    "type": "number"
+        "/search-medicines": {
+            "get": {
+                "description": "약물 검색 키워드 입력시 호출 - 부분 일치, 초성(ㄹㅂㄷ -\u003e 레보도파), 오타 검색\n순위: 완전 일치 \u003e 앞부분 일치 \u003e 부분 일치 \u003e 초성 일치 \u003e 비슷한 이름",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "약물 /medicine"
+                ],
+                "summary": "약물 찾기",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "키워드",
+                        "name": "keyword",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "페이지 (1부터, 기본 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "페이지 크기 (기본 20, 최대 50)",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "약물명",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/take-medicine": {
+            "post": {
+                "description": "약물 복용시 호출",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "약물 /medicine"
+                ],
+                "summary": "약물 복용",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {jwt_token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "약물 복용 데이터",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TakeMedicine"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "성공시 200 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BasicResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "TOO_MANY_REQUESTS - 같은 사용자의 요청이 처리중",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/untake-medicine": {
+            "post": {
+                "description": "약물 복용취소시 호출",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "약물 /medicine"
+                ],
+                "summary": "약물 복용취소",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {jwt_token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "약물 취소 데이터",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UnTakeMedicine"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "성공시 200 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BasicResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "TOO_MANY_REQUESTS - 같은 사용자의 요청이 처리중",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.AdherencePeriod": {
+            "type": "object",
+            "properties": {
+                "late": {
+                    "description": "1시간이 지나 복용",
+                    "type": "integer"
+                },
+                "missed": {
+                    "description": "복용하지 않음",
+                    "type": "integer"
+                },
+                "on_time": {
+                    "description": "예정 시각에서 1시간 안에 복용",
+                    "type": "integer"
+                },
+                "on_time_rate": {
+                    "description": "복용한 것 중 제시간 비율 (%)",
+                    "type": "number"
+                },
+                "period": {
+                    "type": "string",
+                    "example": "YYYY-MM-DD(주 시작 월요일), YYYY-MM"
+                },
+                "rate": {
+                    "description": "복용률 (%)",
+                    "type": "number"
+                },
+                "scheduled": {
+                    "description": "예정 복용 횟수",
+                    "type": "integer"
+                },
+                "taken": {
+                    "description": "복용 횟수",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.AdherenceResponse": {
+            "type": "object",
+            "properties": {
+                "end_date": {
+                    "type": "string",
+                    "example": "YYYY-MM-DD"
+                },
+                "medicines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.MedicineAdherence"
+                    }
+                },
+                "monthly": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AdherencePeriod"
+                    }
+                },
+                "overall": {
+                    "$ref": "#/definitions/dto.AdherenceSummary"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "YYYY-MM-DD"
+                },
+                "weekly": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AdherencePeriod"
+                    }
+                }
+            }
+        },
+        "dto.AdherenceSummary": {
+            "type": "object",
+            "properties": {
+                "current_streak": {
+                    "description": "마지막 날까지 예정된 약을 모두 복용한 연속 일수",
+                    "type": "integer"
+                },
+                "late": {
+                    "description": "1시간이 지나 복용",
+                    "type": "integer"
+                },
+                "longest_streak": {
+                    "description": "기간 중 가장 긴 연속 일수",
+                    "type": "integer"
+                },
+                "missed": {
+                    "description": "복용하지 않음",
+                    "type": "integer"
+                },
+                "on_time": {
+                    "description": "예정 시각에서 1시간 안에 복용",
+                    "type": "integer"
+                },
+                "on_time_rate": {
+                    "description": "복용한 것 중 제시간 비율 (%)",
+                    "type": "number"
+                },
+                "rate": {
+                    "description": "복용률 (%)",
+                    "type": "number"
+                },
+                "scheduled": {
+                    "description": "예정 복용 횟수",
+                    "type": "integer"
+                },
+                "taken": {
+                    "description": "복용 횟수",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.BasicResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DrugProductResponse": {
+            "type": "object",
+            "properties": {
+                "atc_code": {
+                    "type": "string"
+                },
+                "company": {
+                    "type": "string"
+                },
+                "edi_code": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ingredients": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "item_seq": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DrugWarning": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "ingredient": {
+                    "type": "string"
+                },
+                "medicine_id": {
+                    "type": "integer"
+                },
+                "medicine_name": {
+                    "type": "string"
+                },
+                "severity": {
+                    "description": "1 주의 2 병용금기",
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "interaction,duplicate"
+                }
+            }
+        },
+        "dto.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "INVALID_REQUEST(400), UNAUTHORIZED(401), FORBIDDEN(403), NOT_FOUND(404), CONFLICT(409), TOO_MANY_REQUESTS(429), DATABASE_ERROR(500), INTERNAL_ERROR(500)",
+                    "type": "string",
+                    "example": "INVALID_REQUEST"
+                },
+                "detail": {
+                    "description": "원인 (디버깅용, 변경될 수 있음)",
+                    "type": "string",
+                    "example": "invalid date format, should be YYYY-MM-DD"
+                },
+                "message": {
+                    "description": "사용자 안내 문구",
+                    "type": "string",
+                    "example": "요청 값이 올바르지 않아요."
+                }
+            }
+        },
+        "dto.MedicineAdherence": {
+            "type": "object",
+            "properties": {
+                "current_streak": {
+                    "description": "마지막 날까지 예정된 약을 모두 복용한 연속 일수",
+                    "type": "integer"
+                },
+                "late": {
+                    "description": "1시간이 지나 복용",
+                    "type": "integer"
+                },
+                "longest_streak": {
+                    "description": "기간 중 가장 긴 연속 일수",
+                    "type": "integer"
+                },
+                "medicine_id": {
+                    "type": "integer"
+                },
+                "missed": {
+                    "description": "복용하지 않음",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "on_time": {
+                    "description": "예정 시각에서 1시간 안에 복용",
+                    "type": "integer"
+                },
+                "on_time_rate": {
+                    "description": "복용한 것 중 제시간 비율 (%)",
+                    "type": "number"
+                },
+                "rate": {
+                    "description": "복용률 (%)",
+                    "type": "number"
+                },
+                "scheduled": {
+                    "description": "예정 복용 횟수",
+                    "type": "integer"
+                },
+                "taken": {
+                    "description": "복용 횟수",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.MedicineDateInfo": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "YYYY-MM-DD"
+                },
+                "medicines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.MedicineResponse"
+                    }
+                }
+            }
+        },
+        "dto.MedicineOriginResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "string",
+                    "example": "YYYY-mm-ddTHH:mm:ss "
+                },
+                "dose": {
+                    "type": "number"
+                },
+                "drug_product_id": {
+                    "type": "integer"
                 },
                 "end_at": {
                     "type": "string",
@@ -419,6 +996,9 @@ const docTemplate = `{
                 "id": {
                     "type": "integer"
                 },
+                "interval": {
+                    "type": "integer"
+                },
                 "interval_type": {
                     "type": "integer"
                 },
@@ -434,6 +1014,9 @@ const docTemplate = `{
                 "name": {
                     "type": "string"
                 },
+                "off_days": {
+                    "type": "integer"
+                },
                 "start_at": {
                     "type": "string",
                     "example": "YYYY-MM-dd"
@@ -441,6 +1024,12 @@ const docTemplate = `{
                 "store": {
                     "type": "number"
                 },
+                "tapers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.Taper"
+                    }
+                },
                 "timestamp": {
                     "type": "array",
                     "items": {
@@ -451,6 +1040,13 @@ const docTemplate = `{
                         "HH:mm"
                     ]
                 },
+                "updated": {
+                    "type": "string",
+                    "example": "YYYY-mm-ddTHH:mm:ss "
+                },
+                "use_least_store": {
+                    "type": "boolean"
+                },
                 "use_privacy": {
                     "type": "boolean"
                 },
@@ -462,16 +1058,16 @@ const docTemplate = `{
                 }
             }
         },
-        "dto.MedicineResponse": {
+        "dto.MedicineRequest": {
             "type": "object",
             "properties": {
-                "created": {
-                    "type": "string",
-                    "example": "YYYY-mm-ddTHH:mm:ss "
-                },
                 "dose": {
                     "type": "number"
                 },
+                "drug_product_id": {
+                    "description": "의약품 목록에서 선택한 약 (search-drugs 의 id)",
+                    "type": "integer"
+                },
                 "end_at": {
                     "type": "string",
                     "example": "YYYY-MM:dd"
@@ -479,6 +1075,9 @@ const docTemplate = `{
                 "id": {
                     "type": "integer"
                 },
+                "interval": {
+                    "type": "integer"
+                },
                 "interval_type": {
                     "type": "integer"
                 },
@@ -494,6 +1093,9 @@ const docTemplate = `{
                 "name": {
                     "type": "string"
                 },
+                "off_days": {
+                    "type": "integer"
+                },
                 "start_at": {
                     "type": "string",
                     "example": "YYYY-MM-dd"
@@ -501,6 +1103,12 @@ const docTemplate = `{
                 "store": {
                     "type": "number"
                 },
+                "tapers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.Taper"
+                    }
+                },
                 "timestamp": {
                     "type": "array",
                     "items": {
@@ -511,10 +1119,100 @@ const docTemplate = `{
                         "HH:mm"
                     ]
                 },
+                "use_least_store": {
+                    "type": "boolean"
+                },
+                "use_privacy": {
+                    "type": "boolean"
+                },
+                "weekdays": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "dto.MedicineResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "string",
+                    "example": "YYYY-mm-ddTHH:mm:ss "
+                },
+                "dose": {
+                    "type": "number"
+                },
+                "drug_product_id": {
+                    "type": "integer"
+                },
+                "end_at": {
+                    "type": "string",
+                    "example": "YYYY-MM:dd"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "interval": {
+                    "type": "integer"
+                },
+                "interval_type": {
+                    "type": "integer"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "least_store": {
+                    "type": "number"
+                },
+                "medicine_type": {
+                    "type": "string"
+                },
+                "missed": {
+                    "description": "알람 후 복용하지 않아 보호자에게 알린 시각",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "HH:mm"
+                    ]
+                },
+                "name": {
+                    "type": "string"
+                },
+                "off_days": {
+                    "type": "integer"
+                },
+                "start_at": {
+                    "type": "string",
+                    "example": "YYYY-MM-dd"
+                },
+                "store": {
+                    "type": "number"
+                },
+                "tapers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.Taper"
+                    }
+                },
+                "timestamp": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "object",
+                        "additionalProperties": {
+                            "type": "number"
+                        }
+                    }
+                },
                 "updated": {
                     "type": "string",
                     "example": "YYYY-mm-ddTHH:mm:ss "
                 },
+                "use_least_store": {
+                    "type": "boolean"
+                },
                 "use_privacy": {
                     "type": "boolean"
                 },
@@ -526,14 +1224,59 @@ const docTemplate = `{
                 }
             }
         },
-        "dto.MedicineTakenInfo": {
+        "dto.RefillForecastResponse": {
             "type": "object",
             "properties": {
-                "medicine": {
-                    "$ref": "#/definitions/dto.MedicineResponse"
+                "days_left": {
+                    "description": "남은 재고로 복용 가능한 일수 (-1: 소진 예정 없음)",
+                    "type": "integer"
                 },
-                "taken": {
+                "least_store": {
+                    "type": "number"
+                },
+                "medicine_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "need_refill": {
+                    "description": "최소 재고 미만이거나 곧 떨어짐",
                     "type": "boolean"
+                },
+                "run_out_at": {
+                    "description": "재고가 떨어지는 날",
+                    "type": "string",
+                    "example": "YYYY-MM-DD"
+                },
+                "store": {
+                    "type": "number"
+                }
+            }
+        },
+        "dto.RefillRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "medicine_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.SaveMedicineResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "warnings": {
+                    "description": "복용중인 다른 약과의 상호작용, 중복 성분 (저장은 됨)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DrugWarning"
+                    }
                 }
             }
         },
@@ -550,9 +1293,29 @@ const docTemplate = `{
                 "medicine_id": {
                     "type": "integer"
                 },
+                "real_taken": {
+                    "type": "string",
+                    "example": "HH:mm"
+                },
                 "time_taken": {
                     "type": "string",
-                    "example": "YYYY-MM-DD"
+                    "example": "HH:mm"
+                }
+            }
+        },
+        "dto.Taper": {
+            "type": "object",
+            "properties": {
+                "dose": {
+                    "type": "number"
+                },
+                "end_at": {
+                    "type": "string",
+                    "example": "YYYY-MM-dd"
+                },
+                "start_at": {
+                    "type": "string",
+                    "example": "YYYY-MM-dd"
                 }
             }
         },
@@ -6,6 +6,12 @@ type GetParams struct {
 	EndDate   string `form:"end_date" example:"YYYY-MM-DD"`
 }
 
+type SearchParams struct {
+	Keyword string `form:"keyword"`
+	Page    int    `form:"page"` // 1 부터 (기본 1)
+	Size    int    `form:"size"` // 기본 20, 최대 50
+}
+
 type MedicineRequest struct {
 	Id            uint     `json:"id"`
 	Uid           uint     `json:"-"`
@@ -22,6 +28,17 @@ type MedicineRequest struct {
 	StartAt       string   `json:"start_at" example:"YYYY-MM-dd"`
 	EndAt         string   `json:"end_at"  example:"YYYY-MM:dd"`
 	UsePrivacy    *bool    `json:"use_privacy"`
+	Interval      uint     `json:"interval"`
+	OffDays       uint     `json:"off_days"`
+	Tapers        []Taper  `json:"tapers"`
+	DrugProductId uint     `json:"drug_product_id"` // 의약품 목록에서 선택한 약 (search-drugs 의 id)
+}
+
+// 기간별 용량 (용량을 줄여가는 복용 등)
+type Taper struct {
+	StartAt string  `json:"start_at" example:"YYYY-MM-dd"`
+	EndAt   string  `json:"end_at" example:"YYYY-MM-dd"`
+	Dose    float32 `json:"dose"`
 }
 
 type MedicineOriginResponse struct {
@@ -39,6 +56,10 @@ type MedicineOriginResponse struct {
 	StartAt       string   `json:"start_at" example:"YYYY-MM-dd"`
 	EndAt         string   `json:"end_at"  example:"YYYY-MM:dd"`
 	UsePrivacy    bool     `json:"use_privacy"`
+	Interval      uint     `json:"interval"`
+	OffDays       uint     `json:"off_days"`
+	Tapers        []Taper  `json:"tapers"`
+	DrugProductId uint     `json:"drug_product_id"`
 	Created       string   `json:"created"  example:"YYYY-mm-ddTHH:mm:ss "`
 	Updated       string   `json:"updated"  example:"YYYY-mm-ddTHH:mm:ss "`
 }
@@ -67,8 +88,13 @@ type MedicineResponse struct {
 	StartAt       string                        `json:"start_at" example:"YYYY-MM-dd"`
 	EndAt         string                        `json:"end_at"  example:"YYYY-MM:dd"`
 	UsePrivacy    bool                          `json:"use_privacy"`
+	Interval      uint                          `json:"interval"`
+	OffDays       uint                          `json:"off_days"`
+	Tapers        []Taper                       `json:"tapers"`
+	DrugProductId uint                          `json:"drug_product_id"`
 	Created       string                        `json:"created"  example:"YYYY-mm-ddTHH:mm:ss "`
 	Updated       string                        `json:"updated"  example:"YYYY-mm-ddTHH:mm:ss "`
+	Missed        []string                      `json:"missed" example:"HH:mm"` // 알람 후 복용하지 않아 보호자에게 알린 시각
 }
 
 type TakeMedicine struct {
@@ -101,6 +127,10 @@ type MedicinBridge struct {
 	StartAt       string  `json:"start_at" example:"YYYY-MM-dd"`
 	EndAt         string  `json:"end_at"  example:"YYYY-MM:dd"`
 	UsePrivacy    bool    `json:"use_privacy"`
+	Interval      uint    `json:"interval"`
+	OffDays       uint    `json:"off_days"`
+	Tapers        []Taper `json:"tapers"`
+	DrugProductId uint    `json:"drug_product_id"`
 	Created       string  `json:"created"  example:"YYYY-mm-ddTHH:mm:ss "`
 	Updated       string  `json:"updated"  example:"YYYY-mm-ddTHH:mm:ss "`
 }
@@ -108,10 +138,91 @@ type MedicinBridge struct {
 type SuccessResponse struct {
 	Jwt string `json:"jwt"`
 }
+
+// 오류 응답 - code 로 분기하고 message 는 사용자에게 표시 (Accept-Language: en 이면 영어)
 type ErrorResponse struct {
-	Err string `json:"err"`
+	Code    string `json:"code" example:"INVALID_REQUEST"`                                       // INVALID_REQUEST(400), UNAUTHORIZED(401), FORBIDDEN(403), NOT_FOUND(404), CONFLICT(409), TOO_MANY_REQUESTS(429), DATABASE_ERROR(500), INTERNAL_ERROR(500)
+	Message string `json:"message" example:"요청 값이 올바르지 않아요."`                                    // 사용자 안내 문구
+	Detail  string `json:"detail,omitempty" example:"invalid date format, should be YYYY-MM-DD"` // 원인 (디버깅용, 변경될 수 있음)
 }
 
 type BasicResponse struct {
 	Code string `json:"code"`
 }
+
+type RefillRequest struct {
+	Uid        uint    `json:"-"`
+	MedicineId uint    `json:"medicine_id"`
+	Amount     float32 `json:"amount"`
+}
+
+type RefillForecastResponse struct {
+	MedicineId uint    `json:"medicine_id"`
+	Name       string  `json:"name"`
+	Store      float32 `json:"store"`
+	LeastStore float32 `json:"least_store"`
+	DaysLeft   int     `json:"days_left"`                       // 남은 재고로 복용 가능한 일수 (-1: 소진 예정 없음)
+	RunOutAt   string  `json:"run_out_at" example:"YYYY-MM-DD"` // 재고가 떨어지는 날
+	NeedRefill bool    `json:"need_refill"`                     // 최소 재고 미만이거나 곧 떨어짐
+}
+
+type SaveMedicineResponse struct {
+	Code     string        `json:"code"`
+	Warnings []DrugWarning `json:"warnings"` // 복용중인 다른 약과의 상호작용, 중복 성분 (저장은 됨)
+}
+
+type DrugWarning struct {
+	Type         string `json:"type" example:"interaction,duplicate"`
+	Severity     uint   `json:"severity"` // 1 주의 2 병용금기
+	MedicineId   uint   `json:"medicine_id"`
+	MedicineName string `json:"medicine_name"`
+	Ingredient   string `json:"ingredient"`
+	Description  string `json:"description"`
+}
+
+type DrugProductResponse struct {
+	Id          uint     `json:"id"`
+	ItemSeq     string   `json:"item_seq"`
+	Name        string   `json:"name"`
+	Company     string   `json:"company"`
+	EdiCode     string   `json:"edi_code"`
+	AtcCode     string   `json:"atc_code"`
+	Ingredients []string `json:"ingredients"`
+}
+
+// 복용 통계 - 예정 시각이 지난 복용만 계산
+type AdherenceStats struct {
+	Scheduled  int     `json:"scheduled"`    // 예정 복용 횟수
+	Taken      int     `json:"taken"`        // 복용 횟수
+	OnTime     int     `json:"on_time"`      // 예정 시각에서 1시간 안에 복용
+	Late       int     `json:"late"`         // 1시간이 지나 복용
+	Missed     int     `json:"missed"`       // 복용하지 않음
+	Rate       float64 `json:"rate"`         // 복용률 (%)
+	OnTimeRate float64 `json:"on_time_rate"` // 복용한 것 중 제시간 비율 (%)
+}
+
+type AdherenceSummary struct {
+	AdherenceStats
+	CurrentStreak int `json:"current_streak"` // 마지막 날까지 예정된 약을 모두 복용한 연속 일수
+	LongestStreak int `json:"longest_streak"` // 기간 중 가장 긴 연속 일수
+}
+
+type MedicineAdherence struct {
+	MedicineId uint   `json:"medicine_id"`
+	Name       string `json:"name"`
+	AdherenceSummary
+}
+
+type AdherencePeriod struct {
+	Period string `json:"period" example:"YYYY-MM-DD(주 시작 월요일), YYYY-MM"`
+	AdherenceStats
+}
+
+type AdherenceResponse struct {
+	StartDate string              `json:"start_date" example:"YYYY-MM-DD"`
+	EndDate   string              `json:"end_date" example:"YYYY-MM-DD"`
+	Overall   AdherenceSummary    `json:"overall"`
+	Medicines []MedicineAdherence `json:"medicines"`
+	Weekly    []AdherencePeriod   `json:"weekly"`
+	Monthly   []AdherencePeriod   `json:"monthly"`
+}
@@ -12,11 +12,11 @@ import (
 func SaveEndpoint(s service.MedicineService) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		medicine := request.(dto.MedicineRequest)
-		code, err := s.SaveMedicine(medicine)
+		saveResponse, err := s.SaveMedicine(medicine)
 		if err != nil {
 			return dto.BasicResponse{Code: err.Error()}, err
 		}
-		return dto.BasicResponse{Code: code}, nil
+		return saveResponse, nil
 	}
 }
 
@@ -81,11 +81,70 @@ func UnTakeEndpoint(s service.MedicineService) endpoint.Endpoint {
 
 func SearchsEndpoint(s service.MedicineService) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
-		keyword := request.(string)
-		medicines, err := s.SearchMedicines(keyword)
+		params := request.(dto.SearchParams)
+		medicines, err := s.SearchMedicines(params)
 		if err != nil {
 			return dto.BasicResponse{Code: err.Error()}, err
 		}
 		return medicines, nil
 	}
 }
+
+func RefillEndpoint(s service.MedicineService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		refillRequest := request.(dto.RefillRequest)
+		code, err := s.RefillMedicine(refillRequest)
+		if err != nil {
+			return dto.BasicResponse{Code: err.Error()}, err
+		}
+		return dto.BasicResponse{Code: code}, nil
+	}
+}
+
+func GetRefillForecastsEndpoint(s service.MedicineService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		id := request.(uint)
+		forecasts, err := s.GetRefillForecasts(id)
+		if err != nil {
+			return dto.BasicResponse{Code: err.Error()}, err
+		}
+		return forecasts, nil
+	}
+}
+
+func SearchDrugsEndpoint(s service.MedicineService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		params := request.(dto.SearchParams)
+		drugs, err := s.SearchDrugs(params)
+		if err != nil {
+			return dto.BasicResponse{Code: err.Error()}, err
+		}
+		return drugs, nil
+	}
+}
+
+func CheckDrugWarningsEndpoint(s service.MedicineService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		reqMap := request.(map[string]interface{})
+		uid := reqMap["uid"].(uint)
+		drugProductId := reqMap["drug_product_id"].(uint)
+		warnings, err := s.CheckDrugWarnings(uid, drugProductId)
+		if err != nil {
+			return dto.BasicResponse{Code: err.Error()}, err
+		}
+		return warnings, nil
+	}
+}
+
+func GetAdherenceEndpoint(s service.MedicineService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		reqMap := request.(map[string]interface{})
+		id := reqMap["id"].(uint)
+		queryParams := reqMap["queryParams"].(dto.GetParams)
+		adherence, err := s.GetAdherence(id, queryParams.StartDate, queryParams.EndDate)
+		if err != nil {
+			return dto.BasicResponse{Code: err.Error()}, err
+		}
+		return adherence, nil
+	}
+}
@@ -6,16 +6,20 @@ import (
 	"medicine-service/db"
 	_ "medicine-service/docs"
 	"medicine-service/endpoint"
+	pb "medicine-service/proto"
 	"medicine-service/service"
 	"medicine-service/transport"
+	"net"
 	"os"
+	"time"
 
+	"github.com/disterbia/wellkinson/common/model"
+	"github.com/disterbia/wellkinson/common/util"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 func main() {
@@ -32,14 +36,64 @@ func main() {
 		log.Println("Database connection error:", err)
 		return
 	}
+	// 추가된 약 컬럼 (복용 일정, 재고 알림, 의약품 연결)
+	for _, column := range []string{"Interval", "OffDays", "Tapers", "RefillNotifiedAt", "DrugProductId"} {
+		if !database.Migrator().HasColumn(&model.Medicine{}, column) {
+			if err := database.Migrator().AddColumn(&model.Medicine{}, column); err != nil {
+				log.Println("medicine migration error:", err)
+			}
+		}
+	}
+	if err := database.AutoMigrate(&model.MedicineRefill{}, &model.MedicineSearch{}, &model.DrugProduct{}, &model.DrugIngredient{}, &model.DrugInteraction{}, &model.AlarmOutbox{}); err != nil {
+		log.Println("medicine migration error:", err)
+	} else if err := service.SeedDrugInteractions(database); err != nil {
+		log.Println("drug interaction seed error:", err)
+	}
+	// 초성/오타 검색용 함수, 인덱스
+	service.SetupSearch(database)
 	// gRPC 클라이언트 연결 생성
-	conn, err := grpc.Dial("alarm:50051", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := util.DialAlarm("alarm:50051")
 	if err != nil {
-		log.Fatalf("failed to connect to email service: %v", err)
+		log.Fatalf("failed to connect to alarm service: %v", err)
 	}
 	defer conn.Close()
 
-	svc := service.NewMedicineService(database, conn)
+	svc := service.NewMedicineService(database)
+
+	// 저장된 알람 변경 요청을 alarm-service 로 전달
+	relay := util.NewAlarmRelay(database, conn, service.AlarmSource)
+	go func() {
+		ticker := time.NewTicker(2 * time.Second)
+		defer ticker.Stop()
+		for range ticker.C {
+			if _, err := relay.Relay(); err != nil {
+				log.Println("alarm relay error:", err)
+			}
+		}
+	}()
+
+	// 알림 액션용 gRPC 서버
+	lis, err := net.Listen("tcp", ":50053")
+	if err != nil {
+		log.Fatalf("failed to listen: %v", err)
+	}
+	grpcServer := grpc.NewServer()
+	pb.RegisterMedicineServiceServer(grpcServer, &service.MedicineServer{Db: database, Svc: svc})
+
+	go func() {
+		if err := grpcServer.Serve(lis); err != nil {
+			log.Fatalf("failed to serve: %v", err)
+		}
+	}()
+
+	// 재고 부족 보충 알림
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+		for range ticker.C {
+			svc.CheckRefills()
+		}
+	}()
 
 	saveEndpoint := endpoint.SaveEndpoint(svc)
 	removeEndpoint := endpoint.RemoveEndpoint(svc)
@@ -48,8 +102,16 @@ func main() {
 	takeEndpoint := endpoint.TakeEndpoint(svc)
 	unTakeEndpoint := endpoint.UnTakeEndpoint(svc)
 	searchEndpoint := endpoint.SearchsEndpoint(svc)
+	refillEndpoint := endpoint.RefillEndpoint(svc)
+	getRefillForecastsEndpoint := endpoint.GetRefillForecastsEndpoint(svc)
+	searchDrugsEndpoint := endpoint.SearchDrugsEndpoint(svc)
+	checkDrugWarningsEndpoint := endpoint.CheckDrugWarningsEndpoint(svc)
+	getAdherenceEndpoint := endpoint.GetAdherenceEndpoint(svc)
 
 	router := gin.Default()
+	// 보호자 위임 접근 (X-Acting-For)
+	util.SetDelegationStore(util.NewDelegationStore(database))
+	router.Use(util.DelegatedAccess(util.DomainMedicine))
 	router.POST("/save-medicine", transport.SaveHandler(saveEndpoint))
 	router.POST("/remove-medicine", transport.RemoveHandler(removeEndpoint))
 	router.POST("/take-medicine", transport.TakeHandler(takeEndpoint))
@@ -57,6 +119,11 @@ func main() {
 	router.GET("/get-takens", transport.GetTakensHandler(getTakensEndpoint))
 	router.GET("/get-medicines", transport.GetMedicinesHandler(getMedicinesEndpoint))
 	router.GET("/search-medicines", transport.SearchHandler(searchEndpoint))
+	router.POST("/refill-medicine", transport.RefillHandler(refillEndpoint))
+	router.GET("/get-refill-forecasts", transport.GetRefillForecastsHandler(getRefillForecastsEndpoint))
+	router.GET("/search-drugs", transport.SearchDrugsHandler(searchDrugsEndpoint))
+	router.GET("/check-drug-warnings", transport.CheckDrugWarningsHandler(checkDrugWarningsEndpoint))
+	router.GET("/get-adherence", transport.GetAdherenceHandler(getAdherenceEndpoint))
 
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 	router.Run(":44407")
@@ -22,6 +22,7 @@ const (
 	AlarmService_RemoveAlarm_FullMethodName      = "/alarmservice.AlarmService/RemoveAlarm"
 	AlarmService_MultiSetAlarm_FullMethodName    = "/alarmservice.AlarmService/MultiSetAlarm"
 	AlarmService_MultiUpdateAlarm_FullMethodName = "/alarmservice.AlarmService/MultiUpdateAlarm"
+	AlarmService_ReplaceAlarms_FullMethodName    = "/alarmservice.AlarmService/ReplaceAlarms"
 )
 
 // AlarmServiceClient is the client API for AlarmService service.
@@ -31,6 +32,7 @@ type AlarmServiceClient interface {
 	RemoveAlarm(ctx context.Context, in *AlarmRemoveRequest, opts ...grpc.CallOption) (*AlarmResponse, error)
 	MultiSetAlarm(ctx context.Context, in *MultiAlarmRequest, opts ...grpc.CallOption) (*AlarmResponse, error)
 	MultiUpdateAlarm(ctx context.Context, in *MultiAlarmRequest, opts ...grpc.CallOption) (*AlarmResponse, error)
+	ReplaceAlarms(ctx context.Context, in *ReplaceAlarmsRequest, opts ...grpc.CallOption) (*ReplaceAlarmsResponse, error)
 }
 
 type alarmServiceClient struct {
@@ -68,6 +70,15 @@ func (c *alarmServiceClient) MultiUpdateAlarm(ctx context.Context, in *MultiAlar
 	return out, nil
 }
 
+func (c *alarmServiceClient) ReplaceAlarms(ctx context.Context, in *ReplaceAlarmsRequest, opts ...grpc.CallOption) (*ReplaceAlarmsResponse, error) {
+	out := new(ReplaceAlarmsResponse)
+	err := c.cc.Invoke(ctx, AlarmService_ReplaceAlarms_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AlarmServiceServer is the server API for AlarmService service.
 // All implementations must embed UnimplementedAlarmServiceServer
 // for forward compatibility
@@ -75,6 +86,7 @@ type AlarmServiceServer interface {
 	RemoveAlarm(context.Context, *AlarmRemoveRequest) (*AlarmResponse, error)
 	MultiSetAlarm(context.Context, *MultiAlarmRequest) (*AlarmResponse, error)
 	MultiUpdateAlarm(context.Context, *MultiAlarmRequest) (*AlarmResponse, error)
+	ReplaceAlarms(context.Context, *ReplaceAlarmsRequest) (*ReplaceAlarmsResponse, error)
 	mustEmbedUnimplementedAlarmServiceServer()
 }
 
@@ -91,6 +103,9 @@ func (UnimplementedAlarmServiceServer) MultiSetAlarm(context.Context, *MultiAlar
 func (UnimplementedAlarmServiceServer) MultiUpdateAlarm(context.Context, *MultiAlarmRequest) (*AlarmResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method MultiUpdateAlarm not implemented")
 }
+func (UnimplementedAlarmServiceServer) ReplaceAlarms(context.Context, *ReplaceAlarmsRequest) (*ReplaceAlarmsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplaceAlarms not implemented")
+}
 func (UnimplementedAlarmServiceServer) mustEmbedUnimplementedAlarmServiceServer() {}
 
 // UnsafeAlarmServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -158,6 +173,24 @@ func _AlarmService_MultiUpdateAlarm_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AlarmService_ReplaceAlarms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplaceAlarmsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlarmServiceServer).ReplaceAlarms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AlarmService_ReplaceAlarms_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlarmServiceServer).ReplaceAlarms(ctx, req.(*ReplaceAlarmsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AlarmService_ServiceDesc is the grpc.ServiceDesc for AlarmService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -177,6 +210,10 @@ var AlarmService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "MultiUpdateAlarm",
 			Handler:    _AlarmService_MultiUpdateAlarm_Handler,
 		},
+		{
+			MethodName: "ReplaceAlarms",
+			Handler:    _AlarmService_ReplaceAlarms_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "alarm.proto",
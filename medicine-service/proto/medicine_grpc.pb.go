@@ -0,0 +1,109 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.25.1
+// source: medicine.proto
+
+package __
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	MedicineService_TakeMedicine_FullMethodName = "/medicineservice.MedicineService/TakeMedicine"
+)
+
+// MedicineServiceClient is the client API for MedicineService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type MedicineServiceClient interface {
+	TakeMedicine(ctx context.Context, in *TakeMedicineRequest, opts ...grpc.CallOption) (*MedicineResponse, error)
+}
+
+type medicineServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMedicineServiceClient(cc grpc.ClientConnInterface) MedicineServiceClient {
+	return &medicineServiceClient{cc}
+}
+
+func (c *medicineServiceClient) TakeMedicine(ctx context.Context, in *TakeMedicineRequest, opts ...grpc.CallOption) (*MedicineResponse, error) {
+	out := new(MedicineResponse)
+	err := c.cc.Invoke(ctx, MedicineService_TakeMedicine_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MedicineServiceServer is the server API for MedicineService service.
+// All implementations must embed UnimplementedMedicineServiceServer
+// for forward compatibility
+type MedicineServiceServer interface {
+	TakeMedicine(context.Context, *TakeMedicineRequest) (*MedicineResponse, error)
+	mustEmbedUnimplementedMedicineServiceServer()
+}
+
+// UnimplementedMedicineServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedMedicineServiceServer struct {
+}
+
+func (UnimplementedMedicineServiceServer) TakeMedicine(context.Context, *TakeMedicineRequest) (*MedicineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TakeMedicine not implemented")
+}
+func (UnimplementedMedicineServiceServer) mustEmbedUnimplementedMedicineServiceServer() {}
+
+// UnsafeMedicineServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MedicineServiceServer will
+// result in compilation errors.
+type UnsafeMedicineServiceServer interface {
+	mustEmbedUnimplementedMedicineServiceServer()
+}
+
+func RegisterMedicineServiceServer(s grpc.ServiceRegistrar, srv MedicineServiceServer) {
+	s.RegisterService(&MedicineService_ServiceDesc, srv)
+}
+
+func _MedicineService_TakeMedicine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TakeMedicineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MedicineServiceServer).TakeMedicine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MedicineService_TakeMedicine_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MedicineServiceServer).TakeMedicine(ctx, req.(*TakeMedicineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MedicineService_ServiceDesc is the grpc.ServiceDesc for MedicineService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MedicineService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "medicineservice.MedicineService",
+	HandlerType: (*MedicineServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "TakeMedicine",
+			Handler:    _MedicineService_TakeMedicine_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "medicine.proto",
+}
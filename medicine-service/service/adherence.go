@@ -0,0 +1,214 @@
+// /medicine-service/service/adherence.go
+package service
+
+import (
+	"math"
+	"medicine-service/dto"
+	"time"
+
+	"github.com/disterbia/wellkinson/common/model"
+	"github.com/disterbia/wellkinson/common/util"
+)
+
+const (
+	adherenceDefaultDays = 30
+	adherenceMaxDays     = 366
+	onTimeWindow         = 60 // 예정 시각 후 이 시간(분) 안에 복용하면 제시간
+)
+
+// 하루 복용 결과
+type adherenceDay struct {
+	date  string
+	stats dto.AdherenceStats
+}
+
+// 복용률 - 기간(기본 최근 30일, 오늘은 지금까지 예정된 복용만)의 약별/전체 통계, 연속 복용일, 주별/월별 합계
+// 필요시 복용, 중지, 삭제된 약은 제외
+func (service *medicineService) GetAdherence(uid uint, startDateStr, endDateStr string) (dto.AdherenceResponse, error) {
+	now := time.Now().In(userLocation(service.db, uid))
+	today := now.Format("2006-01-02")
+	if endDateStr == "" || endDateStr > today {
+		endDateStr = today
+	}
+	if startDateStr == "" {
+		startDateStr = addDays(endDateStr, 1-adherenceDefaultDays)
+	}
+	startDate, err := time.Parse("2006-01-02", startDateStr)
+	if err != nil {
+		return dto.AdherenceResponse{}, util.NewError(util.ErrInvalidRequest, "invalid start_date")
+	}
+	endDate, err := time.Parse("2006-01-02", endDateStr)
+	if err != nil {
+		return dto.AdherenceResponse{}, util.NewError(util.ErrInvalidRequest, "invalid end_date")
+	}
+	if startDate.After(endDate) || endDate.Sub(startDate) >= adherenceMaxDays*24*time.Hour {
+		return dto.AdherenceResponse{}, util.NewError(util.ErrInvalidRequest, "invalid date range")
+	}
+
+	var medicines []model.Medicine
+	err = service.db.Where("uid = ? AND is_active = true AND is_delete = false AND interval_type <> ? AND (start_at ='' OR start_at <= ?) AND (end_at ='' OR end_at >= ?)",
+		uid, intervalAsNeeded, endDateStr, startDateStr).Order("id").Find(&medicines).Error
+	if err != nil {
+		return dto.AdherenceResponse{}, util.NewError(util.ErrDatabase, "db error")
+	}
+	var medicineTemp []dto.MedicineOriginResponse
+	if err := util.CopyStruct(medicines, &medicineTemp); err != nil {
+		return dto.AdherenceResponse{}, err
+	}
+
+	medicineIds := make([]uint, 0, len(medicines))
+	for _, v := range medicines {
+		medicineIds = append(medicineIds, v.Id)
+	}
+	var takens []model.MedicineTake
+	if len(medicineIds) > 0 {
+		if err := service.db.Where("uid = ? AND medicine_id IN ? AND date_taken BETWEEN ? AND ?", uid, medicineIds, startDateStr, endDateStr).
+			Find(&takens).Error; err != nil {
+			return dto.AdherenceResponse{}, util.NewError(util.ErrDatabase, "db error2")
+		}
+	}
+	// 약, 날짜, 예정 시각별 실제 복용 시각
+	realTaken := make(map[uint]map[string]map[string]string)
+	for _, v := range takens {
+		if realTaken[v.MedicineId] == nil {
+			realTaken[v.MedicineId] = make(map[string]map[string]string)
+		}
+		if realTaken[v.MedicineId][v.DateTaken] == nil {
+			realTaken[v.MedicineId][v.DateTaken] = make(map[string]string)
+		}
+		realTaken[v.MedicineId][v.DateTaken][v.TimeTaken] = v.RealTaken
+	}
+
+	response := dto.AdherenceResponse{StartDate: startDateStr, EndDate: endDateStr, Medicines: make([]dto.MedicineAdherence, 0, len(medicines))}
+	overallDays := make([]adherenceDay, 0, int(endDate.Sub(startDate).Hours()/24)+1)
+	for d := startDate; !d.After(endDate); d = d.AddDate(0, 0, 1) {
+		overallDays = append(overallDays, adherenceDay{date: d.Format("2006-01-02")})
+	}
+
+	for _, m := range medicineTemp {
+		days := make([]adherenceDay, 0, len(overallDays))
+		for i := range overallDays {
+			d := startDate.AddDate(0, 0, i)
+			day := adherenceDay{date: overallDays[i].date}
+			if (m.StartAt == "" || m.StartAt <= day.date) && (m.EndAt == "" || day.date <= m.EndAt) {
+				for _, v := range doseTimes(m, d) {
+					// 오늘 아직 시각이 되지 않은 복용은 제외
+					if day.date == today && v > now.Format("15:04") {
+						continue
+					}
+					realAt, taken := realTaken[m.Id][day.date][v]
+					addDose(&day.stats, taken, taken && isLate(v, realAt))
+				}
+			}
+			addStats(&overallDays[i].stats, day.stats)
+			days = append(days, day)
+		}
+		response.Medicines = append(response.Medicines, dto.MedicineAdherence{MedicineId: m.Id, Name: m.Name, AdherenceSummary: summarize(days)})
+	}
+
+	response.Overall = summarize(overallDays)
+	response.Weekly = rollup(overallDays, weekPeriod)
+	response.Monthly = rollup(overallDays, monthPeriod)
+	return response, nil
+}
+
+func addDose(stats *dto.AdherenceStats, taken, late bool) {
+	stats.Scheduled++
+	if !taken {
+		stats.Missed++
+		return
+	}
+	stats.Taken++
+	if late {
+		stats.Late++
+	} else {
+		stats.OnTime++
+	}
+}
+
+func addStats(stats *dto.AdherenceStats, other dto.AdherenceStats) {
+	stats.Scheduled += other.Scheduled
+	stats.Taken += other.Taken
+	stats.OnTime += other.OnTime
+	stats.Late += other.Late
+	stats.Missed += other.Missed
+}
+
+// 복용률 계산 (소수점 한자리)
+func withRates(stats dto.AdherenceStats) dto.AdherenceStats {
+	if stats.Scheduled > 0 {
+		stats.Rate = math.Round(float64(stats.Taken)*1000/float64(stats.Scheduled)) / 10
+	}
+	if stats.Taken > 0 {
+		stats.OnTimeRate = math.Round(float64(stats.OnTime)*1000/float64(stats.Taken)) / 10
+	}
+	return stats
+}
+
+// 예정 시각보다 onTimeWindow 분 넘게 늦게 복용 - 자정을 넘겨 복용한 경우 다음날로 계산
+func isLate(timeTaken, realTaken string) bool {
+	scheduled, err := time.Parse("15:04", timeTaken)
+	if err != nil {
+		return false
+	}
+	taken, err := time.Parse("15:04", realTaken)
+	if err != nil {
+		return false
+	}
+	diff := taken.Sub(scheduled)
+	if diff < -12*time.Hour {
+		diff += 24 * time.Hour
+	}
+	return diff > onTimeWindow*time.Minute
+}
+
+// 기간 합계와 연속 복용일 (예정된 복용이 없는 날은 건너뜀)
+func summarize(days []adherenceDay) dto.AdherenceSummary {
+	var summary dto.AdherenceSummary
+	streak := 0
+	for _, v := range days {
+		addStats(&summary.AdherenceStats, v.stats)
+		if v.stats.Scheduled == 0 {
+			continue
+		}
+		if v.stats.Missed > 0 {
+			streak = 0
+			continue
+		}
+		streak++
+		if streak > summary.LongestStreak {
+			summary.LongestStreak = streak
+		}
+	}
+	summary.CurrentStreak = streak
+	summary.AdherenceStats = withRates(summary.AdherenceStats)
+	return summary
+}
+
+// 주 시작일 (월요일)
+func weekPeriod(date time.Time) string {
+	return date.AddDate(0, 0, -(int(date.Weekday())+6)%7).Format("2006-01-02")
+}
+
+func monthPeriod(date time.Time) string {
+	return date.Format("2006-01")
+}
+
+func rollup(days []adherenceDay, period func(time.Time) string) []dto.AdherencePeriod {
+	periods := make([]dto.AdherencePeriod, 0)
+	for _, v := range days {
+		date, err := time.Parse("2006-01-02", v.date)
+		if err != nil {
+			continue
+		}
+		key := period(date)
+		if len(periods) == 0 || periods[len(periods)-1].Period != key {
+			periods = append(periods, dto.AdherencePeriod{Period: key})
+		}
+		addStats(&periods[len(periods)-1].AdherenceStats, v.stats)
+	}
+	for i := range periods {
+		periods[i].AdherenceStats = withRates(periods[i].AdherenceStats)
+	}
+	return periods
+}
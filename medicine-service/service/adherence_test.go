@@ -0,0 +1,97 @@
+// /medicine-service/service/adherence_test.go
+package service
+
+import (
+	"medicine-service/dto"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestIsLate(t *testing.T) {
+	tests := []struct {
+		name      string
+		timeTaken string
+		realTaken string
+		want      bool
+	}{
+		{"on time", "09:00", "09:00", false},
+		{"early", "09:00", "08:30", false},
+		{"within window", "09:00", "10:00", false},
+		{"after window", "09:00", "10:01", true},
+		{"after midnight within window", "23:30", "00:20", false},
+		{"after midnight late", "23:30", "01:00", true},
+		{"invalid time", "09:00", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isLate(tt.timeTaken, tt.realTaken); got != tt.want {
+				t.Fatalf("isLate(%q, %q) = %v, want %v", tt.timeTaken, tt.realTaken, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	full := dto.AdherenceStats{Scheduled: 2, Taken: 2, OnTime: 1, Late: 1}
+	missed := dto.AdherenceStats{Scheduled: 2, Taken: 1, OnTime: 1, Missed: 1}
+	none := dto.AdherenceStats{}
+
+	tests := []struct {
+		name string
+		days []adherenceDay
+		want dto.AdherenceSummary
+	}{
+		{"empty", nil, dto.AdherenceSummary{}},
+		{"all taken", []adherenceDay{{"2024-05-01", full}, {"2024-05-02", full}},
+			dto.AdherenceSummary{AdherenceStats: dto.AdherenceStats{Scheduled: 4, Taken: 4, OnTime: 2, Late: 2, Rate: 100, OnTimeRate: 50}, CurrentStreak: 2, LongestStreak: 2}},
+		{"missed resets streak", []adherenceDay{{"2024-05-01", full}, {"2024-05-02", full}, {"2024-05-03", missed}, {"2024-05-04", full}},
+			dto.AdherenceSummary{AdherenceStats: dto.AdherenceStats{Scheduled: 8, Taken: 7, OnTime: 4, Late: 3, Missed: 1, Rate: 87.5, OnTimeRate: 57.1}, CurrentStreak: 1, LongestStreak: 2}},
+		{"days without doses skipped", []adherenceDay{{"2024-05-01", full}, {"2024-05-02", none}, {"2024-05-03", full}},
+			dto.AdherenceSummary{AdherenceStats: dto.AdherenceStats{Scheduled: 4, Taken: 4, OnTime: 2, Late: 2, Rate: 100, OnTimeRate: 50}, CurrentStreak: 2, LongestStreak: 2}},
+		{"ends missed", []adherenceDay{{"2024-05-01", full}, {"2024-05-02", missed}},
+			dto.AdherenceSummary{AdherenceStats: dto.AdherenceStats{Scheduled: 4, Taken: 3, OnTime: 2, Late: 1, Missed: 1, Rate: 75, OnTimeRate: 66.7}, CurrentStreak: 0, LongestStreak: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := summarize(tt.days); got != tt.want {
+				t.Fatalf("summarize = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRollup(t *testing.T) {
+	taken := dto.AdherenceStats{Scheduled: 1, Taken: 1, OnTime: 1}
+	missed := dto.AdherenceStats{Scheduled: 1, Missed: 1}
+	// 2024-04-28 (일) ~ 2024-05-06 (월)
+	days := []adherenceDay{{"2024-04-28", taken}, {"2024-04-29", taken}, {"2024-04-30", missed}, {"2024-05-01", taken},
+		{"2024-05-05", missed}, {"2024-05-06", taken}}
+
+	tests := []struct {
+		name   string
+		period func(time.Time) string
+		want   []dto.AdherencePeriod
+	}{
+		{"weekly", weekPeriod, []dto.AdherencePeriod{
+			{Period: "2024-04-22", AdherenceStats: dto.AdherenceStats{Scheduled: 1, Taken: 1, OnTime: 1, Rate: 100, OnTimeRate: 100}},
+			{Period: "2024-04-29", AdherenceStats: dto.AdherenceStats{Scheduled: 4, Taken: 2, OnTime: 2, Missed: 2, Rate: 50, OnTimeRate: 100}},
+			{Period: "2024-05-06", AdherenceStats: dto.AdherenceStats{Scheduled: 1, Taken: 1, OnTime: 1, Rate: 100, OnTimeRate: 100}},
+		}},
+		{"monthly", monthPeriod, []dto.AdherencePeriod{
+			{Period: "2024-04", AdherenceStats: dto.AdherenceStats{Scheduled: 3, Taken: 2, OnTime: 2, Missed: 1, Rate: 66.7, OnTimeRate: 100}},
+			{Period: "2024-05", AdherenceStats: dto.AdherenceStats{Scheduled: 3, Taken: 2, OnTime: 2, Missed: 1, Rate: 66.7, OnTimeRate: 100}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rollup(days, tt.period); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("rollup = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if got := rollup(nil, monthPeriod); got == nil || len(got) != 0 {
+		t.Fatalf("rollup(nil) = %#v, want empty slice", got)
+	}
+}
@@ -0,0 +1,107 @@
+// /medicine-service/service/alarm.go
+package service
+
+import (
+	"encoding/json"
+	"medicine-service/dto"
+	pb "medicine-service/proto"
+
+	"github.com/disterbia/wellkinson/common/model"
+	"github.com/disterbia/wellkinson/common/util"
+	"gorm.io/gorm"
+)
+
+const reconcileBatch = 500
+
+// 알람 변경 요청 출처 (relay 가 이 출처의 요청만 전달)
+const AlarmSource = "medicine"
+
+// parent 의 알람을 ars 로 교체 (비어 있으면 삭제) - 도메인 변경과 같은 트랜잭션(tx)으로 호출
+func enqueueReplaceAlarms(tx *gorm.DB, parentId, uid uint, alarmType int, ars []*pb.AlarmRequest) error {
+	return util.EnqueueAlarm(tx, AlarmSource, parentId, uid, util.ReplaceAlarmsMethod,
+		&pb.ReplaceAlarmsRequest{ParentId: int32(parentId), Uid: int32(uid), Type: int32(alarmType), Alarms: ars})
+}
+
+// 알람 삭제 - 기록마다 순서를 지키도록 parent 별로 저장
+func enqueueRemoveAlarms(tx *gorm.DB, parentIds []uint, uid uint, alarmType int) error {
+	for _, v := range parentIds {
+		if err := enqueueReplaceAlarms(tx, v, uid, alarmType, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 약 기록대로 알람 교체 요청 - 중지했거나 필요시 복용이면 삭제
+func enqueueMedicineAlarms(tx *gorm.DB, medicine model.Medicine) error {
+	if !medicine.IsActive || medicine.IntervalType == intervalAsNeeded {
+		return enqueueRemoveAlarms(tx, []uint{medicine.Id}, medicine.Uid, util.MedicineType)
+	}
+	var timestamps []string
+	if len(medicine.Timestamp) > 0 {
+		if err := json.Unmarshal(medicine.Timestamp, &timestamps); err != nil {
+			return err
+		}
+	}
+	_, week, err := validateWeek(medicine)
+	if err != nil {
+		return err
+	}
+	var tapers []dto.Taper
+	if len(medicine.Tapers) > 0 {
+		if err := json.Unmarshal(medicine.Tapers, &tapers); err != nil {
+			return err
+		}
+	}
+	return enqueueReplaceAlarms(tx, medicine.Id, medicine.Uid, util.MedicineType, buildAlarmRequests(medicine, timestamps, week, tapers))
+}
+
+// 약 기록(삭제 제외) 기준으로 알람 다시 맞추기 (uid 0 이면 전체) - 기록마다 교체/삭제 요청, 기록이 없는 알람은 삭제 요청
+// 요청 건수 반환, 전달은 AlarmRelay 가 처리
+func ReconcileAlarms(db *gorm.DB, uid uint) (int, error) {
+	count := 0
+	query := db.Where("is_delete = false").Order("id")
+	if uid != 0 {
+		query = query.Where("uid = ?", uid)
+	}
+	var medicines []model.Medicine
+	err := query.FindInBatches(&medicines, reconcileBatch, func(_ *gorm.DB, batch int) error {
+		return db.Transaction(func(tx *gorm.DB) error {
+			for _, v := range medicines {
+				if err := enqueueMedicineAlarms(tx, v); err != nil {
+					return err
+				}
+			}
+			count += len(medicines)
+			return nil
+		})
+	}).Error
+	if err != nil {
+		return count, err
+	}
+
+	var orphans []struct {
+		Uid      uint
+		ParentId uint
+	}
+	query = db.Table("alarms").Distinct("uid", "parent_id").
+		Where("type = ? AND NOT EXISTS (SELECT 1 FROM medicines WHERE medicines.id = alarms.parent_id AND medicines.uid = alarms.uid AND medicines.is_delete = false)", util.MedicineType)
+	if uid != 0 {
+		query = query.Where("uid = ?", uid)
+	}
+	if err := query.Scan(&orphans).Error; err != nil {
+		return count, err
+	}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		for _, v := range orphans {
+			if err := enqueueRemoveAlarms(tx, []uint{v.ParentId}, v.Uid, util.MedicineType); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return count, err
+	}
+	return count + len(orphans), nil
+}
@@ -0,0 +1,219 @@
+// /medicine-service/service/drug.go
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"medicine-service/dto"
+	"regexp"
+	"strings"
+
+	"github.com/disterbia/wellkinson/common/model"
+	"github.com/disterbia/wellkinson/common/util"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 상호작용 심각도
+const (
+	interactionCaution          = 1 // 주의
+	interactionContraindicated  = 2 // 병용금기
+	drugWarningDuplicate        = "duplicate"
+	drugWarningInteraction      = "interaction"
+	levodopaKey                 = "레보도파"
+	antidopaminergicDescription = "도파민 차단 작용으로 레보도파 효과가 줄고 파킨슨 증상이 나빠질 수 있어요."
+	maoiDescription             = "비선택적 MAO 억제제와 함께 복용하면 급격한 혈압 상승이 생길 수 있어요."
+	serotoninDescription        = "MAO-B 억제제와 함께 복용하면 세로토닌 증후군이 생길 수 있어요."
+)
+
+// 성분명 비교시 빼는 염/수화물 표기
+var saltSuffixes = []string{"무수물", "수화물", "염산염", "메실산염", "황산염", "말레산염", "타르타르산염", "주석산염", "숙신산염", "브롬화수소산염", "인산염",
+	"anhydrous", "hydrate", "hydrochloride", "mesylate", "sulfate", "maleate", "bitartrate", "tartrate", "succinate", "hydrobromide", "phosphate"}
+
+var parenthesized = regexp.MustCompile(`\(.*?\)`)
+
+// 파킨슨 환자에게 중요한 레보도파/MAO-B 억제제 상호작용 (DUR 자료를 가져오지 않아도 확인되도록 기본 등록)
+var defaultDrugInteractions = []model.DrugInteraction{
+	{KeyA: levodopaKey, KeyB: "페넬진", Severity: interactionContraindicated, Description: maoiDescription},
+	{KeyA: levodopaKey, KeyB: "트라닐시프로민", Severity: interactionContraindicated, Description: maoiDescription},
+	{KeyA: levodopaKey, KeyB: "이소카르복사지드", Severity: interactionContraindicated, Description: maoiDescription},
+	{KeyA: levodopaKey, KeyB: "할로페리돌", Severity: interactionCaution, Description: antidopaminergicDescription},
+	{KeyA: levodopaKey, KeyB: "클로르프로마진", Severity: interactionCaution, Description: antidopaminergicDescription},
+	{KeyA: levodopaKey, KeyB: "리스페리돈", Severity: interactionCaution, Description: antidopaminergicDescription},
+	{KeyA: levodopaKey, KeyB: "올란자핀", Severity: interactionCaution, Description: antidopaminergicDescription},
+	{KeyA: levodopaKey, KeyB: "메토클로프라미드", Severity: interactionCaution, Description: antidopaminergicDescription},
+	{KeyA: levodopaKey, KeyB: "레보설피리드", Severity: interactionCaution, Description: antidopaminergicDescription},
+	{KeyA: levodopaKey, KeyB: "황산제일철", Severity: interactionCaution, Description: "철분제가 레보도파 흡수를 줄일 수 있어요. 2시간 이상 간격을 두고 복용하세요."},
+	{KeyA: "셀레길린", KeyB: "페티딘", Severity: interactionContraindicated, Description: serotoninDescription},
+	{KeyA: "라사길린", KeyB: "페티딘", Severity: interactionContraindicated, Description: serotoninDescription},
+	{KeyA: "사피나미드", KeyB: "페티딘", Severity: interactionContraindicated, Description: serotoninDescription},
+	{KeyA: "셀레길린", KeyB: "트라마돌", Severity: interactionCaution, Description: serotoninDescription},
+	{KeyA: "라사길린", KeyB: "트라마돌", Severity: interactionCaution, Description: serotoninDescription},
+	{KeyA: "라사길린", KeyB: "플루옥세틴", Severity: interactionCaution, Description: serotoninDescription},
+}
+
+// 기본 상호작용 등록 (이미 있으면 유지)
+func SeedDrugInteractions(db *gorm.DB) error {
+	interactions := make([]model.DrugInteraction, 0, len(defaultDrugInteractions))
+	for _, v := range defaultDrugInteractions {
+		v.KeyA, v.KeyB = interactionKeys(v.KeyA, v.KeyB)
+		interactions = append(interactions, v)
+	}
+	return db.Clauses(clause.OnConflict{DoNothing: true}).Create(&interactions).Error
+}
+
+// 성분 비교용 이름 - 괄호, 공백, 염/수화물 표기 제거
+func ingredientKey(name string) string {
+	key := strings.ToLower(parenthesized.ReplaceAllString(name, ""))
+	key = strings.Join(strings.Fields(key), "")
+	for trimmed := true; trimmed; {
+		trimmed = false
+		for _, suffix := range saltSuffixes {
+			if len(key) > len(suffix) && strings.HasSuffix(key, suffix) {
+				key = strings.TrimSuffix(key, suffix)
+				trimmed = true
+			}
+		}
+	}
+	return key
+}
+
+func interactionKeys(a, b string) (string, string) {
+	a, b = ingredientKey(a), ingredientKey(b)
+	if a > b {
+		return b, a
+	}
+	return a, b
+}
+
+// 복용중인 다른 약(활성, 의약품 목록 연결)과의 중복 성분, 상호작용
+func drugWarnings(db *gorm.DB, uid, drugProductId, medicineId uint) ([]dto.DrugWarning, error) {
+	if drugProductId == 0 {
+		return nil, nil
+	}
+	var ingredients []model.DrugIngredient
+	if err := db.Where("drug_product_id = ?", drugProductId).Find(&ingredients).Error; err != nil {
+		return nil, err
+	}
+	if len(ingredients) == 0 {
+		return nil, nil
+	}
+
+	var others []struct {
+		MedicineId   uint
+		MedicineName string
+		Key          string
+		Name         string
+	}
+	if err := db.Table("medicines").Select("medicines.id AS medicine_id, medicines.name AS medicine_name, drug_ingredients.key, drug_ingredients.name").
+		Joins("JOIN drug_ingredients ON drug_ingredients.drug_product_id = medicines.drug_product_id").
+		Where("medicines.uid = ? AND medicines.id <> ? AND medicines.is_active = true AND medicines.is_delete = false AND medicines.drug_product_id <> 0",
+			uid, medicineId).Order("medicines.id").Scan(&others).Error; err != nil {
+		return nil, err
+	}
+	if len(others) == 0 {
+		return nil, nil
+	}
+
+	keys := make(map[string]string)
+	var keyList, otherKeys []string
+	for _, v := range ingredients {
+		if _, ok := keys[v.Key]; !ok {
+			keys[v.Key] = v.Name
+			keyList = append(keyList, v.Key)
+		}
+	}
+	for _, v := range others {
+		otherKeys = append(otherKeys, v.Key)
+	}
+
+	var interactions []model.DrugInteraction
+	if err := db.Where("(key_a IN ? AND key_b IN ?) OR (key_b IN ? AND key_a IN ?)", keyList, otherKeys, keyList, otherKeys).
+		Find(&interactions).Error; err != nil {
+		return nil, err
+	}
+
+	var warnings []dto.DrugWarning
+	for _, v := range others {
+		if name, ok := keys[v.Key]; ok {
+			warnings = append(warnings, dto.DrugWarning{Type: drugWarningDuplicate, Severity: interactionCaution, MedicineId: v.MedicineId,
+				MedicineName: v.MedicineName, Ingredient: name,
+				Description: fmt.Sprintf("%s 성분이 %s에도 들어있어요. 중복 복용에 주의하세요.", name, v.MedicineName)})
+		}
+		for _, interaction := range interactions {
+			var key string
+			switch v.Key {
+			case interaction.KeyA:
+				key = interaction.KeyB
+			case interaction.KeyB:
+				key = interaction.KeyA
+			default:
+				continue
+			}
+			if name, ok := keys[key]; ok {
+				warnings = append(warnings, dto.DrugWarning{Type: drugWarningInteraction, Severity: interaction.Severity, MedicineId: v.MedicineId,
+					MedicineName: v.MedicineName, Ingredient: name + "+" + v.Name, Description: interaction.Description})
+			}
+		}
+	}
+	return warnings, nil
+}
+
+func validateDrugProduct(db *gorm.DB, drugProductId uint) error {
+	if drugProductId == 0 {
+		return nil
+	}
+	if err := db.Select("id").Where("id = ?", drugProductId).First(&model.DrugProduct{}).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return util.NewError(util.ErrInvalidRequest, "invalid drug_product_id")
+		}
+		return util.NewError(util.ErrDatabase, "db error")
+	}
+	return nil
+}
+
+// 저장 후 경고 확인 - 약은 이미 저장되었으므로 실패해도 저장 결과는 유지
+func saveWarnings(db *gorm.DB, uid, drugProductId, medicineId uint) []dto.DrugWarning {
+	warnings, err := drugWarnings(db, uid, drugProductId, medicineId)
+	if err != nil {
+		log.Printf("error checking drug warnings: %v", err)
+	}
+	return warnings
+}
+
+// 등록 전 확인
+func (service *medicineService) CheckDrugWarnings(uid, drugProductId uint) ([]dto.DrugWarning, error) {
+	if err := validateDrugProduct(service.db, drugProductId); err != nil {
+		return nil, err
+	}
+	warnings, err := drugWarnings(service.db, uid, drugProductId, 0)
+	if err != nil {
+		return nil, util.NewError(util.ErrDatabase, "db error2")
+	}
+	return warnings, nil
+}
+
+func drugProductResponses(db *gorm.DB, products []model.DrugProduct) ([]dto.DrugProductResponse, error) {
+	ids := make([]uint, 0, len(products))
+	for _, v := range products {
+		ids = append(ids, v.Id)
+	}
+	var ingredients []model.DrugIngredient
+	if len(ids) > 0 {
+		if err := db.Where("drug_product_id IN ?", ids).Order("id").Find(&ingredients).Error; err != nil {
+			return nil, util.NewError(util.ErrDatabase, "db error2")
+		}
+	}
+	names := make(map[uint][]string)
+	for _, v := range ingredients {
+		names[v.DrugProductId] = append(names[v.DrugProductId], v.Name)
+	}
+
+	responses := make([]dto.DrugProductResponse, 0, len(products))
+	for _, v := range products {
+		responses = append(responses, dto.DrugProductResponse{Id: v.Id, ItemSeq: v.ItemSeq, Name: v.Name, Company: v.Company,
+			EdiCode: v.EdiCode, AtcCode: v.AtcCode, Ingredients: names[v.Id]})
+	}
+	return responses, nil
+}
@@ -0,0 +1,260 @@
+// /medicine-service/service/drug_import.go
+package service
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"io"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/disterbia/wellkinson/common/model"
+	"golang.org/x/text/encoding/korean"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const drugImportBatch = 500
+
+// CSV 헤더 (식약처 파일 한글 헤더, 공공데이터 API 필드명)
+var drugProductColumns = map[string][]string{
+	"item_seq":       {"품목기준코드", "품목일련번호", "ITEM_SEQ"},
+	"name":           {"품목명", "제품명", "ITEM_NAME"},
+	"company":        {"업체명", "ENTP_NAME"},
+	"ingredients":    {"주성분", "주성분명", "MAIN_ITEM_INGR"},
+	"ingredients_en": {"주성분영문", "주성분명(영문)", "MAIN_INGR_ENG"},
+	"edi_code":       {"보험코드", "EDI_CODE"},
+	"atc_code":       {"ATC코드", "ATC_CODE"},
+}
+
+var drugInteractionColumns = map[string][]string{
+	"ingredient_a": {"성분명A", "성분명1", "INGR_KOR_NAME_A", "INGR_NAME_A"},
+	"ingredient_b": {"성분명B", "성분명2", "INGR_KOR_NAME_B", "INGR_NAME_B"},
+	"description":  {"금기내용", "상세정보", "PROHBT_CONTENT"},
+	"severity":     {"심각도", "SEVERITY"},
+}
+
+// [M040534]레보도파 형식의 성분코드
+var ingredientCode = regexp.MustCompile(`^\[(\w+)\](.*)$`)
+
+// 의약품 제품 허가정보 CSV 가져오기 - 품목기준코드 기준으로 갱신, 성분은 새로 저장
+func ImportDrugProducts(db *gorm.DB, r io.Reader) (int, error) {
+	reader, columns, err := openDrugCsv(r, drugProductColumns, "item_seq", "name")
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	var products []model.DrugProduct
+	ingredients := make(map[string][]model.DrugIngredient)
+	flush := func() error {
+		if len(products) == 0 {
+			return nil
+		}
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "item_seq"}},
+				DoUpdates: clause.AssignmentColumns([]string{"name", "company", "edi_code", "atc_code", "updated"}),
+			}).Create(&products).Error; err != nil {
+				return err
+			}
+			ids := make([]uint, 0, len(products))
+			var newIngredients []model.DrugIngredient
+			for _, v := range products {
+				ids = append(ids, v.Id)
+				for _, ingredient := range ingredients[v.ItemSeq] {
+					ingredient.DrugProductId = v.Id
+					newIngredients = append(newIngredients, ingredient)
+				}
+			}
+			if err := tx.Where("drug_product_id IN ?", ids).Delete(&model.DrugIngredient{}).Error; err != nil {
+				return err
+			}
+			if len(newIngredients) == 0 {
+				return nil
+			}
+			return tx.Create(&newIngredients).Error
+		})
+		if err != nil {
+			return err
+		}
+		count += len(products)
+		products = nil
+		ingredients = make(map[string][]model.DrugIngredient)
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return count, err
+		}
+		itemSeq := csvValue(record, columns, "item_seq")
+		name := csvValue(record, columns, "name")
+		// 같은 품목이 여러 줄이면 처음 것만 사용 (한 배치에 두번 있으면 upsert 실패)
+		if itemSeq == "" || name == "" || seen[itemSeq] {
+			continue
+		}
+		seen[itemSeq] = true
+		products = append(products, model.DrugProduct{ItemSeq: itemSeq, Name: name, Company: csvValue(record, columns, "company"),
+			EdiCode: csvValue(record, columns, "edi_code"), AtcCode: csvValue(record, columns, "atc_code")})
+		ingredients[itemSeq] = parseIngredients(csvValue(record, columns, "ingredients"), csvValue(record, columns, "ingredients_en"))
+
+		if len(products) >= drugImportBatch {
+			if err := flush(); err != nil {
+				return count, err
+			}
+		}
+	}
+	return count, flush()
+}
+
+// 병용금기 CSV 가져오기 (DUR 병용금기 성분 목록) - 심각도가 없으면 병용금기
+func ImportDrugInteractions(db *gorm.DB, r io.Reader) (int, error) {
+	reader, columns, err := openDrugCsv(r, drugInteractionColumns, "ingredient_a", "ingredient_b")
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	interactions := make(map[[2]string]model.DrugInteraction)
+	flush := func() error {
+		if len(interactions) == 0 {
+			return nil
+		}
+		batch := make([]model.DrugInteraction, 0, len(interactions))
+		for _, v := range interactions {
+			batch = append(batch, v)
+		}
+		if err := db.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "key_a"}, {Name: "key_b"}},
+			DoUpdates: clause.AssignmentColumns([]string{"severity", "description", "updated"}),
+		}).Create(&batch).Error; err != nil {
+			return err
+		}
+		count += len(batch)
+		interactions = make(map[[2]string]model.DrugInteraction)
+		return nil
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return count, err
+		}
+		keyA, keyB := interactionKeys(csvValue(record, columns, "ingredient_a"), csvValue(record, columns, "ingredient_b"))
+		if keyA == "" || keyB == "" || keyA == keyB {
+			continue
+		}
+		severity := uint(interactionContraindicated)
+		if value := csvValue(record, columns, "severity"); value != "" && value != "2" && !strings.Contains(value, "금기") {
+			severity = interactionCaution
+		}
+		interactions[[2]string{keyA, keyB}] = model.DrugInteraction{KeyA: keyA, KeyB: keyB, Severity: severity,
+			Description: csvValue(record, columns, "description")}
+
+		if len(interactions) >= drugImportBatch {
+			if err := flush(); err != nil {
+				return count, err
+			}
+		}
+	}
+	return count, flush()
+}
+
+// 주성분 "[코드]이름|[코드]이름" - 영문명은 개수가 같을 때만 순서대로 연결
+func parseIngredients(main, mainEn string) []model.DrugIngredient {
+	var ingredients []model.DrugIngredient
+	names := splitIngredients(main, "|;")
+	namesEn := splitIngredients(mainEn, "|;/")
+	for i, v := range names {
+		ingredient := model.DrugIngredient{Name: v}
+		if match := ingredientCode.FindStringSubmatch(v); match != nil {
+			ingredient.Code = match[1]
+			ingredient.Name = strings.TrimSpace(match[2])
+		}
+		if len(namesEn) == len(names) {
+			ingredient.NameEn = namesEn[i]
+		}
+		ingredient.Key = ingredientKey(ingredient.Name)
+		if ingredient.Key == "" {
+			ingredient.Key = ingredientKey(ingredient.NameEn)
+		}
+		if ingredient.Key != "" {
+			ingredients = append(ingredients, ingredient)
+		}
+	}
+	return ingredients
+}
+
+func splitIngredients(value, separators string) []string {
+	var names []string
+	for _, v := range strings.FieldsFunc(value, func(r rune) bool { return strings.ContainsRune(separators, r) }) {
+		if v = strings.TrimSpace(v); v != "" {
+			names = append(names, v)
+		}
+	}
+	return names
+}
+
+// 식약처 파일은 EUC-KR 인 경우가 있어 UTF-8 이 아니면 변환 후 헤더로 열 위치 확인
+func openDrugCsv(r io.Reader, aliases map[string][]string, required ...string) (*csv.Reader, map[string]int, error) {
+	buffered := bufio.NewReaderSize(r, 64*1024)
+	head, _ := buffered.Peek(64 * 1024)
+	var source io.Reader = buffered
+	if !isUTF8(head) {
+		source = korean.EUCKR.NewDecoder().Reader(buffered)
+	}
+
+	reader := csv.NewReader(source)
+	reader.LazyQuotes = true
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	columns := make(map[string]int)
+	for i, v := range header {
+		name := strings.ToUpper(strings.Join(strings.Fields(strings.TrimPrefix(v, "\ufeff")), ""))
+		for column, names := range aliases {
+			for _, alias := range names {
+				if _, ok := columns[column]; !ok && name == strings.ToUpper(alias) {
+					columns[column] = i
+				}
+			}
+		}
+	}
+	for _, v := range required {
+		if _, ok := columns[v]; !ok {
+			return nil, nil, errors.New("missing column " + v)
+		}
+	}
+	return reader, columns, nil
+}
+
+// 앞부분이 UTF-8 인지 (잘린 마지막 글자 제외)
+func isUTF8(head []byte) bool {
+	n := len(head)
+	for i := 0; i < utf8.UTFMax && n > 0 && !utf8.Valid(head[:n]); i++ {
+		n--
+	}
+	return utf8.Valid(head[:n])
+}
+
+func csvValue(record []string, columns map[string]int, column string) string {
+	i, ok := columns[column]
+	if !ok || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
@@ -0,0 +1,33 @@
+// /medicine-service/service/drug_test.go
+package service
+
+import "testing"
+
+func TestIngredientKey(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Levodopa", "levodopa"},
+		{"레보도파(Levodopa)", "레보도파"},
+		{" Rasagiline  Mesylate ", "rasagiline"},
+		{"Ropinirole Hydrochloride", "ropinirole"},
+		{"카비도파 수화물", "카비도파"},
+		{"프라미펙솔염산염수화물", "프라미펙솔"},
+		{"염산염", "염산염"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := ingredientKey(tt.name); got != tt.want {
+			t.Errorf("ingredientKey(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestInteractionKeys(t *testing.T) {
+	a, b := interactionKeys("셀레길린 염산염", "Levodopa")
+	c, d := interactionKeys("levodopa", "셀레길린")
+	if a != c || b != d || a > b {
+		t.Fatalf("interactionKeys = (%q, %q) and (%q, %q), want the same sorted pair", a, b, c, d)
+	}
+}
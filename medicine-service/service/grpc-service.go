@@ -0,0 +1,63 @@
+// /medicine-service/service/grpc-service.go
+package service
+
+import (
+	"context"
+	"errors"
+	"medicine-service/dto"
+	pb "medicine-service/proto"
+	"time"
+
+	"github.com/disterbia/wellkinson/common/model"
+	"github.com/disterbia/wellkinson/common/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// 알림 액션(확인/복용) 처리용 gRPC 서버
+type MedicineServer struct {
+	pb.UnimplementedMedicineServiceServer
+	Db  *gorm.DB
+	Svc MedicineService
+}
+
+// 알림에서 복용 처리 - 앱의 복용 체크와 같은 로직 (용량은 복용일의 용량 기간 기준)
+func (s *MedicineServer) TakeMedicine(ctx context.Context, req *pb.TakeMedicineRequest) (*pb.MedicineResponse, error) {
+	date, err := time.Parse("2006-01-02", req.DateTaken)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid date_taken")
+	}
+	var medicine model.Medicine
+	if err := s.Db.Where("id = ? AND uid = ? AND is_delete = false", req.MedicineId, req.Uid).First(&medicine).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Error(codes.NotFound, "medicine not found")
+		}
+		return nil, status.Error(codes.Unavailable, "db unavailable")
+	}
+	var origin dto.MedicineOriginResponse
+	if err := util.CopyStruct(medicine, &origin); err != nil {
+		return nil, status.Error(codes.Internal, "invalid medicine")
+	}
+
+	code, err := s.Svc.TakeMedicine(dto.TakeMedicine{Uid: uint(req.Uid), MedicineId: uint(req.MedicineId), DateTaken: req.DateTaken,
+		TimeTaken: req.TimeTaken, RealTaken: req.RealTaken, Dose: doseOn(origin, date)})
+	if err != nil {
+		return nil, appStatus(err)
+	}
+	return &pb.MedicineResponse{Status: code}, nil
+}
+
+// 서비스 오류 코드를 gRPC 상태로 - 다시 보내도 실패하는 요청은 재시도하지 않도록 구분
+func appStatus(err error) error {
+	appErr := util.ToAppError(err)
+	switch appErr.Code {
+	case util.ErrInvalidRequest:
+		return status.Error(codes.InvalidArgument, appErr.Detail)
+	case util.ErrNotFound:
+		return status.Error(codes.NotFound, appErr.Detail)
+	case util.ErrDatabase:
+		return status.Error(codes.Unavailable, appErr.Detail)
+	}
+	return status.Error(codes.Internal, appErr.Detail)
+}
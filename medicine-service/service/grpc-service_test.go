@@ -0,0 +1,31 @@
+// /medicine-service/service/grpc-service_test.go
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/disterbia/wellkinson/common/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAppStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{"invalid request", util.NewError(util.ErrInvalidRequest, "over dose"), codes.InvalidArgument},
+		{"not found", util.NewError(util.ErrNotFound, "medicine not found"), codes.NotFound},
+		{"database", util.NewError(util.ErrDatabase, "db error"), codes.Unavailable},
+		{"untyped", errors.New("boom"), codes.Internal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := status.Code(appStatus(tt.err)); got != tt.want {
+				t.Errorf("appStatus() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+// /medicine-service/service/refill.go
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"medicine-service/dto"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/disterbia/wellkinson/common/model"
+	"github.com/disterbia/wellkinson/common/util"
+	"gorm.io/gorm"
+)
+
+const (
+	refillForecastDays = 365 // 이 기간 안에 떨어지지 않으면 소진일 없음
+	refillRemindDays   = 3   // 보충하지 않으면 이 기간마다 다시 알림
+	refillNotifyFrom   = 9   // 유저 시간대 기준 알림 시간 (9시~21시)
+	refillNotifyUntil  = 21
+	refillBatch        = 500
+	pushQueued         = 5 // fcm-service 가 유저의 기기별로 나눠 발송
+)
+
+// 소진 N일 전부터 알림 - REFILL_WARN_DAYS 로 설정 (기본 7일)
+func refillWarnDays() int {
+	days, err := strconv.Atoi(os.Getenv("REFILL_WARN_DAYS"))
+	if err != nil || days <= 0 {
+		days = 7
+	}
+	return days
+}
+
+// 남은 재고로 복용 가능한 일수와 소진일 - 오늘은 지금 이후 복용분만 계산
+// 일정이 없거나(필요시 복용) 기간 안에 떨어지지 않으면 -1, ""
+func forecastSupply(medicine dto.MedicineOriginResponse, now time.Time) (int, string) {
+	store := medicine.Store
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	clock := now.Format("15:04")
+	for i := 0; i < refillForecastDays; i++ {
+		date := today.AddDate(0, 0, i)
+		day := date.Format("2006-01-02")
+		if day < medicine.StartAt {
+			continue
+		}
+		if medicine.EndAt != "" && day > medicine.EndAt {
+			break
+		}
+		dose := doseOn(medicine, date)
+		for _, v := range doseTimes(medicine, date) {
+			if i == 0 && v <= clock {
+				continue
+			}
+			store -= dose
+			if store < 0 {
+				return i, day
+			}
+		}
+	}
+	return -1, ""
+}
+
+func refillForecast(medicine model.Medicine, now time.Time) (dto.RefillForecastResponse, error) {
+	var origin dto.MedicineOriginResponse
+	if err := util.CopyStruct(medicine, &origin); err != nil {
+		return dto.RefillForecastResponse{}, err
+	}
+	daysLeft, runOutAt := forecastSupply(origin, now)
+	return dto.RefillForecastResponse{MedicineId: medicine.Id, Name: medicine.Name, Store: medicine.Store, LeastStore: medicine.LeastStore,
+		DaysLeft: daysLeft, RunOutAt: runOutAt,
+		NeedRefill: medicine.Store < medicine.LeastStore || (daysLeft >= 0 && daysLeft <= refillWarnDays())}, nil
+}
+
+// 재고 관리 중인 약의 소진 예상
+func (service *medicineService) GetRefillForecasts(uid uint) ([]dto.RefillForecastResponse, error) {
+	var medicines []model.Medicine
+	if err := service.db.Where("uid = ? AND use_least_store = true AND is_delete = false", uid).Find(&medicines).Error; err != nil {
+		return nil, util.NewError(util.ErrDatabase, "db error")
+	}
+	now := time.Now().In(userLocation(service.db, uid))
+
+	forecasts := make([]dto.RefillForecastResponse, 0, len(medicines))
+	for _, v := range medicines {
+		forecast, err := refillForecast(v, now)
+		if err != nil {
+			return nil, err
+		}
+		forecasts = append(forecasts, forecast)
+	}
+	return forecasts, nil
+}
+
+// 약 보충 - 재고를 늘리고 재고 부족 알림 상태 초기화
+func (service *medicineService) RefillMedicine(refillRequest dto.RefillRequest) (string, error) {
+	if refillRequest.Amount <= 0 {
+		return "", util.NewError(util.ErrInvalidRequest, "invalid amount")
+	}
+
+	err := service.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Medicine{}).Where("id = ? AND uid = ? AND is_delete = false", refillRequest.MedicineId, refillRequest.Uid).
+			Updates(map[string]interface{}{"store": gorm.Expr("store + ?", refillRequest.Amount), "refill_notified_at": ""})
+		if result.Error != nil {
+			return util.NewError(util.ErrDatabase, "db error")
+		}
+		if result.RowsAffected == 0 {
+			return util.NewError(util.ErrNotFound, "medicine not found")
+		}
+		var medicine model.Medicine
+		if err := tx.Select("store").Where("id = ?", refillRequest.MedicineId).First(&medicine).Error; err != nil {
+			return util.NewError(util.ErrDatabase, "db error2")
+		}
+		if err := tx.Create(&model.MedicineRefill{Uid: refillRequest.Uid, MedicineId: refillRequest.MedicineId,
+			Amount: refillRequest.Amount, Store: medicine.Store}).Error; err != nil {
+			return util.NewError(util.ErrDatabase, "db error3")
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return "200", nil
+}
+
+// 재고가 최소 재고보다 적거나 N일 안에 떨어질 약의 보충 알림 (한시간마다 실행)
+func (service *medicineService) CheckRefills() {
+	locations := make(map[uint]*time.Location)
+	var medicines []model.Medicine
+	err := service.db.Where("use_least_store = true AND is_active = true AND is_delete = false").
+		FindInBatches(&medicines, refillBatch, func(tx *gorm.DB, batch int) error {
+			for _, v := range medicines {
+				loc, ok := locations[v.Uid]
+				if !ok {
+					loc = userLocation(service.db, v.Uid)
+					locations[v.Uid] = loc
+				}
+				now := time.Now().In(loc)
+				if now.Hour() < refillNotifyFrom || now.Hour() >= refillNotifyUntil {
+					continue
+				}
+				today := now.Format("2006-01-02")
+				if v.RefillNotifiedAt != "" && v.RefillNotifiedAt > addDays(today, -refillRemindDays) {
+					continue
+				}
+
+				forecast, err := refillForecast(v, now)
+				if err != nil || !forecast.NeedRefill {
+					continue
+				}
+				if err := service.notifyRefill(v, forecast, today); err != nil {
+					log.Printf("error notifying refill %d: %v\n", v.Id, err)
+				}
+			}
+			return nil
+		}).Error
+	if err != nil {
+		log.Printf("error checking refills: %v\n", err)
+	}
+}
+
+// 알림함 저장 후 fcm-service 에 발송 요청
+func (service *medicineService) notifyRefill(medicine model.Medicine, forecast dto.RefillForecastResponse, today string) error {
+	body := fmt.Sprintf("%s 재고가 %g 남았어요. 약을 보충해주세요.", medicine.Name, medicine.Store)
+	if forecast.DaysLeft >= 0 {
+		body = fmt.Sprintf("%s 재고가 %g 남아 %s 에 떨어질 예정이에요. 약을 보충해주세요.", medicine.Name, medicine.Store, forecast.RunOutAt)
+	}
+
+	return service.db.Transaction(func(tx *gorm.DB) error {
+		// 여러 인스턴스가 같은 약을 알리지 않도록 이전 알림 날짜를 조건으로 갱신
+		result := tx.Model(&model.Medicine{}).Where("id = ? AND refill_notified_at = ?", medicine.Id, medicine.RefillNotifiedAt).
+			Update("refill_notified_at", today)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		if err := tx.Create(&model.Notification{Uid: medicine.Uid, Type: uint(util.MedicineType), Body: body,
+			ParentId: medicine.Id}).Error; err != nil {
+			return err
+		}
+		payload, err := json.Marshal(map[string]interface{}{
+			"data": map[string]string{
+				"uid":       strconv.FormatUint(uint64(medicine.Uid), 10),
+				"type":      strconv.Itoa(util.MedicineType),
+				"timestamp": time.Now().Format(time.RFC3339),
+				"parent_id": strconv.FormatUint(uint64(medicine.Id), 10),
+				"refill":    "1",
+			},
+			"title": "약 재고",
+			"body":  body,
+		})
+		if err != nil {
+			return err
+		}
+		return tx.Create(&model.PushDelivery{Uid: medicine.Uid, Payload: payload, Status: pushQueued}).Error
+	})
+}
@@ -0,0 +1,39 @@
+// /medicine-service/service/refill_test.go
+package service
+
+import (
+	"medicine-service/dto"
+	"testing"
+	"time"
+)
+
+func TestForecastSupply(t *testing.T) {
+	// 2024-05-01 (수) 10:00 - 오늘 09:00 복용분은 이미 지남
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	everyday := []uint{0, 1, 2, 3, 4, 5, 6}
+
+	tests := []struct {
+		name     string
+		medicine dto.MedicineOriginResponse
+		wantDays int
+		wantDate string
+	}{
+		{"twice a day", dto.MedicineOriginResponse{StartAt: "2024-04-01", Store: 3, Dose: 1, Weekdays: everyday, Timestamp: []string{"09:00", "21:00"}}, 2, "2024-05-03"},
+		{"runs out today", dto.MedicineOriginResponse{StartAt: "2024-04-01", Store: 0, Dose: 1, Weekdays: everyday, Timestamp: []string{"09:00", "21:00"}}, 0, "2024-05-01"},
+		{"weekly", dto.MedicineOriginResponse{StartAt: "2024-04-01", Store: 1, Dose: 1, Weekdays: []uint{1}, Timestamp: []string{"09:00"}}, 12, "2024-05-13"},
+		{"future start", dto.MedicineOriginResponse{StartAt: "2024-05-05", Store: 1, Dose: 1, Weekdays: everyday, Timestamp: []string{"09:00"}}, 5, "2024-05-06"},
+		{"taper dose", dto.MedicineOriginResponse{StartAt: "2024-04-01", Store: 3, Dose: 1, Weekdays: everyday, Timestamp: []string{"09:00"},
+			Tapers: []dto.Taper{{StartAt: "2024-05-02", EndAt: "2024-05-10", Dose: 2}}}, 2, "2024-05-03"},
+		{"every 2 days", dto.MedicineOriginResponse{StartAt: "2024-05-01", Store: 1, Dose: 1, IntervalType: intervalDays, Interval: 2, Timestamp: []string{"09:00"}}, 4, "2024-05-05"},
+		{"ends before running out", dto.MedicineOriginResponse{StartAt: "2024-04-01", EndAt: "2024-05-02", Store: 3, Dose: 1, Weekdays: everyday, Timestamp: []string{"09:00", "21:00"}}, -1, ""},
+		{"as needed", dto.MedicineOriginResponse{StartAt: "2024-04-01", Store: 0, Dose: 1, IntervalType: intervalAsNeeded}, -1, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			days, date := forecastSupply(tt.medicine, now)
+			if days != tt.wantDays || date != tt.wantDate {
+				t.Fatalf("forecastSupply = (%d, %q), want (%d, %q)", days, date, tt.wantDays, tt.wantDate)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+// /medicine-service/service/search.go
+package service
+
+import (
+	"log"
+	"medicine-service/dto"
+	"strings"
+
+	"github.com/disterbia/wellkinson/common/model"
+	"github.com/disterbia/wellkinson/common/util"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	searchDefaultSize = 20
+	searchMaxSize     = 50
+	hangulBase        = 0xAC00 // 가
+	hangulLast        = 0xD7A3 // 힣
+	hangulInitialSpan = 21 * 28
+)
+
+// 초성 (ㄱ ㄲ ㄴ ...) - 한글 음절의 첫소리 순서
+var choseong = []rune("ㄱㄲㄴㄷㄸㄹㅁㅂㅃㅅㅆㅇㅈㅉㅊㅋㅌㅍㅎ")
+
+// 검색용 DB 함수/인덱스 - chosung(text) 는 한글 음절을 초성으로 바꾼 문자열 (인덱스에 쓰도록 IMMUTABLE)
+var searchSetupStatements = []string{
+	`CREATE OR REPLACE FUNCTION chosung(input text) RETURNS text LANGUAGE plpgsql IMMUTABLE STRICT AS $$
+DECLARE
+	result text := '';
+	ch text;
+	code int;
+BEGIN
+	FOREACH ch IN ARRAY regexp_split_to_array(input, '') LOOP
+		code := ascii(ch);
+		IF code BETWEEN 44032 AND 55203 THEN
+			result := result || substr('ㄱㄲㄴㄷㄸㄹㅁㅂㅃㅅㅆㅇㅈㅉㅊㅋㅌㅍㅎ', (code - 44032) / 588 + 1, 1);
+		ELSE
+			result := result || ch;
+		END IF;
+	END LOOP;
+	RETURN result;
+END
+$$`,
+	`CREATE EXTENSION IF NOT EXISTS pg_trgm`,
+	`CREATE INDEX IF NOT EXISTS idx_medicine_searches_name_trgm ON medicine_searches USING gin (name gin_trgm_ops)`,
+	`CREATE INDEX IF NOT EXISTS idx_medicine_searches_chosung_trgm ON medicine_searches USING gin (chosung(name) gin_trgm_ops)`,
+	`CREATE INDEX IF NOT EXISTS idx_drug_products_name_trgm ON drug_products USING gin (name gin_trgm_ops)`,
+	`CREATE INDEX IF NOT EXISTS idx_drug_products_chosung_trgm ON drug_products USING gin (chosung(name) gin_trgm_ops)`,
+	`CREATE INDEX IF NOT EXISTS idx_drug_ingredients_name_trgm ON drug_ingredients USING gin (name gin_trgm_ops)`,
+	`CREATE INDEX IF NOT EXISTS idx_drug_ingredients_chosung_trgm ON drug_ingredients USING gin (chosung(name) gin_trgm_ops)`,
+}
+
+// 검색 함수와 인덱스 생성 - pg_trgm 을 못 쓰면(권한 등) 오타 검색 없이 부분/초성 일치만 사용
+func SetupSearch(db *gorm.DB) {
+	for _, v := range searchSetupStatements {
+		if err := db.Exec(v).Error; err != nil {
+			log.Println("search setup error:", err)
+		}
+	}
+}
+
+// DB 에서 쓸 수 있는 검색 기능
+type searchFeatures struct {
+	chosung bool // chosung() 함수
+	trigram bool // pg_trgm 오타 검색
+}
+
+func detectSearchFeatures(db *gorm.DB) searchFeatures {
+	var features searchFeatures
+	var count int64
+	if err := db.Raw("SELECT COUNT(*) FROM pg_proc WHERE proname = 'chosung'").Scan(&count).Error; err == nil {
+		features.chosung = count > 0
+	}
+	if err := db.Raw("SELECT COUNT(*) FROM pg_extension WHERE extname = 'pg_trgm'").Scan(&count).Error; err == nil {
+		features.trigram = count > 0
+	}
+	return features
+}
+
+// 한글 음절을 초성으로 바꿈 (레보도파 -> ㄹㅂㄷㅍ), 나머지 글자는 그대로
+func toChosung(input string) string {
+	var b strings.Builder
+	for _, r := range input {
+		if r >= hangulBase && r <= hangulLast {
+			b.WriteRune(choseong[(r-hangulBase)/hangulInitialSpan])
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// 초성(ㄱ~ㅎ)이 섞인 검색어인지
+func hasChosung(input string) bool {
+	for _, r := range input {
+		if r >= 'ㄱ' && r <= 'ㅎ' {
+			return true
+		}
+	}
+	return false
+}
+
+func escapeLike(input string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(input)
+}
+
+// 검색어 조건 - 부분 일치, 초성 일치(검색어에 초성이 있을 때), 오타(pg_trgm 단어 유사도)
+type searchQuery struct {
+	keyword  string
+	pattern  string
+	chosung  string
+	features searchFeatures
+}
+
+func newSearchQuery(keyword string, features searchFeatures) searchQuery {
+	query := searchQuery{keyword: strings.TrimSpace(keyword), features: features}
+	query.pattern = escapeLike(query.keyword)
+	if features.chosung && hasChosung(query.keyword) {
+		query.chosung = escapeLike(toChosung(query.keyword))
+	}
+	return query
+}
+
+func (q searchQuery) where(column string) (string, []interface{}) {
+	conditions := []string{column + " ILIKE ?"}
+	args := []interface{}{"%" + q.pattern + "%"}
+	if q.chosung != "" {
+		conditions = append(conditions, "chosung("+column+") LIKE ?")
+		args = append(args, "%"+q.chosung+"%")
+	}
+	if q.features.trigram {
+		conditions = append(conditions, "? <% "+column)
+		args = append(args, q.keyword)
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")", args
+}
+
+// 순위: 완전 일치 > 앞부분 일치 > 부분 일치 > 초성 앞부분 > 초성 부분 > 오타 (같은 순위는 유사도, 짧은 이름 순)
+func (q searchQuery) order(column string) clause.OrderBy {
+	chosungPrefix, chosungContains := "", ""
+	if q.chosung != "" {
+		chosungPrefix = " WHEN chosung(" + column + ") LIKE ? THEN 3"
+		chosungContains = " WHEN chosung(" + column + ") LIKE ? THEN 4"
+	}
+	sql := "CASE WHEN lower(" + column + ") = lower(?) THEN 0 WHEN " + column + " ILIKE ? THEN 1 WHEN " + column + " ILIKE ? THEN 2" +
+		chosungPrefix + chosungContains + " ELSE 5 END"
+	vars := []interface{}{q.keyword, q.pattern + "%", "%" + q.pattern + "%"}
+	if q.chosung != "" {
+		vars = append(vars, q.chosung+"%", "%"+q.chosung+"%")
+	}
+	if q.features.trigram {
+		sql += ", word_similarity(?, " + column + ") DESC"
+		vars = append(vars, q.keyword)
+	}
+	sql += ", length(" + column + "), " + column
+	return clause.OrderBy{Expression: clause.Expr{SQL: sql, Vars: vars, WithoutParentheses: true}}
+}
+
+func searchPage(params dto.SearchParams) (int, int) {
+	size := params.Size
+	if size <= 0 {
+		size = searchDefaultSize
+	}
+	if size > searchMaxSize {
+		size = searchMaxSize
+	}
+	page := params.Page
+	if page <= 0 {
+		page = 1
+	}
+	return size, (page - 1) * size
+}
+
+// 약 이름 검색 (순위순, 페이지)
+func (service *medicineService) SearchMedicines(params dto.SearchParams) ([]string, error) {
+	names := make([]string, 0)
+	query := newSearchQuery(params.Keyword, service.search)
+	if query.keyword == "" {
+		return names, nil
+	}
+	size, offset := searchPage(params)
+
+	where, args := query.where("name")
+	err := service.db.Model(&model.MedicineSearch{}).Where(where, args...).Clauses(query.order("name")).
+		Limit(size).Offset(offset).Pluck("name", &names).Error
+	if err != nil {
+		return nil, util.NewError(util.ErrDatabase, "db error")
+	}
+	return names, nil
+}
+
+// 의약품 검색 - 제품명 또는 성분명 (성분명으로만 찾은 제품은 뒤로)
+func (service *medicineService) SearchDrugs(params dto.SearchParams) ([]dto.DrugProductResponse, error) {
+	query := newSearchQuery(params.Keyword, service.search)
+	if query.keyword == "" {
+		return make([]dto.DrugProductResponse, 0), nil
+	}
+	size, offset := searchPage(params)
+
+	where, args := query.where("drug_products.name")
+	ingredientWhere, ingredientArgs := query.where("drug_ingredients.name")
+	var products []model.DrugProduct
+	err := service.db.Where(where+" OR EXISTS (SELECT 1 FROM drug_ingredients WHERE drug_ingredients.drug_product_id = drug_products.id AND "+ingredientWhere+")",
+		append(args, ingredientArgs...)...).Clauses(query.order("drug_products.name")).Limit(size).Offset(offset).Find(&products).Error
+	if err != nil {
+		return nil, util.NewError(util.ErrDatabase, "db error")
+	}
+	return drugProductResponses(service.db, products)
+}
@@ -0,0 +1,41 @@
+// /medicine-service/service/search_test.go
+package service
+
+import "testing"
+
+func TestToChosung(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"레보도파", "ㄹㅂㄷㅍ"},
+		{"가힣", "ㄱㅎ"},
+		{"까치", "ㄲㅊ"},
+		{"마도파 125mg", "ㅁㄷㅍ 125mg"},
+		{"ㄹㅂ도파", "ㄹㅂㄷㅍ"},
+		{"Sinemet", "Sinemet"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := toChosung(tt.input); got != tt.want {
+			t.Errorf("toChosung(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestHasChosung(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"ㄹㅂㄷㅍ", true},
+		{"레보ㄷㅍ", true},
+		{"레보도파", false},
+		{"levodopa", false},
+	}
+	for _, tt := range tests {
+		if got := hasChosung(tt.input); got != tt.want {
+			t.Errorf("hasChosung(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
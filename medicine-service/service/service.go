@@ -2,46 +2,50 @@
 package service
 
 import (
-	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
-	"log"
-	"medicine-service/common/model"
-	"medicine-service/common/util"
 	"medicine-service/dto"
-	pb "medicine-service/proto"
 	"reflect"
 	"time"
 
-	"google.golang.org/grpc"
+	"github.com/disterbia/wellkinson/common/model"
+	"github.com/disterbia/wellkinson/common/util"
 	"gorm.io/gorm"
 )
 
 type MedicineService interface {
-	SaveMedicine(medicineRequest dto.MedicineRequest) (string, error)
+	SaveMedicine(medicineRequest dto.MedicineRequest) (dto.SaveMedicineResponse, error)
 	RemoveMedicines(ids []uint, uid uint) (string, error)
 	GetTakens(id uint, startDateStr, endDateStr string) ([]dto.MedicineDateInfo, error)
 	GetMedicines(id uint) ([]dto.MedicineOriginResponse, error)
 	TakeMedicine(takeMedicine dto.TakeMedicine) (string, error)
 	UnTakeMedicine(takeMedicine dto.UnTakeMedicine) (string, error)
-	SearchMedicines(keyword string) ([]string, error)
+	SearchMedicines(params dto.SearchParams) ([]string, error)
+	RefillMedicine(refillRequest dto.RefillRequest) (string, error)
+	GetRefillForecasts(uid uint) ([]dto.RefillForecastResponse, error)
+	CheckRefills()
+	SearchDrugs(params dto.SearchParams) ([]dto.DrugProductResponse, error)
+	CheckDrugWarnings(uid, drugProductId uint) ([]dto.DrugWarning, error)
+	GetAdherence(uid uint, startDateStr, endDateStr string) (dto.AdherenceResponse, error)
 }
 
 type medicineService struct {
-	db          *gorm.DB
-	alarmClient pb.AlarmServiceClient
+	db     *gorm.DB
+	search searchFeatures
 }
 
-func NewMedicineService(db *gorm.DB, conn *grpc.ClientConn) MedicineService {
-	alarmClient := pb.NewAlarmServiceClient(conn)
-	return &medicineService{db: db, alarmClient: alarmClient}
+// 알람은 outbox 에 저장하고 AlarmRelay 가 alarm-service 로 전달
+func NewMedicineService(db *gorm.DB) MedicineService {
+	return &medicineService{db: db, search: detectSearchFeatures(db)}
 
 }
 
-func (service *medicineService) SaveMedicine(medicineRequest dto.MedicineRequest) (string, error) {
+func (service *medicineService) SaveMedicine(medicineRequest dto.MedicineRequest) (dto.SaveMedicineResponse, error) {
 	if err := validateMedicine(medicineRequest); err != nil {
-		return "", err
+		return dto.SaveMedicineResponse{}, err
+	}
+	if err := validateDrugProduct(service.db, medicineRequest.DrugProductId); err != nil {
+		return dto.SaveMedicineResponse{}, err
 	}
 
 	var medicine model.Medicine
@@ -49,24 +53,17 @@ func (service *medicineService) SaveMedicine(medicineRequest dto.MedicineRequest
 	result := service.db.Where("id=? AND uid=?", medicineRequest.Id, medicineRequest.Uid).First(&model.Medicine{})
 
 	if err := util.CopyStruct(medicineRequest, &medicine); err != nil {
-		return "", err
+		return dto.SaveMedicineResponse{}, err
 	}
 
 	medicine.Uid = medicineRequest.Uid //  json: "-" 이라서
 
-	newWeekdays, unique, err := validateWeek(medicine)
+	newWeekdays, _, err := validateWeek(medicine)
 	if err != nil {
-		return "", err
+		return dto.SaveMedicineResponse{}, err
 	}
 	medicine.Weekdays = newWeekdays
-	bodyMessage := "약 먹을 시간입니다. 드시고 나면 잊지 말고 표시해주세요."
-
-	mar := &pb.MultiAlarmRequest{}
-	var ars []*pb.AlarmRequest
 
-	if medicine.UsePrivacy {
-		bodyMessage = medicine.Name + " " + fmt.Sprintf("%v", medicine.Dose) + " " + medicine.MedicineType + " 먹을 시간입니다. 드시고 나면 잊지 말고 표시해주세요."
-	}
 	if medicine.IntervalType == 1 {
 		medicine.Timestamp = json.RawMessage("[]")
 		medicine.Weekdays = json.RawMessage("[]")
@@ -75,141 +72,101 @@ func (service *medicineService) SaveMedicine(medicineRequest dto.MedicineRequest
 		// 레코드가 존재하지 않으면 새 레코드 생성
 		medicine.Id = 0
 		medicine.IsActive = true
-		if err := service.db.Create(&medicine).Error; err != nil {
-			return "", err
-		}
-
-		if medicine.IntervalType != 1 {
-
-			for _, v := range medicineRequest.Timestamp {
-				ar := &pb.AlarmRequest{
-					ParentId:  int32(medicine.Id),
-					Uid:       int32(medicine.Uid),
-					Body:      bodyMessage,
-					Type:      int32(util.MedicineType),
-					StartAt:   medicine.StartAt,
-					EndAt:     medicine.EndAt,
-					Timestamp: v,
-					Week:      unique,
-				}
-				ars = append(ars, ar)
+		err := service.db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Create(&medicine).Error; err != nil {
+				return err
 			}
-
-			mar.AlarmRequests = ars
-
-			go sendAlarm(service, mar)
+			// 복용 일정(요일/N시간/N일/주기)과 기간별 용량에 맞춰 알람 생성
+			return enqueueMedicineAlarms(tx, medicine)
+		})
+		if err != nil {
+			return dto.SaveMedicineResponse{}, err
 		}
 	} else if result.Error != nil {
-		return "", errors.New("db error")
+		return dto.SaveMedicineResponse{}, util.NewError(util.ErrDatabase, "db error")
 	} else {
-		if medicine.IntervalType == 1 {
-			medicineRequest.Timestamp = make([]string, 0)
-			medicineRequest.Weekdays = make([]uint, 0)
-		}
-		updateFields := make(map[string]interface{})
+		updateFields := scheduleFields(medicine)
 
 		userRequestValue := reflect.ValueOf(medicineRequest)
 		userRequestType := userRequestValue.Type()
 		for i := 0; i < userRequestValue.NumField(); i++ {
 			field := userRequestValue.Field(i)
 			fieldName := userRequestType.Field(i).Tag.Get("json")
-			if fieldName == "-" {
+			if _, ok := updateFields[fieldName]; ok || fieldName == "-" {
 				continue
 			}
 			if !field.IsZero() {
-				if fieldName == "weekdays" || fieldName == "timestamp" {
-					// weekdays 필드를 JSON 형식으로 변환
-					updateFields[fieldName], _ = json.Marshal(field.Interface())
-				} else {
-					updateFields[fieldName] = field.Interface()
-				}
+				updateFields[fieldName] = field.Interface()
 			}
 		}
-		// 레코드가 존재하면 업데이트
-		if err := service.db.Model(&medicine).Updates(updateFields).Error; err != nil {
-			return "", err
+		// 재고를 바꾸면 재고 부족 알림 다시 확인
+		if _, ok := updateFields["store"]; ok {
+			updateFields["refill_notified_at"] = ""
 		}
-
-		if medicine.IntervalType != 1 {
-			for _, v := range medicineRequest.Timestamp {
-				ar := &pb.AlarmRequest{
-					ParentId:  int32(medicine.Id),
-					Uid:       int32(medicine.Uid),
-					Body:      bodyMessage,
-					Type:      int32(util.MedicineType),
-					StartAt:   medicine.StartAt,
-					EndAt:     medicine.EndAt,
-					Timestamp: v,
-					Week:      unique,
-				}
-				ars = append(ars, ar)
+		// 레코드가 존재하면 업데이트
+		err := service.db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Model(&medicine).Updates(updateFields).Error; err != nil {
+				return err
 			}
-
-			mar.AlarmRequests = ars
-			if medicine.IsActive {
-				go updateAlarm(service, mar)
-			} else {
-				b := make([]int32, 1)
-
-				b[0] = int32(medicine.Id)
-
-				arr := &pb.AlarmRemoveRequest{
-					ParentIds: b,
-					Uid:       int32(medicine.Uid),
-					Type:      int32(util.MedicineType),
-				}
-				go removeAlarm(service, arr)
+			// 요청에 없는 필드는 기존 값이므로 저장된 기록으로 알람 요청
+			var saved model.Medicine
+			if err := tx.Where("id = ?", medicine.Id).First(&saved).Error; err != nil {
+				return err
 			}
+			return enqueueMedicineAlarms(tx, saved)
+		})
+		if err != nil {
+			return dto.SaveMedicineResponse{}, err
 		}
-
 	}
 
-	return "200", nil
+	// 복용중인 다른 약과 성분이 겹치거나 상호작용이 있으면 경고
+	warnings := saveWarnings(service.db, medicine.Uid, medicineRequest.DrugProductId, medicine.Id)
+	return dto.SaveMedicineResponse{Code: "200", Warnings: warnings}, nil
 }
 
 func (service *medicineService) RemoveMedicines(ids []uint, uid uint) (string, error) {
-	result := service.db.Model(&model.Medicine{}).Where("id IN (?) AND uid= ?", ids, uid).Select("is_delete").Updates(map[string]interface{}{"is_delete": true})
-	if result.Error != nil {
-		return "", errors.New("db error")
-	}
-
-	b := make([]int32, len(ids))
-
-	for i, v := range ids {
-		b[i] = int32(v)
-	}
-
-	arr := &pb.AlarmRemoveRequest{
-		ParentIds: b,
-		Uid:       int32(uid),
-		Type:      int32(util.MedicineType),
+	err := service.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Medicine{}).Where("id IN (?) AND uid= ?", ids, uid).Select("is_delete").Updates(map[string]interface{}{"is_delete": true}).Error; err != nil {
+			return err
+		}
+		return enqueueRemoveAlarms(tx, ids, uid, util.MedicineType)
+	})
+	if err != nil {
+		return "", util.NewError(util.ErrDatabase, "db error")
 	}
-
-	go removeAlarm(service, arr)
 	return "200", nil
 }
 
 func (service *medicineService) GetTakens(id uint, startDateStr, endDateStr string) ([]dto.MedicineDateInfo, error) {
 
-	// 문자열을 time.Time 타입으로 변환
-	startDate, err := time.Parse("2006-01-02", startDateStr)
-	if err != nil {
-		return nil, err
+	// 날짜가 없으면 유저 시간대 기준 오늘
+	if startDateStr == "" {
+		startDateStr = userToday(service.db, id)
+	}
+	if endDateStr == "" {
+		endDateStr = startDateStr
 	}
-	endDate, err := time.Parse("2006-01-02", endDateStr)
 
-	if err != nil {
+	if err := util.ValidateDate(startDateStr); err != nil {
+		return nil, err
+	}
+	if err := util.ValidateDate(endDateStr); err != nil {
 		return nil, err
 	}
 
+	// 문자열을 time.Time 타입으로 변환
+	startDate, _ := time.Parse("2006-01-02", startDateStr)
+	endDate, _ := time.Parse("2006-01-02", endDateStr)
+
 	var medicines []model.Medicine
 	var medicineTemp []dto.MedicineOriginResponse
 	var medicineBridge []dto.MedicinBridge
 	var medicineResponses []dto.MedicineResponse
-	err = service.db.Debug().Where("uid = ? AND (start_at ='' OR start_at <= ?) AND (end_at ='' OR end_at >= ?)",
+	err := service.db.Debug().Where("uid = ? AND (start_at ='' OR start_at <= ?) AND (end_at ='' OR end_at >= ?)",
 		id, endDate.Format("2006-01-02"), startDate.Format("2006-01-02")).Find(&medicines).Error
 	if err != nil {
-		return nil, errors.New("db error")
+		return nil, util.NewError(util.ErrDatabase, "db error")
 	}
 
 	if err := util.CopyStruct(medicines, &medicineTemp); err != nil {
@@ -250,6 +207,24 @@ func (service *medicineService) GetTakens(id uint, startDateStr, endDateStr stri
 		takenMap[tm.MedicineId][tm.DateTaken][tm.TimeTaken][tm.RealTaken] = tm.Dose
 	}
 
+	// 복용 누락 기록 (누락 후 늦게 복용한 시각은 제외)
+	var escalations []model.DoseEscalation
+	err = service.db.Where("medicine_id IN (?) AND missed = true AND date_taken BETWEEN ? AND ?",
+		medicineIds, startDate.Format("2006-01-02"), endDate.Format("2006-01-02")).Order("time_taken").Find(&escalations).Error
+	if err != nil {
+		return nil, err
+	}
+	missedMap := make(map[uint]map[string][]string)
+	for _, e := range escalations {
+		if len(takenMap[e.MedicineId][e.DateTaken][e.TimeTaken]) > 0 {
+			continue
+		}
+		if missedMap[e.MedicineId] == nil {
+			missedMap[e.MedicineId] = make(map[string][]string)
+		}
+		missedMap[e.MedicineId][e.DateTaken] = append(missedMap[e.MedicineId][e.DateTaken], e.TimeTaken)
+	}
+
 	// 전체날짜에서 약물 복용날짜 체크
 	medicineDates := make([]dto.MedicineDateInfo, 0)
 	for d := startDate; !d.After(endDate); d = d.AddDate(0, 0, 1) {
@@ -267,6 +242,8 @@ func (service *medicineService) GetTakens(id uint, startDateStr, endDateStr stri
 			}
 
 			var a = make(map[string]map[string]float32)
+			// 복용 일정(요일/N시간/N일/주기)상 이 날의 복용 시각
+			times := doseTimes(m, d)
 
 			// m.Timestamp가 비어있을 경우, takenMap에서 해당 날짜의 모든 시간에 대한 데이터를 가져옴
 
@@ -274,18 +251,20 @@ func (service *medicineService) GetTakens(id uint, startDateStr, endDateStr stri
 				if takenTime != "" {
 					taken := takenMap[m.Id][d.Format("2006-01-02")][takenTime]
 					a[takenTime] = taken
-					if !d.Before(startAt) && d.Before(endAt.AddDate(0, 0, 1)) && !(isMedicineDay(m.Weekdays, d.Weekday())) {
+					if !d.Before(startAt) && d.Before(endAt.AddDate(0, 0, 1)) && len(times) == 0 {
 						tempMedicineResponse := medicineResponses[i]
 						tempMedicineResponse.Timestamp = a
+						tempMedicineResponse.Dose = doseOn(m, d)
+						tempMedicineResponse.Missed = missedMap[m.Id][d.Format("2006-01-02")]
 						dayMedicineResponses = append(dayMedicineResponses, tempMedicineResponse)
 					}
 				}
 			}
 
-			if !d.Before(startAt) && d.Before(endAt.AddDate(0, 0, 1)) && (isMedicineDay(m.Weekdays, d.Weekday())) {
+			if !d.Before(startAt) && d.Before(endAt.AddDate(0, 0, 1)) && len(times) > 0 {
 				// var a = make(map[string]string)
 
-				for _, v := range m.Timestamp {
+				for _, v := range times {
 
 					taken := takenMap[m.Id][d.Format("2006-01-02")][v]
 					a[v] = taken
@@ -294,6 +273,8 @@ func (service *medicineService) GetTakens(id uint, startDateStr, endDateStr stri
 				}
 				tempMedicineResponse := medicineResponses[i]
 				tempMedicineResponse.Timestamp = a
+				tempMedicineResponse.Dose = doseOn(m, d)
+				tempMedicineResponse.Missed = missedMap[m.Id][d.Format("2006-01-02")]
 				dayMedicineResponses = append(dayMedicineResponses, tempMedicineResponse)
 			}
 		}
@@ -311,7 +292,7 @@ func (service *medicineService) GetMedicines(id uint) ([]dto.MedicineOriginRespo
 
 	err := service.db.Where("uid = ? AND is_delete = false ", id).Find(&medicines).Error
 	if err != nil {
-		return nil, errors.New("db error")
+		return nil, util.NewError(util.ErrDatabase, "db error")
 	}
 	if err := util.CopyStruct(medicines, &medicineTemp); err != nil {
 		return nil, err
@@ -346,17 +327,17 @@ func (service *medicineService) TakeMedicine(takeMedicine dto.TakeMedicine) (str
 
 		err := tx.Where("id = ? AND uid = ?", takeMedicine.MedicineId, takeMedicine.Uid).First(&medicine).Error
 		if err != nil {
-			return "", errors.New("db error2")
+			return "", util.NewError(util.ErrDatabase, "db error2")
 		} else {
 			store := medicine.Store
 			dose := takeMedicine.Dose
 			if medicine.UseLeastStore {
 				if store < dose {
-					return "", errors.New("over dose")
+					return "", util.NewError(util.ErrInvalidRequest, "over dose")
 				} else {
 					err := tx.Model(&medicine).Select("store").Updates(map[string]interface{}{"store": store - dose}).Error
 					if err != nil {
-						return "", errors.New("db error3")
+						return "", util.NewError(util.ErrDatabase, "db error3")
 					}
 				}
 			}
@@ -364,13 +345,13 @@ func (service *medicineService) TakeMedicine(takeMedicine dto.TakeMedicine) (str
 
 		if err := tx.Create(&medicineTake).Error; err != nil {
 			tx.Rollback()
-			return "", errors.New("db error")
+			return "", util.NewError(util.ErrDatabase, "db error")
 		}
 
 	} else {
 		err := tx.Where("id = ? AND uid = ?", takeMedicine.MedicineId, takeMedicine.Uid).First(&medicine).Error
 		if err != nil {
-			return "", errors.New("db error2")
+			return "", util.NewError(util.ErrDatabase, "db error2")
 		}
 		if medicine.IntervalType == 1 {
 			takeMedicine.RealTaken = takeMedicine.TimeTaken
@@ -378,7 +359,7 @@ func (service *medicineService) TakeMedicine(takeMedicine dto.TakeMedicine) (str
 		err2 := tx.Model(&medicineTake).UpdateColumn("real_taken", takeMedicine.RealTaken).Error
 		if err2 != nil {
 			tx.Rollback()
-			return "", errors.New("db error3")
+			return "", util.NewError(util.ErrDatabase, "db error3")
 		}
 
 	}
@@ -402,7 +383,7 @@ func (service *medicineService) UnTakeMedicine(unTakeMedicine dto.UnTakeMedicine
 	result := service.db.Where("medicine_id = ? AND uid=? AND date_taken = ? AND time_taken = ?",
 		unTakeMedicine.MedicineId, unTakeMedicine.Uid, unTakeMedicine.DateTaken, unTakeMedicine.TimeTaken).First(&medicineTake)
 	if result.Error != nil {
-		return "", errors.New("db error2")
+		return "", util.NewError(util.ErrDatabase, "db error2")
 	}
 	tx := service.db.Begin()
 
@@ -410,57 +391,15 @@ func (service *medicineService) UnTakeMedicine(unTakeMedicine dto.UnTakeMedicine
 		unTakeMedicine.MedicineId, unTakeMedicine.Uid, unTakeMedicine.DateTaken, unTakeMedicine.TimeTaken).Delete(&model.MedicineTake{})
 	if result2.Error != nil {
 		tx.Rollback()
-		return "", errors.New("db error2")
+		return "", util.NewError(util.ErrDatabase, "db error2")
 	}
 
 	err := tx.Model(&medicine).Where("id = ? AND uid = ?", medicineTake.MedicineId, medicineTake.Uid).UpdateColumn("store", gorm.Expr("store + ?", medicineTake.Dose)).Error
 	if err != nil {
 		tx.Rollback()
-		return "", errors.New("db error3")
+		return "", util.NewError(util.ErrDatabase, "db error3")
 	}
 	tx.Commit()
 
 	return "200", nil
 }
-
-func (service *medicineService) SearchMedicines(keyword string) ([]string, error) {
-	var names []string
-	err := service.db.Model(&model.MedicineSearch{}).Where("name LIKE ?", "%"+keyword+"%").Pluck("name", &names).Error
-	if err != nil {
-		return nil, errors.New("db error")
-	}
-	return names, nil
-}
-
-func isMedicineDay(weekdays []uint, day time.Weekday) bool {
-	for _, d := range weekdays {
-		if uint(day) == d {
-			return true
-		}
-	}
-	return false
-}
-
-func sendAlarm(service *medicineService, mar *pb.MultiAlarmRequest) {
-	reponse, err := service.alarmClient.MultiSetAlarm(context.Background(), mar)
-	if err != nil {
-		log.Printf("Failed to set Alarm: %v", err)
-	}
-	log.Printf("set Alarm: %v", reponse)
-}
-
-func removeAlarm(service *medicineService, arr *pb.AlarmRemoveRequest) {
-	reponse, err := service.alarmClient.RemoveAlarm(context.Background(), arr)
-	if err != nil {
-		log.Printf("Failed to remove Alarm: %v", err)
-	}
-	log.Printf("remove Alarm: %v", reponse)
-}
-
-func updateAlarm(service *medicineService, mar *pb.MultiAlarmRequest) {
-	reponse, err := service.alarmClient.MultiUpdateAlarm(context.Background(), mar)
-	if err != nil {
-		log.Printf("Failed to update Alarm: %v", err)
-	}
-	log.Printf("update Alarm: %v", reponse)
-}
@@ -0,0 +1,29 @@
+// /medicine-service/service/service_test.go
+package service
+
+import (
+	"testing"
+
+	"github.com/disterbia/wellkinson/common/util"
+)
+
+func TestGetTakensInvalidDate(t *testing.T) {
+	tests := []struct {
+		name      string
+		startDate string
+		endDate   string
+	}{
+		{"bad start_date", "2024-13-01", "2024-05-01"},
+		{"bad end_date", "2024-05-01", "05/02/2024"},
+		{"start only", "2024/05/01", ""},
+	}
+	service := &medicineService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.GetTakens(1, tt.startDate, tt.endDate)
+			if code := util.ToAppError(err).Code; err == nil || code != util.ErrInvalidRequest {
+				t.Errorf("GetTakens() = %v, want %s", err, util.ErrInvalidRequest)
+			}
+		})
+	}
+}
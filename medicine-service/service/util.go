@@ -3,14 +3,31 @@ package service
 
 import (
 	"encoding/json"
-	"errors"
-	"medicine-service/common/model"
-	"medicine-service/common/util"
+	"fmt"
 	"medicine-service/dto"
+	pb "medicine-service/proto"
+	"sort"
+	"time"
+
+	"github.com/disterbia/wellkinson/common/model"
+	"github.com/disterbia/wellkinson/common/util"
+	"gorm.io/gorm"
+)
+
+// 복용 일정 (interval_type)
+const (
+	intervalWeekly   = 0 // 요일 + 시각
+	intervalAsNeeded = 1 // 필요시 복용 (알람 없음)
+	intervalHours    = 2 // start_at 의 timestamp 부터 interval 시간마다
+	intervalDays     = 3 // start_at 부터 interval 일마다
+	intervalCycle    = 4 // start_at 부터 interval 일 복용, off_days 일 휴약 반복
 )
 
 func validateMedicine(medicine dto.MedicineRequest) error {
-	if medicine.IntervalType != 1 {
+	if medicine.IntervalType > intervalCycle {
+		return util.NewError(util.ErrInvalidRequest, "invalid interval_type")
+	}
+	if medicine.IntervalType != intervalAsNeeded {
 		if medicine.StartAt != "" {
 			if err := util.ValidateDate(medicine.StartAt); err != nil {
 				return err
@@ -29,14 +46,198 @@ func validateMedicine(medicine dto.MedicineRequest) error {
 				}
 			}
 		} else {
-			return errors.New(("invalid time format, should be HH:MM"))
+			return util.NewError(util.ErrInvalidRequest, "check timestamp")
+		}
+		if err := validateInterval(medicine); err != nil {
+			return err
+		}
+		if err := validateTapers(medicine.Tapers); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateInterval(medicine dto.MedicineRequest) error {
+	if medicine.IntervalType == intervalWeekly {
+		return nil
+	}
+	// 반복 기준일
+	if medicine.StartAt == "" {
+		return util.NewError(util.ErrInvalidRequest, "check start_at")
+	}
+	switch medicine.IntervalType {
+	case intervalHours:
+		if len(medicine.Timestamp) != 1 {
+			return util.NewError(util.ErrInvalidRequest, "check timestamp")
+		}
+		if medicine.Interval == 0 || medicine.Interval > 72 {
+			return util.NewError(util.ErrInvalidRequest, "check interval")
+		}
+	case intervalDays:
+		if medicine.Interval == 0 || medicine.Interval > 365 {
+			return util.NewError(util.ErrInvalidRequest, "check interval")
+		}
+	case intervalCycle:
+		if medicine.Interval == 0 || medicine.Interval > 365 || medicine.OffDays == 0 || medicine.OffDays > 365 {
+			return util.NewError(util.ErrInvalidRequest, "check interval,off_days")
+		}
+	}
+	return nil
+}
+
+// 기간별 용량은 기간이 겹치지 않아야 함
+func validateTapers(tapers []dto.Taper) error {
+	sorted := sortTapers(tapers)
+	for i, v := range sorted {
+		if err := util.ValidateDate(v.StartAt); err != nil {
+			return err
+		}
+		if err := util.ValidateDate(v.EndAt); err != nil {
+			return err
+		}
+		if v.StartAt > v.EndAt || v.Dose <= 0 {
+			return util.NewError(util.ErrInvalidRequest, "check tapers")
+		}
+		if i > 0 && v.StartAt <= sorted[i-1].EndAt {
+			return util.NewError(util.ErrInvalidRequest, "check tapers")
 		}
 	}
 	return nil
 }
 
+func sortTapers(tapers []dto.Taper) []dto.Taper {
+	sorted := append([]dto.Taper(nil), tapers...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].StartAt < sorted[j].StartAt })
+	return sorted
+}
+
+// 약 기간을 기간별 용량으로 나눔 (기간별 용량이 없는 날은 기본 용량, "" 는 무기한)
+func doseSegments(startAt, endAt string, dose float32, tapers []dto.Taper) []dto.Taper {
+	var segments []dto.Taper
+	cursor := startAt
+	for _, v := range sortTapers(tapers) {
+		if endAt != "" && v.StartAt > endAt {
+			break
+		}
+		if cursor != "" && v.EndAt < cursor {
+			continue
+		}
+		if v.StartAt > cursor {
+			segments = append(segments, dto.Taper{StartAt: cursor, EndAt: addDays(v.StartAt, -1), Dose: dose})
+			cursor = v.StartAt
+		}
+		segmentEnd := v.EndAt
+		if endAt != "" && endAt < segmentEnd {
+			segmentEnd = endAt
+		}
+		segments = append(segments, dto.Taper{StartAt: cursor, EndAt: segmentEnd, Dose: v.Dose})
+		cursor = addDays(v.EndAt, 1)
+		if endAt != "" && cursor > endAt {
+			return segments
+		}
+	}
+	return append(segments, dto.Taper{StartAt: cursor, EndAt: endAt, Dose: dose})
+}
+
+// 복용 일정 필드 - 0 (요일 반복, 간격 없음) 이나 빈 목록도 유효한 값이므로 수정시 항상 함께 저장
+func scheduleFields(medicine model.Medicine) map[string]interface{} {
+	emptyIfNull := func(v json.RawMessage) json.RawMessage {
+		if len(v) == 0 || string(v) == "null" {
+			return json.RawMessage("[]")
+		}
+		return v
+	}
+	return map[string]interface{}{
+		"interval_type": medicine.IntervalType,
+		"interval":      medicine.Interval,
+		"off_days":      medicine.OffDays,
+		"weekdays":      emptyIfNull(medicine.Weekdays),
+		"timestamp":     emptyIfNull(medicine.Timestamp),
+		"tapers":        emptyIfNull(medicine.Tapers),
+	}
+}
+
+func addDays(date string, days int) string {
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return date
+	}
+	return t.AddDate(0, 0, days).Format("2006-01-02")
+}
+
+func medicineRepeat(intervalType uint8, interval, offDays uint, startAt string) util.Repeat {
+	return util.Repeat{Type: uint(intervalType), Every: interval, Off: offDays, Anchor: startAt}
+}
+
+// 해당 날짜의 복용 시각 (복용일이 아니면 nil) - 기간 확인은 호출하는 쪽에서
+func doseTimes(medicine dto.MedicineOriginResponse, date time.Time) []string {
+	repeat := medicineRepeat(medicine.IntervalType, medicine.Interval, medicine.OffDays, medicine.StartAt)
+	switch medicine.IntervalType {
+	case intervalAsNeeded:
+		return nil
+	case intervalHours:
+		if len(medicine.Timestamp) == 0 {
+			return nil
+		}
+		return util.HourlyTimes(date, medicine.Timestamp[0], repeat)
+	}
+	weekdays := make([]int, 0, len(medicine.Weekdays))
+	for _, v := range medicine.Weekdays {
+		weekdays = append(weekdays, int(v))
+	}
+	if !util.IsRepeatDay(date, weekdays, repeat) {
+		return nil
+	}
+	return medicine.Timestamp
+}
+
+// 해당 날짜의 용량
+func doseOn(medicine dto.MedicineOriginResponse, date time.Time) float32 {
+	day := date.Format("2006-01-02")
+	for _, v := range medicine.Tapers {
+		if v.StartAt <= day && day <= v.EndAt {
+			return v.Dose
+		}
+	}
+	return medicine.Dose
+}
+
+// 용량 기간별로 알람 생성 요청 작성
+func buildAlarmRequests(medicine model.Medicine, timestamps []string, week []int32, tapers []dto.Taper) []*pb.AlarmRequest {
+	var ars []*pb.AlarmRequest
+	for _, segment := range doseSegments(medicine.StartAt, medicine.EndAt, medicine.Dose, tapers) {
+		body := alarmBody(medicine, segment.Dose)
+		for _, v := range timestamps {
+			ars = append(ars, &pb.AlarmRequest{
+				ParentId:    int32(medicine.Id),
+				Uid:         int32(medicine.Uid),
+				Body:        body,
+				Type:        int32(util.MedicineType),
+				StartAt:     segment.StartAt,
+				EndAt:       segment.EndAt,
+				Timestamp:   v,
+				Week:        week,
+				RepeatType:  int32(medicine.IntervalType),
+				RepeatEvery: int32(medicine.Interval),
+				RepeatOff:   int32(medicine.OffDays),
+				AnchorAt:    medicine.StartAt,
+			})
+		}
+	}
+	return ars
+}
+
+func alarmBody(medicine model.Medicine, dose float32) string {
+	if medicine.UsePrivacy {
+		return medicine.Name + " " + fmt.Sprintf("%v", dose) + " " + medicine.MedicineType + " 먹을 시간입니다. 드시고 나면 잊지 말고 표시해주세요."
+	}
+	return "약 먹을 시간입니다. 드시고 나면 잊지 말고 표시해주세요."
+}
+
 func validateWeek(medicine model.Medicine) (json.RawMessage, []int32, error) {
-	if medicine.IntervalType == 1 {
+	// 요일 반복이 아니면 요일 없음
+	if medicine.IntervalType != intervalWeekly {
 		return json.RawMessage("[]"), []int32{}, nil
 	}
 	// JSON 배열을 Go 슬라이스로 변환
@@ -44,11 +245,11 @@ func validateWeek(medicine model.Medicine) (json.RawMessage, []int32, error) {
 	var weekdaySlice []int32
 	err := json.Unmarshal(weekdays, &weekdaySlice)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, util.WrapError(util.ErrInvalidRequest, err)
 	}
 
 	if len(weekdaySlice) == 0 {
-		return nil, nil, errors.New("must weekday")
+		return nil, nil, util.NewError(util.ErrInvalidRequest, "must weekday")
 	}
 	seen := make(map[int32]bool)
 	unique := []int32{}
@@ -62,7 +263,7 @@ func validateWeek(medicine model.Medicine) (json.RawMessage, []int32, error) {
 				unique = append(unique, v)
 			}
 		} else {
-			return nil, nil, errors.New("weekday mus 0-6")
+			return nil, nil, util.NewError(util.ErrInvalidRequest, "weekday mus 0-6")
 		}
 	}
 
@@ -72,3 +273,15 @@ func validateWeek(medicine model.Medicine) (json.RawMessage, []int32, error) {
 	}
 	return newWeekdays, unique, nil
 }
+
+// 유저 시간대 - 조회 실패시 기본 시간대
+func userLocation(db *gorm.DB, uid uint) *time.Location {
+	var user model.User
+	db.Select("time_zone").Where("id = ?", uid).First(&user)
+	return util.LoadUserLocation(user.TimeZone)
+}
+
+// 유저 시간대 기준 오늘 날짜
+func userToday(db *gorm.DB, uid uint) string {
+	return time.Now().In(userLocation(db, uid)).Format("2006-01-02")
+}
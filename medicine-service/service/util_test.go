@@ -0,0 +1,105 @@
+// /medicine-service/service/util_test.go
+package service
+
+import (
+	"encoding/json"
+	"medicine-service/dto"
+	"reflect"
+	"testing"
+
+	"github.com/disterbia/wellkinson/common/model"
+	"github.com/disterbia/wellkinson/common/util"
+)
+
+func TestDoseSegments(t *testing.T) {
+	tests := []struct {
+		name   string
+		endAt  string
+		tapers []dto.Taper
+		want   []dto.Taper
+	}{
+		{"no tapers", "2024-05-31", nil, []dto.Taper{{StartAt: "2024-05-01", EndAt: "2024-05-31", Dose: 1}}},
+		{"taper in middle", "2024-05-31", []dto.Taper{{StartAt: "2024-05-10", EndAt: "2024-05-15", Dose: 0.5}},
+			[]dto.Taper{{StartAt: "2024-05-01", EndAt: "2024-05-09", Dose: 1}, {StartAt: "2024-05-10", EndAt: "2024-05-15", Dose: 0.5}, {StartAt: "2024-05-16", EndAt: "2024-05-31", Dose: 1}}},
+		{"taper from start", "2024-05-31", []dto.Taper{{StartAt: "2024-05-01", EndAt: "2024-05-05", Dose: 2}},
+			[]dto.Taper{{StartAt: "2024-05-01", EndAt: "2024-05-05", Dose: 2}, {StartAt: "2024-05-06", EndAt: "2024-05-31", Dose: 1}}},
+		{"taper started before", "2024-05-31", []dto.Taper{{StartAt: "2024-04-25", EndAt: "2024-05-03", Dose: 0.5}},
+			[]dto.Taper{{StartAt: "2024-05-01", EndAt: "2024-05-03", Dose: 0.5}, {StartAt: "2024-05-04", EndAt: "2024-05-31", Dose: 1}}},
+		{"taper past end", "2024-05-31", []dto.Taper{{StartAt: "2024-05-20", EndAt: "2024-06-10", Dose: 0.5}},
+			[]dto.Taper{{StartAt: "2024-05-01", EndAt: "2024-05-19", Dose: 1}, {StartAt: "2024-05-20", EndAt: "2024-05-31", Dose: 0.5}}},
+		{"taper outside period", "2024-05-31", []dto.Taper{{StartAt: "2024-04-01", EndAt: "2024-04-10", Dose: 0.5}, {StartAt: "2024-06-05", EndAt: "2024-06-10", Dose: 0.5}},
+			[]dto.Taper{{StartAt: "2024-05-01", EndAt: "2024-05-31", Dose: 1}}},
+		{"unsorted tapers", "2024-05-31", []dto.Taper{{StartAt: "2024-05-20", EndAt: "2024-05-25", Dose: 0.25}, {StartAt: "2024-05-05", EndAt: "2024-05-10", Dose: 0.5}},
+			[]dto.Taper{{StartAt: "2024-05-01", EndAt: "2024-05-04", Dose: 1}, {StartAt: "2024-05-05", EndAt: "2024-05-10", Dose: 0.5}, {StartAt: "2024-05-11", EndAt: "2024-05-19", Dose: 1},
+				{StartAt: "2024-05-20", EndAt: "2024-05-25", Dose: 0.25}, {StartAt: "2024-05-26", EndAt: "2024-05-31", Dose: 1}}},
+		{"no end date", "", []dto.Taper{{StartAt: "2024-05-10", EndAt: "2024-05-15", Dose: 0.5}},
+			[]dto.Taper{{StartAt: "2024-05-01", EndAt: "2024-05-09", Dose: 1}, {StartAt: "2024-05-10", EndAt: "2024-05-15", Dose: 0.5}, {StartAt: "2024-05-16", EndAt: "", Dose: 1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := doseSegments("2024-05-01", tt.endAt, 1, tt.tapers); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("doseSegments = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScheduleFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		medicine model.Medicine
+		want     map[string]interface{}
+	}{
+		{"weekly without interval", model.Medicine{IntervalType: 0, Weekdays: json.RawMessage("[1,3]"), Timestamp: json.RawMessage(`["09:00"]`)},
+			map[string]interface{}{"interval_type": uint8(0), "interval": uint(0), "off_days": uint(0),
+				"weekdays": json.RawMessage("[1,3]"), "timestamp": json.RawMessage(`["09:00"]`), "tapers": json.RawMessage("[]")}},
+		{"cycle", model.Medicine{IntervalType: 4, Interval: 21, OffDays: 7, Weekdays: json.RawMessage("null"), Timestamp: json.RawMessage(`["21:00"]`), Tapers: json.RawMessage(`[{"start_at":"2024-05-01","end_at":"2024-05-07","dose":0.5}]`)},
+			map[string]interface{}{"interval_type": uint8(4), "interval": uint(21), "off_days": uint(7),
+				"weekdays": json.RawMessage("[]"), "timestamp": json.RawMessage(`["21:00"]`), "tapers": json.RawMessage(`[{"start_at":"2024-05-01","end_at":"2024-05-07","dose":0.5}]`)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scheduleFields(tt.medicine); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("scheduleFields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateMedicineInvalidRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		medicine dto.MedicineRequest
+	}{
+		{"no timestamp", dto.MedicineRequest{Weekdays: []uint{1}}},
+		{"bad time", dto.MedicineRequest{Weekdays: []uint{1}, Timestamp: []string{"25:00"}}},
+		{"bad start_at", dto.MedicineRequest{Weekdays: []uint{1}, Timestamp: []string{"09:00"}, StartAt: "2024-13-01"}},
+		{"unknown interval_type", dto.MedicineRequest{IntervalType: intervalCycle + 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateMedicine(tt.medicine)
+			if code := util.ToAppError(err).Code; err == nil || code != util.ErrInvalidRequest {
+				t.Errorf("validateMedicine() = %v, want %s", err, util.ErrInvalidRequest)
+			}
+		})
+	}
+}
+
+func TestValidateWeekInvalidRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		weekdays json.RawMessage
+	}{
+		{"not json", json.RawMessage("mon")},
+		{"empty", json.RawMessage("[]")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := validateWeek(model.Medicine{Weekdays: tt.weekdays})
+			if code := util.ToAppError(err).Code; err == nil || code != util.ErrInvalidRequest {
+				t.Errorf("validateWeek() = %v, want %s", err, util.ErrInvalidRequest)
+			}
+		})
+	}
+}
@@ -2,11 +2,12 @@
 package transport
 
 import (
-	"medicine-service/common/util"
 	"medicine-service/dto"
 	"net/http"
+	"strconv"
 	"sync"
 
+	"github.com/disterbia/wellkinson/common/util"
 	"github.com/gin-gonic/gin"
 	kitEndpoint "github.com/go-kit/kit/endpoint"
 )
@@ -15,42 +16,45 @@ var userLocks sync.Map
 
 // @Tags 약물 /medicine
 // @Summary 약물 저장
-// @Description 약물등록 및 수정시 호출
+// @Description 약물등록 및 수정시 호출 - interval_type 0:요일+시각 1:필요시 2:start_at 의 timestamp 부터 interval 시간마다 3:start_at 부터 interval 일마다 4:start_at 부터 interval 일 복용, off_days 일 휴약 반복 / tapers: 기간별 용량
+// @Description drug_product_id: 의약품 검색(search-drugs)에서 선택한 약 - 복용중인 다른 약과 성분이 겹치거나 상호작용이 있으면 warnings 반환 (저장은 됨)
 // @Produce  json
 // @Param Authorization header string true "Bearer {jwt_token}"
 // @Param request body dto.MedicineRequest true "요청 DTO - 약물데이터"
-// @Success 200 {object} dto.BasicResponse "성공시 200 반환"
+// @Success 200 {object} dto.SaveMedicineResponse "성공시 200 과 경고 반환"
 // @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
+// @Failure 429 {object} dto.ErrorResponse "TOO_MANY_REQUESTS - 같은 사용자의 요청이 처리중"
 // @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
 // @Router /save-medicine [post]
 func SaveHandler(saveEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		id, _, err := util.VerifyJWT(c)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
 			return
 		}
 
 		// 사용자별 잠금 시작
 		if _, loaded := userLocks.LoadOrStore(id, true); loaded {
-			c.JSON(http.StatusTooManyRequests, gin.H{"error": "Concurrent request detected"})
+			util.AbortError(c, util.NewError(util.ErrTooManyRequests, "concurrent request detected"))
 			return
 		}
 		defer userLocks.Delete(id)
 
 		var req dto.MedicineRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			util.AbortBadRequest(c, err)
 			return
 		}
 		req.Uid = id
 		response, err := saveEndpoint(c.Request.Context(), req)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, err)
 			return
 		}
 
-		resp := response.(dto.BasicResponse)
+		resp := response.(dto.SaveMedicineResponse)
 		c.JSON(http.StatusOK, resp)
 	}
 }
@@ -64,18 +68,19 @@ func SaveHandler(saveEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 // @Param request body []uint true "삭제할 id 배열"
 // @Success 200 {object} dto.BasicResponse "성공시 200 반환"
 // @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
 // @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
 // @Router /remove-medicines [post]
 func RemoveHandler(removeEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		uid, _, err := util.VerifyJWT(c)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
 			return
 		}
 		var ids []uint // 삭제할 ID 배열
 		if err := c.ShouldBindJSON(&ids); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			util.AbortBadRequest(c, err)
 			return
 		}
 		response, err := removeEndpoint(c.Request.Context(), map[string]interface{}{
@@ -83,7 +88,7 @@ func RemoveHandler(removeEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 			"ids": ids,
 		})
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, err)
 			return
 		}
 
@@ -95,26 +100,27 @@ func RemoveHandler(removeEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 
 // @Tags 약물 /medicine
 // @Summary 약물 복용내역 조회
-// @Description 약물 복용내역 조회시 호출
+// @Description 약물 복용내역 조회시 호출 (날짜가 없으면 유저 시간대 기준 오늘) - missed: 알람 후 복용하지 않아 보호자에게 알린 시각
 // @Produce  json
 // @Param Authorization header string true "Bearer {jwt_token}"
-// @Param  start_date  query string  true  "시작날짜 yyyy-mm-dd"
-// @Param  end_date  query string  true  "종료날짜 yyyy-mm-dd"
+// @Param  start_date  query string  false  "시작날짜 yyyy-mm-dd"
+// @Param  end_date  query string  false  "종료날짜 yyyy-mm-dd"
 // @Success 200 {object} []dto.MedicineDateInfo "운동정보"
 // @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
 // @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
 // @Router /get-takens [get]
 func GetTakensHandler(getEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		id, _, err := util.VerifyJWT(c)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
 			return
 		}
 
 		var queryParams dto.GetParams
 		if err := c.ShouldBindQuery(&queryParams); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			util.AbortBadRequest(c, err)
 			return
 		}
 
@@ -123,7 +129,7 @@ func GetTakensHandler(getEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 			"queryParams": queryParams,
 		})
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, err)
 			return
 		}
 
@@ -139,19 +145,20 @@ func GetTakensHandler(getEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 // @Param Authorization header string true "Bearer {jwt_token}"
 // @Success 200 {object} []dto.MedicineOriginResponse "등록 약물 정보"
 // @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
 // @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
 // @Router /get-medicines [get]
 func GetMedicinesHandler(getEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		id, _, err := util.VerifyJWT(c)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
 			return
 		}
 
 		response, err := getEndpoint(c.Request.Context(), id)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, err)
 			return
 		}
 
@@ -169,32 +176,34 @@ func GetMedicinesHandler(getEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 // @Param request body dto.TakeMedicine true "약물 복용 데이터"
 // @Success 200 {object} dto.BasicResponse "성공시 200 반환"
 // @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
+// @Failure 429 {object} dto.ErrorResponse "TOO_MANY_REQUESTS - 같은 사용자의 요청이 처리중"
 // @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
 // @Router /take-medicine [post]
 func TakeHandler(doEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		uid, _, err := util.VerifyJWT(c)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
 			return
 		}
 
 		// 사용자별 잠금 시작
 		if _, loaded := userLocks.LoadOrStore(uid, true); loaded {
-			c.JSON(http.StatusTooManyRequests, gin.H{"error": "Concurrent request detected"})
+			util.AbortError(c, util.NewError(util.ErrTooManyRequests, "concurrent request detected"))
 			return
 		}
 		defer userLocks.Delete(uid)
 
 		var param dto.TakeMedicine
 		if err := c.ShouldBindJSON(&param); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			util.AbortBadRequest(c, err)
 			return
 		}
 		param.Uid = uid
 		response, err := doEndpoint(c.Request.Context(), param)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, err)
 			return
 		}
 
@@ -213,32 +222,34 @@ func TakeHandler(doEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 // @Param request body dto.UnTakeMedicine true "약물 취소 데이터"
 // @Success 200 {object} dto.BasicResponse "성공시 200 반환"
 // @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
+// @Failure 429 {object} dto.ErrorResponse "TOO_MANY_REQUESTS - 같은 사용자의 요청이 처리중"
 // @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
 // @Router /untake-medicine [post]
 func UnTakeHandler(doEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		uid, _, err := util.VerifyJWT(c)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
 			return
 		}
 
 		// 사용자별 잠금 시작
 		if _, loaded := userLocks.LoadOrStore(uid, true); loaded {
-			c.JSON(http.StatusTooManyRequests, gin.H{"error": "Concurrent request detected"})
+			util.AbortError(c, util.NewError(util.ErrTooManyRequests, "concurrent request detected"))
 			return
 		}
 		defer userLocks.Delete(uid)
 
 		var param dto.UnTakeMedicine
 		if err := c.ShouldBindJSON(&param); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			util.AbortBadRequest(c, err)
 			return
 		}
 		param.Uid = uid
 		response, err := doEndpoint(c.Request.Context(), param)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, err)
 			return
 		}
 
@@ -250,9 +261,12 @@ func UnTakeHandler(doEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 
 // @Tags 약물 /medicine
 // @Summary 약물 찾기
-// @Description 약물 검색 키워드 입력시 호출
+// @Description 약물 검색 키워드 입력시 호출 - 부분 일치, 초성(ㄹㅂㄷ -> 레보도파), 오타 검색
+// @Description 순위: 완전 일치 > 앞부분 일치 > 부분 일치 > 초성 일치 > 비슷한 이름
 // @Produce  json
 // @Param  keyword  query string  true  "키워드"
+// @Param  page  query int  false  "페이지 (1부터, 기본 1)"
+// @Param  size  query int  false  "페이지 크기 (기본 20, 최대 50)"
 // @Success 200 {object} []string "약물명"
 // @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
 // @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
@@ -260,11 +274,15 @@ func UnTakeHandler(doEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 func SearchHandler(getEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 	return func(c *gin.Context) {
 
-		keyword := c.Query("keyword")
+		var params dto.SearchParams
+		if err := c.ShouldBindQuery(&params); err != nil {
+			util.AbortBadRequest(c, err)
+			return
+		}
 
-		response, err := getEndpoint(c.Request.Context(), keyword)
+		response, err := getEndpoint(c.Request.Context(), params)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, err)
 			return
 		}
 
@@ -272,3 +290,183 @@ func SearchHandler(getEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 		c.JSON(http.StatusOK, resp)
 	}
 }
+
+// @Tags 약물 /medicine
+// @Summary 약물 보충
+// @Description 약을 보충했을때 호출 - 재고(store)에 amount 만큼 더하고 재고 부족 알림 초기화
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Bearer {jwt_token}"
+// @Param request body dto.RefillRequest true "약물 보충 데이터"
+// @Success 200 {object} dto.BasicResponse "성공시 200 반환"
+// @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
+// @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Router /refill-medicine [post]
+func RefillHandler(refillEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		uid, _, err := util.VerifyJWT(c)
+		if err != nil {
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
+			return
+		}
+
+		var param dto.RefillRequest
+		if err := c.ShouldBindJSON(&param); err != nil {
+			util.AbortBadRequest(c, err)
+			return
+		}
+		param.Uid = uid
+		response, err := refillEndpoint(c.Request.Context(), param)
+		if err != nil {
+			util.AbortError(c, err)
+			return
+		}
+
+		resp := response.(dto.BasicResponse)
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// @Tags 약물 /medicine
+// @Summary 약물 재고 소진 예상
+// @Description 재고 관리(use_least_store) 중인 약물의 소진 예상 조회시 호출
+// @Description 복용 일정과 기간별 용량으로 남은 재고가 떨어지는 날 계산 (1년 안에 떨어지지 않거나 필요시 복용이면 days_left -1)
+// @Description need_refill: 최소 재고 미만이거나 곧 떨어지는 약 (하루 9시~21시 사이 보충 알림 발송, 보충 전까지 3일마다 다시 알림)
+// @Produce  json
+// @Param Authorization header string true "Bearer {jwt_token}"
+// @Success 200 {object} []dto.RefillForecastResponse "약물별 소진 예상"
+// @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
+// @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Router /get-refill-forecasts [get]
+func GetRefillForecastsHandler(getEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, _, err := util.VerifyJWT(c)
+		if err != nil {
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
+			return
+		}
+
+		response, err := getEndpoint(c.Request.Context(), id)
+		if err != nil {
+			util.AbortError(c, err)
+			return
+		}
+
+		resp := response.([]dto.RefillForecastResponse)
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// @Tags 약물 /medicine
+// @Summary 의약품 검색
+// @Description 의약품 목록(식약처 허가정보)에서 제품명, 성분명으로 검색시 호출 - 약물 저장시 drug_product_id 로 연결
+// @Description 약물 찾기와 같이 초성, 오타 검색 (성분명으로만 찾은 제품은 뒤에)
+// @Produce  json
+// @Param  keyword  query string  true  "키워드"
+// @Param  page  query int  false  "페이지 (1부터, 기본 1)"
+// @Param  size  query int  false  "페이지 크기 (기본 20, 최대 50)"
+// @Success 200 {object} []dto.DrugProductResponse "의약품 정보"
+// @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Router /search-drugs [get]
+func SearchDrugsHandler(searchEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		var params dto.SearchParams
+		if err := c.ShouldBindQuery(&params); err != nil {
+			util.AbortBadRequest(c, err)
+			return
+		}
+
+		response, err := searchEndpoint(c.Request.Context(), params)
+		if err != nil {
+			util.AbortError(c, err)
+			return
+		}
+
+		resp := response.([]dto.DrugProductResponse)
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// @Tags 약물 /medicine
+// @Summary 의약품 상호작용 확인
+// @Description 약물 등록 전 복용중인 약과 중복 성분, 상호작용 확인시 호출 - severity 1:주의 2:병용금기
+// @Produce  json
+// @Param Authorization header string true "Bearer {jwt_token}"
+// @Param  drug_product_id  query int  true  "의약품 id"
+// @Success 200 {object} []dto.DrugWarning "경고 목록"
+// @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
+// @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Router /check-drug-warnings [get]
+func CheckDrugWarningsHandler(checkEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		uid, _, err := util.VerifyJWT(c)
+		if err != nil {
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
+			return
+		}
+		drugProductId, err := strconv.ParseUint(c.Query("drug_product_id"), 10, 32)
+		if err != nil || drugProductId == 0 {
+			util.AbortError(c, util.NewError(util.ErrInvalidRequest, "invalid drug_product_id"))
+			return
+		}
+
+		response, err := checkEndpoint(c.Request.Context(), map[string]interface{}{
+			"uid":             uid,
+			"drug_product_id": uint(drugProductId),
+		})
+		if err != nil {
+			util.AbortError(c, err)
+			return
+		}
+
+		resp := response.([]dto.DrugWarning)
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// @Tags 약물 /medicine
+// @Summary 약물 복용률 조회
+// @Description 기간의 약물별/전체 복용률 조회시 호출 (날짜가 없으면 유저 시간대 기준 최근 30일, 최대 366일)
+// @Description 예정 시각이 지난 복용만 계산 (필요시 복용, 중지, 삭제된 약 제외) - on_time: 예정 시각 1시간 안에 복용, late: 1시간 후 복용
+// @Description current_streak/longest_streak: 예정된 약을 모두 복용한 연속 일수 (예정이 없는 날은 건너뜀) / weekly: 월요일 시작 주별, monthly: 월별 합계
+// @Produce  json
+// @Param Authorization header string true "Bearer {jwt_token}"
+// @Param  start_date  query string  false  "시작날짜 yyyy-mm-dd"
+// @Param  end_date  query string  false  "종료날짜 yyyy-mm-dd"
+// @Success 200 {object} dto.AdherenceResponse "복용률"
+// @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
+// @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Router /get-adherence [get]
+func GetAdherenceHandler(getEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, _, err := util.VerifyJWT(c)
+		if err != nil {
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
+			return
+		}
+
+		var queryParams dto.GetParams
+		if err := c.ShouldBindQuery(&queryParams); err != nil {
+			util.AbortBadRequest(c, err)
+			return
+		}
+
+		response, err := getEndpoint(c.Request.Context(), map[string]interface{}{
+			"id":          id,
+			"queryParams": queryParams,
+		})
+		if err != nil {
+			util.AbortError(c, err)
+			return
+		}
+
+		resp := response.(dto.AdherenceResponse)
+		c.JSON(http.StatusOK, resp)
+	}
+}
@@ -233,6 +233,140 @@ func (x *AlarmRemoveRequest) GetType() int32 {
 	return 0
 }
 
+type ReplaceAlarmsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ParentId       int32           `protobuf:"varint,1,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	Type           int32           `protobuf:"varint,2,opt,name=type,proto3" json:"type,omitempty"`
+	Uid            int32           `protobuf:"varint,3,opt,name=uid,proto3" json:"uid,omitempty"`
+	Alarms         []*AlarmRequest `protobuf:"bytes,4,rep,name=alarms,proto3" json:"alarms,omitempty"`
+	IdempotencyKey string          `protobuf:"bytes,5,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+}
+
+func (x *ReplaceAlarmsRequest) Reset() {
+	*x = ReplaceAlarmsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_alarm_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReplaceAlarmsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplaceAlarmsRequest) ProtoMessage() {}
+
+func (x *ReplaceAlarmsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_alarm_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplaceAlarmsRequest.ProtoReflect.Descriptor instead.
+func (*ReplaceAlarmsRequest) Descriptor() ([]byte, []int) {
+	return file_alarm_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ReplaceAlarmsRequest) GetParentId() int32 {
+	if x != nil {
+		return x.ParentId
+	}
+	return 0
+}
+
+func (x *ReplaceAlarmsRequest) GetType() int32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *ReplaceAlarmsRequest) GetUid() int32 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *ReplaceAlarmsRequest) GetAlarms() []*AlarmRequest {
+	if x != nil {
+		return x.Alarms
+	}
+	return nil
+}
+
+func (x *ReplaceAlarmsRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type ReplaceAlarmsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status   string  `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	AlarmIds []int32 `protobuf:"varint,2,rep,packed,name=alarm_ids,json=alarmIds,proto3" json:"alarm_ids,omitempty"`
+}
+
+func (x *ReplaceAlarmsResponse) Reset() {
+	*x = ReplaceAlarmsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_alarm_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReplaceAlarmsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplaceAlarmsResponse) ProtoMessage() {}
+
+func (x *ReplaceAlarmsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_alarm_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplaceAlarmsResponse.ProtoReflect.Descriptor instead.
+func (*ReplaceAlarmsResponse) Descriptor() ([]byte, []int) {
+	return file_alarm_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ReplaceAlarmsResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ReplaceAlarmsResponse) GetAlarmIds() []int32 {
+	if x != nil {
+		return x.AlarmIds
+	}
+	return nil
+}
+
 var File_alarm_proto protoreflect.FileDescriptor
 
 var file_alarm_proto_rawDesc = []byte{
@@ -258,23 +392,45 @@ var file_alarm_proto_rawDesc = []byte{
 	0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x05, 0x52, 0x09, 0x70, 0x61, 0x72, 0x65,
 	0x6e, 0x74, 0x49, 0x64, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
 	0x28, 0x05, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x32, 0xe9, 0x01, 0x0a, 0x0c,
-	0x41, 0x6c, 0x61, 0x72, 0x6d, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x43, 0x0a, 0x08,
-	0x53, 0x65, 0x74, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x12, 0x1a, 0x2e, 0x61, 0x6c, 0x61, 0x72, 0x6d,
-	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x73, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x2e, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x46, 0x0a, 0x0b, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x6c, 0x61, 0x72, 0x6d,
-	0x12, 0x1a, 0x2e, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
-	0x41, 0x6c, 0x61, 0x72, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x61,
-	0x6c, 0x61, 0x72, 0x6d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x41, 0x6c, 0x61, 0x72,
-	0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4c, 0x0a, 0x0b, 0x52, 0x65, 0x6d,
-	0x6f, 0x76, 0x65, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x12, 0x20, 0x2e, 0x61, 0x6c, 0x61, 0x72, 0x6d,
-	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x52, 0x65, 0x6d,
-	0x6f, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x61, 0x6c, 0x61,
-	0x72, 0x6d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x04, 0x5a, 0x02, 0x2e, 0x2f, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0xb6, 0x01, 0x0a, 0x14,
+	0x52, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x49,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x32, 0x0a, 0x06, 0x61, 0x6c, 0x61, 0x72, 0x6d,
+	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x52, 0x06, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x69,
+	0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63,
+	0x79, 0x4b, 0x65, 0x79, 0x22, 0x4c, 0x0a, 0x15, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x41,
+	0x6c, 0x61, 0x72, 0x6d, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x5f, 0x69,
+	0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x05, 0x52, 0x08, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x49,
+	0x64, 0x73, 0x32, 0xc3, 0x02, 0x0a, 0x0c, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0x43, 0x0a, 0x08, 0x53, 0x65, 0x74, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x12,
+	0x1a, 0x2e, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x41,
+	0x6c, 0x61, 0x72, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x61, 0x6c,
+	0x61, 0x72, 0x6d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x41, 0x6c, 0x61, 0x72, 0x6d,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x0b, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x12, 0x1a, 0x2e, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x4c, 0x0a, 0x0b, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x12,
+	0x20, 0x2e, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x41,
+	0x6c, 0x61, 0x72, 0x6d, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1b, 0x2e, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x2e, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x58,
+	0x0a, 0x0d, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x73, 0x12,
+	0x22, 0x2e, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x52,
+	0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x61, 0x6c, 0x61, 0x72, 0x6d, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x41, 0x6c, 0x61, 0x72, 0x6d, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x04, 0x5a, 0x02, 0x2e, 0x2f, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -289,24 +445,29 @@ func file_alarm_proto_rawDescGZIP() []byte {
 	return file_alarm_proto_rawDescData
 }
 
-var file_alarm_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_alarm_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_alarm_proto_goTypes = []interface{}{
-	(*AlarmRequest)(nil),       // 0: alarmservice.AlarmRequest
-	(*AlarmResponse)(nil),      // 1: alarmservice.AlarmResponse
-	(*AlarmRemoveRequest)(nil), // 2: alarmservice.AlarmRemoveRequest
+	(*AlarmRequest)(nil),          // 0: alarmservice.AlarmRequest
+	(*AlarmResponse)(nil),         // 1: alarmservice.AlarmResponse
+	(*AlarmRemoveRequest)(nil),    // 2: alarmservice.AlarmRemoveRequest
+	(*ReplaceAlarmsRequest)(nil),  // 3: alarmservice.ReplaceAlarmsRequest
+	(*ReplaceAlarmsResponse)(nil), // 4: alarmservice.ReplaceAlarmsResponse
 }
 var file_alarm_proto_depIdxs = []int32{
-	0, // 0: alarmservice.AlarmService.SetAlarm:input_type -> alarmservice.AlarmRequest
-	0, // 1: alarmservice.AlarmService.UpdateAlarm:input_type -> alarmservice.AlarmRequest
-	2, // 2: alarmservice.AlarmService.RemoveAlarm:input_type -> alarmservice.AlarmRemoveRequest
-	1, // 3: alarmservice.AlarmService.SetAlarm:output_type -> alarmservice.AlarmResponse
-	1, // 4: alarmservice.AlarmService.UpdateAlarm:output_type -> alarmservice.AlarmResponse
-	1, // 5: alarmservice.AlarmService.RemoveAlarm:output_type -> alarmservice.AlarmResponse
-	3, // [3:6] is the sub-list for method output_type
-	0, // [0:3] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0, // 0: alarmservice.ReplaceAlarmsRequest.alarms:type_name -> alarmservice.AlarmRequest
+	0, // 1: alarmservice.AlarmService.SetAlarm:input_type -> alarmservice.AlarmRequest
+	0, // 2: alarmservice.AlarmService.UpdateAlarm:input_type -> alarmservice.AlarmRequest
+	2, // 3: alarmservice.AlarmService.RemoveAlarm:input_type -> alarmservice.AlarmRemoveRequest
+	3, // 4: alarmservice.AlarmService.ReplaceAlarms:input_type -> alarmservice.ReplaceAlarmsRequest
+	1, // 5: alarmservice.AlarmService.SetAlarm:output_type -> alarmservice.AlarmResponse
+	1, // 6: alarmservice.AlarmService.UpdateAlarm:output_type -> alarmservice.AlarmResponse
+	1, // 7: alarmservice.AlarmService.RemoveAlarm:output_type -> alarmservice.AlarmResponse
+	4, // 8: alarmservice.AlarmService.ReplaceAlarms:output_type -> alarmservice.ReplaceAlarmsResponse
+	5, // [5:9] is the sub-list for method output_type
+	1, // [1:5] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_alarm_proto_init() }
@@ -351,6 +512,30 @@ func file_alarm_proto_init() {
 				return nil
 			}
 		}
+		file_alarm_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReplaceAlarmsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_alarm_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReplaceAlarmsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -358,7 +543,7 @@ func file_alarm_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_alarm_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,9 +19,10 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	AlarmService_SetAlarm_FullMethodName    = "/alarmservice.AlarmService/SetAlarm"
-	AlarmService_UpdateAlarm_FullMethodName = "/alarmservice.AlarmService/UpdateAlarm"
-	AlarmService_RemoveAlarm_FullMethodName = "/alarmservice.AlarmService/RemoveAlarm"
+	AlarmService_SetAlarm_FullMethodName      = "/alarmservice.AlarmService/SetAlarm"
+	AlarmService_UpdateAlarm_FullMethodName   = "/alarmservice.AlarmService/UpdateAlarm"
+	AlarmService_RemoveAlarm_FullMethodName   = "/alarmservice.AlarmService/RemoveAlarm"
+	AlarmService_ReplaceAlarms_FullMethodName = "/alarmservice.AlarmService/ReplaceAlarms"
 )
 
 // AlarmServiceClient is the client API for AlarmService service.
@@ -31,6 +32,7 @@ type AlarmServiceClient interface {
 	SetAlarm(ctx context.Context, in *AlarmRequest, opts ...grpc.CallOption) (*AlarmResponse, error)
 	UpdateAlarm(ctx context.Context, in *AlarmRequest, opts ...grpc.CallOption) (*AlarmResponse, error)
 	RemoveAlarm(ctx context.Context, in *AlarmRemoveRequest, opts ...grpc.CallOption) (*AlarmResponse, error)
+	ReplaceAlarms(ctx context.Context, in *ReplaceAlarmsRequest, opts ...grpc.CallOption) (*ReplaceAlarmsResponse, error)
 }
 
 type alarmServiceClient struct {
@@ -68,6 +70,15 @@ func (c *alarmServiceClient) RemoveAlarm(ctx context.Context, in *AlarmRemoveReq
 	return out, nil
 }
 
+func (c *alarmServiceClient) ReplaceAlarms(ctx context.Context, in *ReplaceAlarmsRequest, opts ...grpc.CallOption) (*ReplaceAlarmsResponse, error) {
+	out := new(ReplaceAlarmsResponse)
+	err := c.cc.Invoke(ctx, AlarmService_ReplaceAlarms_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AlarmServiceServer is the server API for AlarmService service.
 // All implementations must embed UnimplementedAlarmServiceServer
 // for forward compatibility
@@ -75,6 +86,7 @@ type AlarmServiceServer interface {
 	SetAlarm(context.Context, *AlarmRequest) (*AlarmResponse, error)
 	UpdateAlarm(context.Context, *AlarmRequest) (*AlarmResponse, error)
 	RemoveAlarm(context.Context, *AlarmRemoveRequest) (*AlarmResponse, error)
+	ReplaceAlarms(context.Context, *ReplaceAlarmsRequest) (*ReplaceAlarmsResponse, error)
 	mustEmbedUnimplementedAlarmServiceServer()
 }
 
@@ -91,6 +103,9 @@ func (UnimplementedAlarmServiceServer) UpdateAlarm(context.Context, *AlarmReques
 func (UnimplementedAlarmServiceServer) RemoveAlarm(context.Context, *AlarmRemoveRequest) (*AlarmResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveAlarm not implemented")
 }
+func (UnimplementedAlarmServiceServer) ReplaceAlarms(context.Context, *ReplaceAlarmsRequest) (*ReplaceAlarmsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplaceAlarms not implemented")
+}
 func (UnimplementedAlarmServiceServer) mustEmbedUnimplementedAlarmServiceServer() {}
 
 // UnsafeAlarmServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -158,6 +173,24 @@ func _AlarmService_RemoveAlarm_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AlarmService_ReplaceAlarms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplaceAlarmsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlarmServiceServer).ReplaceAlarms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AlarmService_ReplaceAlarms_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlarmServiceServer).ReplaceAlarms(ctx, req.(*ReplaceAlarmsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AlarmService_ServiceDesc is the grpc.ServiceDesc for AlarmService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -177,6 +210,10 @@ var AlarmService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveAlarm",
 			Handler:    _AlarmService_RemoveAlarm_Handler,
 		},
+		{
+			MethodName: "ReplaceAlarms",
+			Handler:    _AlarmService_ReplaceAlarms_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "alarm.proto",
@@ -0,0 +1,102 @@
+// /sleep-service/service/alarm.go
+package service
+
+import (
+	"encoding/json"
+	pb "sleep-service/proto"
+
+	"github.com/disterbia/wellkinson/common/model"
+	"github.com/disterbia/wellkinson/common/util"
+	"gorm.io/gorm"
+)
+
+const reconcileBatch = 500
+
+// 알람 변경 요청 출처 (relay 가 이 출처의 요청만 전달)
+const AlarmSource = "sleep"
+
+// parent 의 알람을 ars 로 교체 (비어 있으면 삭제) - 도메인 변경과 같은 트랜잭션(tx)으로 호출
+func enqueueReplaceAlarms(tx *gorm.DB, parentId, uid uint, alarmType int, ars []*pb.AlarmRequest) error {
+	return util.EnqueueAlarm(tx, AlarmSource, parentId, uid, util.ReplaceAlarmsMethod,
+		&pb.ReplaceAlarmsRequest{ParentId: int32(parentId), Uid: int32(uid), Type: int32(alarmType), Alarms: ars})
+}
+
+// 알람 삭제 - 기록마다 순서를 지키도록 parent 별로 저장
+func enqueueRemoveAlarms(tx *gorm.DB, parentIds []uint, uid uint, alarmType int) error {
+	for _, v := range parentIds {
+		if err := enqueueReplaceAlarms(tx, v, uid, alarmType, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 수면 알람 기록대로 알람 교체 요청 - 꺼져 있으면 삭제
+func enqueueSleepAlarm(tx *gorm.DB, sleep model.SleepAlarm) error {
+	if !sleep.IsActive {
+		return enqueueRemoveAlarms(tx, []uint{sleep.Id}, sleep.Uid, util.SleepType)
+	}
+	var week []int32
+	if err := json.Unmarshal(sleep.Weekdays, &week); err != nil {
+		return err
+	}
+	return enqueueReplaceAlarms(tx, sleep.Id, sleep.Uid, util.SleepType, []*pb.AlarmRequest{{
+		ParentId:  int32(sleep.Id),
+		Uid:       int32(sleep.Uid),
+		Body:      "취침 할 시간입니다.",
+		Type:      int32(util.SleepType),
+		StartAt:   userToday(tx, sleep.Uid),
+		Timestamp: sleep.AlarmTime,
+		Week:      week,
+	}})
+}
+
+// 수면 알람 기록 기준으로 알람 다시 맞추기 (uid 0 이면 전체) - 기록마다 교체/삭제 요청, 기록이 없는 알람은 삭제 요청
+// 요청 건수 반환, 전달은 AlarmRelay 가 처리
+func ReconcileAlarms(db *gorm.DB, uid uint) (int, error) {
+	count := 0
+	query := db.Order("id")
+	if uid != 0 {
+		query = query.Where("uid = ?", uid)
+	}
+	var sleeps []model.SleepAlarm
+	err := query.FindInBatches(&sleeps, reconcileBatch, func(_ *gorm.DB, batch int) error {
+		return db.Transaction(func(tx *gorm.DB) error {
+			for _, v := range sleeps {
+				if err := enqueueSleepAlarm(tx, v); err != nil {
+					return err
+				}
+			}
+			count += len(sleeps)
+			return nil
+		})
+	}).Error
+	if err != nil {
+		return count, err
+	}
+
+	var orphans []struct {
+		Uid      uint
+		ParentId uint
+	}
+	query = db.Table("alarms").Distinct("uid", "parent_id").
+		Where("type = ? AND NOT EXISTS (SELECT 1 FROM sleep_alarms WHERE sleep_alarms.id = alarms.parent_id AND sleep_alarms.uid = alarms.uid)", util.SleepType)
+	if uid != 0 {
+		query = query.Where("uid = ?", uid)
+	}
+	if err := query.Scan(&orphans).Error; err != nil {
+		return count, err
+	}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		for _, v := range orphans {
+			if err := enqueueRemoveAlarms(tx, []uint{v.ParentId}, v.Uid, util.SleepType); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return count, err
+	}
+	return count + len(orphans), nil
+}
@@ -2,18 +2,14 @@
 package service
 
 import (
-	"context"
 	"encoding/json"
 	"errors"
-	"log"
 	"reflect"
-	"sleep-service/common/model"
-	"sleep-service/common/util"
 	"sleep-service/dto"
-	pb "sleep-service/proto"
 	"time"
 
-	"google.golang.org/grpc"
+	"github.com/disterbia/wellkinson/common/model"
+	"github.com/disterbia/wellkinson/common/util"
 	"gorm.io/gorm"
 )
 
@@ -27,13 +23,12 @@ type SleepService interface {
 }
 
 type sleepService struct {
-	db          *gorm.DB
-	alarmClient pb.AlarmServiceClient
+	db *gorm.DB
 }
 
-func NewSleepService(db *gorm.DB, conn *grpc.ClientConn) SleepService {
-	alarmClient := pb.NewAlarmServiceClient(conn)
-	return &sleepService{db: db, alarmClient: alarmClient}
+// 알람은 outbox 에 저장하고 AlarmRelay 가 alarm-service 로 전달
+func NewSleepService(db *gorm.DB) SleepService {
+	return &sleepService{db: db}
 }
 
 func (service *sleepService) SaveSleepAlarm(sleepRequest dto.SleepAlarmRequest) (string, error) {
@@ -58,9 +53,9 @@ func (service *sleepService) SaveSleepAlarm(sleepRequest dto.SleepAlarmRequest)
 
 	sleep.Uid = sleepRequest.Uid //  json: "-" 이라서
 
-	newWeekdays, unique, err2 := validateWeek(sleep.Weekdays)
+	newWeekdays, _, err2 := validateWeek(sleep.Weekdays)
 	if err2 != nil {
-		return "", errors.New("week error")
+		return "", util.WrapError(util.ErrInvalidRequest, err2)
 	}
 	sleep.Weekdays = newWeekdays
 
@@ -68,24 +63,17 @@ func (service *sleepService) SaveSleepAlarm(sleepRequest dto.SleepAlarmRequest)
 		// 레코드가 존재하지 않으면 새 레코드 생성
 		sleep.Id = 0
 
-		if err := service.db.Create(&sleep).Error; err != nil {
+		err := service.db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Create(&sleep).Error; err != nil {
+				return err
+			}
+			return enqueueSleepAlarm(tx, sleep)
+		})
+		if err != nil {
 			return "", err
 		}
-		ar := &pb.AlarmRequest{
-			ParentId: int32(sleep.Id),
-			Uid:      int32(sleep.Uid),
-			Body:     "취침 할 시간입니다.",
-			Type:     int32(util.SleepType),
-			StartAt:  time.Now().Format("2006-01-02"),
-			// EndAt:     "",
-			Timestamp: sleep.AlarmTime,
-			Week:      unique,
-		}
-		if sleep.IsActive {
-			go sendAlarm(service, ar)
-		}
 	} else if result.Error != nil {
-		return "", errors.New("db error")
+		return "", util.NewError(util.ErrDatabase, "db error")
 	} else {
 		// 레코드가 존재하면 업데이트
 
@@ -108,32 +96,19 @@ func (service *sleepService) SaveSleepAlarm(sleepRequest dto.SleepAlarmRequest)
 				}
 			}
 		}
-		if err := service.db.Model(&sleep).Debug().Updates(updateFields).Error; err != nil {
-			return "", err
-		}
-		ar := &pb.AlarmRequest{
-			ParentId: int32(sleep.Id),
-			Uid:      int32(sleep.Uid),
-			Body:     "취침 할 시간입니다.",
-			Type:     int32(util.SleepType),
-			StartAt:  time.Now().Format("2006-01-02"),
-			// EndAt:     "",
-			Timestamp: sleep.AlarmTime,
-			Week:      unique,
-		}
-		if sleep.IsActive {
-			go updateAlarm(service, ar)
-		} else {
-			b := make([]int32, 1)
-
-			b[0] = int32(sleep.Id)
-
-			arr := &pb.AlarmRemoveRequest{
-				ParentIds: b,
-				Uid:       int32(sleep.Uid),
-				Type:      int32(util.SleepType),
+		err := service.db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Model(&sleep).Debug().Updates(updateFields).Error; err != nil {
+				return err
 			}
-			go removeAlarm(service, arr)
+			// 요청에 없는 필드는 기존 값이므로 저장된 기록으로 알람 요청
+			var saved model.SleepAlarm
+			if err := tx.Where("id = ?", sleep.Id).First(&saved).Error; err != nil {
+				return err
+			}
+			return enqueueSleepAlarm(tx, saved)
+		})
+		if err != nil {
+			return "", err
 		}
 	}
 
@@ -145,7 +120,7 @@ func (service *sleepService) GetSleepAlarms(id uint) ([]dto.SleepAlarmResponse,
 	var alarmsResponses []dto.SleepAlarmResponse
 	err := service.db.Where("uid = ? ", id).Order("end_time").Find(&sleepAlarms).Error
 	if err != nil {
-		return nil, errors.New("db error")
+		return nil, util.NewError(util.ErrDatabase, "db error")
 	}
 
 	if err := util.CopyStruct(sleepAlarms, &alarmsResponses); err != nil {
@@ -155,25 +130,15 @@ func (service *sleepService) GetSleepAlarms(id uint) ([]dto.SleepAlarmResponse,
 }
 
 func (service *sleepService) RemoveSleepAlarms(ids []uint, uid uint) (string, error) {
-	result := service.db.Where("id IN (?) AND uid= ?", ids, uid).Delete(&model.SleepAlarm{})
-
-	if result.Error != nil {
-		return "", errors.New("db error")
-	}
-
-	b := make([]int32, len(ids))
-
-	for i, v := range ids {
-		b[i] = int32(v)
-	}
-
-	arr := &pb.AlarmRemoveRequest{
-		ParentIds: b,
-		Uid:       int32(uid),
-		Type:      int32(util.SleepType),
+	err := service.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id IN (?) AND uid= ?", ids, uid).Delete(&model.SleepAlarm{}).Error; err != nil {
+			return err
+		}
+		return enqueueRemoveAlarms(tx, ids, uid, util.SleepType)
+	})
+	if err != nil {
+		return "", util.NewError(util.ErrDatabase, "db error")
 	}
-
-	go removeAlarm(service, arr)
 	return "200", nil
 }
 
@@ -194,7 +159,7 @@ func (service *sleepService) GetSleepTimes(id uint, startDateStr, endDateStr str
 	var sleepTimeResponses []dto.SleepTimeResponse
 	err = service.db.Debug().Where("uid = ? AND DATE(date_sleep) BETWEEN ? AND ?", id, startDate.Format("2006-01-02"), endDate.Format("2006-01-02")).Find(&sleepTimes).Error
 	if err != nil {
-		return nil, errors.New("db error")
+		return nil, util.NewError(util.ErrDatabase, "db error")
 	}
 	if err := util.CopyStruct(sleepTimes, &sleepTimeResponses); err != nil {
 		return nil, err
@@ -206,7 +171,7 @@ func (service *sleepService) GetSleepTimes(id uint, startDateStr, endDateStr str
 func (service *sleepService) RemoveSleepTime(id uint, uid uint) (string, error) {
 	result := service.db.Where("id = ? AND uid= ?", id, uid).Delete(&model.SleepTime{})
 	if result.Error != nil {
-		return "", errors.New("db error")
+		return "", util.NewError(util.ErrDatabase, "db error")
 	}
 	return "200", nil
 }
@@ -228,37 +193,13 @@ func (service *sleepService) SaveSleepTime(sleepRequest dto.SleepTimeRequest) (s
 	if result.RowsAffected == 0 {
 
 		if err := service.db.Create(&sleepTime).Error; err != nil {
-			return "", errors.New("db error")
+			return "", util.NewError(util.ErrDatabase, "db error")
 		}
 	} else {
 		result := service.db.Model(&sleepTime).Updates(sleepRequest)
 		if result.Error != nil {
-			return "", errors.New("db error2")
+			return "", util.NewError(util.ErrDatabase, "db error2")
 		}
 	}
 	return "200", nil
 }
-
-func sendAlarm(service *sleepService, ar *pb.AlarmRequest) {
-	reponse, err := service.alarmClient.SetAlarm(context.Background(), ar)
-	if err != nil {
-		log.Printf("Failed to set Alarm: %v", err)
-	}
-	log.Printf("set Alarm: %v", reponse)
-}
-
-func removeAlarm(service *sleepService, arr *pb.AlarmRemoveRequest) {
-	reponse, err := service.alarmClient.RemoveAlarm(context.Background(), arr)
-	if err != nil {
-		log.Printf("Failed to remove Alarm: %v", err)
-	}
-	log.Printf("remove Alarm: %v", reponse)
-}
-
-func updateAlarm(service *sleepService, ar *pb.AlarmRequest) {
-	reponse, err := service.alarmClient.UpdateAlarm(context.Background(), ar)
-	if err != nil {
-		log.Printf("Failed to update Alarm: %v", err)
-	}
-	log.Printf("update Alarm: %v", reponse)
-}
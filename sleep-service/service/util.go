@@ -3,9 +3,12 @@ package service
 
 import (
 	"encoding/json"
-	"errors"
-	"sleep-service/common/util"
 	"sleep-service/dto"
+	"time"
+
+	"github.com/disterbia/wellkinson/common/model"
+	"github.com/disterbia/wellkinson/common/util"
+	"gorm.io/gorm"
 )
 
 func validateSleep(sleepRequest dto.SleepAlarmRequest) error {
@@ -46,7 +49,7 @@ func validateWeek(weekdays json.RawMessage) (json.RawMessage, []int32, error) {
 	}
 
 	if len(weekdaySlice) == 0 {
-		return nil, nil, errors.New("must weekday")
+		return nil, nil, util.NewError(util.ErrInvalidRequest, "must weekday")
 	}
 
 	seen := make(map[int32]bool)
@@ -83,7 +86,7 @@ func isDuplicateWeek(userWeekdays []uint, dbWeekdaysRaw []json.RawMessage) error
 		for _, uDay := range userWeekdays {
 			for _, dbDay := range dbWeekdays {
 				if uDay == dbDay {
-					return errors.New("중복되는 요일이 있습니다")
+					return util.NewError(util.ErrInvalidRequest, "중복되는 요일이 있습니다")
 				}
 			}
 		}
@@ -91,3 +94,11 @@ func isDuplicateWeek(userWeekdays []uint, dbWeekdaysRaw []json.RawMessage) error
 
 	return nil // 중복 없음
 }
+
+// 유저 시간대 기준 오늘 날짜
+func userToday(db *gorm.DB, uid uint) string {
+	var user model.User
+	// 조회 실패시 기본 시간대
+	db.Select("time_zone").Where("id = ?", uid).First(&user)
+	return time.Now().In(util.LoadUserLocation(user.TimeZone)).Format("2006-01-02")
+}
@@ -0,0 +1,133 @@
+// /user-service/cmd/timestamp-backfill/main.go
+// 서버 시각 문자열 (created, updated, 세션 만료 등) 중 다른 시간대로 저장된 기간을 서버 기준 시간대로 옮기기
+// 이전에는 컨테이너 TZ 로 저장했으므로 TZ=Asia/Seoul 로 실행된 기간은 옮길 필요 없음 (TZ 없이 실행되어 UTC 로 저장된 기간 등만)
+// -since, -until 은 저장된 값 기준 (from 시간대), -tables 를 주면 해당 테이블만
+//
+//	timestamp-backfill -from UTC -since "2024-05-01 00:00:00" -until "2024-05-02 00:00:00" [-tables sessions,devices] [-dry-run]
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"strings"
+	"time"
+	"user-service/db"
+
+	"github.com/disterbia/wellkinson/common/model"
+	"github.com/joho/godotenv"
+	"gorm.io/gorm"
+)
+
+const timeLayout = "2006-01-02 15:04:05"
+
+// 모든 테이블의 created, updated 와 테이블별 서버 시각 컬럼 (유저 시간대 값이나 UTC 값은 제외)
+var serverTimeColumns = map[string][]string{
+	"sessions":           {"expires_at", "last_used", "revoked_at"},
+	"devices":            {"last_seen"},
+	"admin_credentials":  {"locked_until"},
+	"care_links":         {"invite_expires"},
+	"message_deliveries": {"next_retry", "expires_at"},
+	"push_deliveries":    {"next_retry"},
+	"alarm_outboxes":     {"next_retry"},
+}
+
+func main() {
+	from := flag.String("from", "UTC", "잘못 저장된 값의 시간대")
+	since := flag.String("since", "", "이 시각 이후 값 (YYYY-mm-dd HH:MM:SS, 필수)")
+	until := flag.String("until", "", "이 시각 이전 값 (YYYY-mm-dd HH:MM:SS, 필수)")
+	tables := flag.String("tables", "", "쉼표로 구분한 테이블 (비어있으면 전체)")
+	dryRun := flag.Bool("dry-run", false, "바꿀 행 수만 출력")
+	flag.Parse()
+
+	if _, err := time.LoadLocation(*from); err != nil {
+		log.Fatalf("invalid -from: %v", err)
+	}
+	for _, v := range []string{*since, *until} {
+		if _, err := time.Parse(timeLayout, v); err != nil {
+			log.Fatalf("check -since, -until: %v", err)
+		}
+	}
+
+	if err := godotenv.Load(".env"); err != nil {
+		log.Fatalln("Error loading .env file")
+	}
+	database, err := db.NewDB(os.Getenv("DB_PATH"))
+	if err != nil {
+		log.Fatalln("Database connection error:", err)
+	}
+
+	targets, err := timestampColumns(database, *tables)
+	if err != nil {
+		log.Fatalln("column lookup error:", err)
+	}
+
+	to := model.ServerLocation.String()
+	total := int64(0)
+	err = database.Transaction(func(tx *gorm.DB) error {
+		for _, target := range targets {
+			table, column := target[0], target[1]
+			where := tx.Table(table).Where(column+" ~ '^[0-9]{4}-[0-9]{2}-[0-9]{2} [0-9]{2}:[0-9]{2}:[0-9]{2}$' AND "+column+" >= ? AND "+column+" < ?", *since, *until)
+			var count int64
+			if *dryRun {
+				if err := where.Count(&count).Error; err != nil {
+					return err
+				}
+			} else {
+				result := where.Update(column, gorm.Expr("to_char(("+column+"::timestamp AT TIME ZONE ?) AT TIME ZONE ?, 'YYYY-MM-DD HH24:MI:SS')", *from, to))
+				if result.Error != nil {
+					return result.Error
+				}
+				count = result.RowsAffected
+			}
+			if count > 0 {
+				log.Printf("%s.%s: %d rows", table, column, count)
+			}
+			total += count
+		}
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("backfill error: %v", err)
+	}
+	if *dryRun {
+		log.Printf("%d values would be moved from %s to %s", total, *from, to)
+		return
+	}
+	log.Printf("moved %d values from %s to %s", total, *from, to)
+}
+
+// 대상 (테이블, 컬럼) 목록 - 실제 있는 문자열 컬럼만
+func timestampColumns(database *gorm.DB, tables string) ([][2]string, error) {
+	var only map[string]bool
+	if tables != "" {
+		only = make(map[string]bool)
+		for _, v := range strings.Split(tables, ",") {
+			only[strings.TrimSpace(v)] = true
+		}
+	}
+
+	var columns []struct {
+		TableName  string
+		ColumnName string
+	}
+	if err := database.Raw(`SELECT table_name, column_name FROM information_schema.columns
+		WHERE table_schema = current_schema() AND data_type IN ('text', 'character varying') ORDER BY table_name, column_name`).Scan(&columns).Error; err != nil {
+		return nil, err
+	}
+
+	var targets [][2]string
+	for _, v := range columns {
+		if only != nil && !only[v.TableName] {
+			continue
+		}
+		match := v.ColumnName == "created" || v.ColumnName == "updated"
+		for _, column := range serverTimeColumns[v.TableName] {
+			match = match || column == v.ColumnName
+		}
+		if match {
+			targets = append(targets, [2]string{v.TableName, v.ColumnName})
+		}
+	}
+	return targets, nil
+}
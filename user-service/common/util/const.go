@@ -1,18 +0,0 @@
-// /common/util/util.go
-package util
-
-var ExerciseType = 1
-
-var MedicineType = 2
-
-var SleepType = 3
-
-var UserProfileImageType = 0
-
-var DietImageType = 1
-
-var KakaoSnsType = 1
-
-var GoogleSnsType = 2
-
-var AppleSnsType = 3
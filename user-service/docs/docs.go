@@ -15,68 +15,34 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/admin-login": {
-            "post": {
-                "security": [
-                    {
-                        "jwt": []
-                    }
-                ],
-                "description": "관리자 로그인시 호출",
-                "consumes": [
-                    "application/json"
-                ],
+        "/.well-known/jwks.json": {
+            "get": {
+                "description": "서비스들이 JWT 서명 검증에 사용하는 공개키(JWKS) - kid 로 키 선택",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "로그인 /user"
-                ],
-                "summary": "관리자 로그인",
-                "parameters": [
-                    {
-                        "description": "email",
-                        "name": "email",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "password",
-                        "name": "password",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
+                    "공통 /user"
                 ],
+                "summary": "토큰 검증 공개키 조회",
                 "responses": {
                     "200": {
-                        "description": "성공시 JWT 토큰 반환",
-                        "schema": {
-                            "$ref": "#/definitions/dto.SuccessResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "description": "공개키 목록",
                         "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/util.JWKS"
                         }
                     }
                 }
             }
         },
-        "/auto-login": {
+        "/accept-care": {
             "post": {
                 "security": [
                     {
                         "jwt": []
                     }
                 ],
-                "description": "최초 로그인 이후 앱 실행시 호출",
+                "description": "보호자가 환자에게 받은 초대 코드 입력시 호출",
                 "consumes": [
                     "application/json"
                 ],
@@ -84,9 +50,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "로그인 /user"
+                    "보호자 /user"
                 ],
-                "summary": "자동로그인",
+                "summary": "보호자 초대 수락",
                 "parameters": [
                     {
                         "type": "string",
@@ -101,15 +67,27 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.AutoLoginRequest"
+                            "$ref": "#/definitions/dto.CareAcceptRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "성공시 JWT 토큰 반환",
+                        "description": "성공시 200 반환",
                         "schema": {
-                            "$ref": "#/definitions/dto.SuccessResponse"
+                            "$ref": "#/definitions/dto.BasicResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
@@ -121,9 +99,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/get-services": {
-            "get": {
-                "description": "이용하고 싶은 서비스 목록 조회시 호출",
+        "/admin-change-password": {
+            "post": {
+                "description": "관리자 비밀번호 변경시 호출 (10자 이상) - 변경 후 모든 세션 로그아웃\n비밀번호 미설정 관리자는 password 에 기존 전화번호, auth_code 에 /admin-send-code 로 받은 인증번호 입력",
                 "consumes": [
                     "application/json"
                 ],
@@ -131,21 +109,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "공통 /user"
+                    "관리자 인증 /user"
+                ],
+                "summary": "관리자 비밀번호 변경",
+                "parameters": [
+                    {
+                        "description": "요청 DTO",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AdminPasswordRequest"
+                        }
+                    }
                 ],
-                "summary": "전체 서비스 목록 조회",
                 "responses": {
                     "200": {
-                        "description": "서비스 목록",
+                        "description": "성공시 200 반환",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.MainServiceResponse"
-                            }
+                            "$ref": "#/definitions/dto.BasicResponse"
                         }
                     },
                     "400": {
-                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "description": "요청 처리 실패시 오류 메시지 반환 (VERIFICATION_MISMATCH - 인증번호 불일치)",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "UNAUTHORIZED - 인증 실패, 잠금 (로그인과 같은 응답)",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "VERIFICATION_REQUIRED - 비밀번호 미설정 관리자는 인증번호 필요",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "VERIFICATION_EXPIRED - 인증번호 만료, 시도 횟수 초과",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -159,9 +163,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/get-user": {
+        "/admin-login": {
             "post": {
-                "description": "내 정보 조회시 호출",
+                "description": "관리자 로그인시 호출 - 5회 실패시 15분 잠금 (잠금 중에는 비밀번호가 맞아도 로그인 실패), TOTP 등록한 관리자는 otp_code 필요",
                 "consumes": [
                     "application/json"
                 ],
@@ -169,23 +173,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "회원조회(본인)  /user"
+                    "로그인 /user"
                 ],
-                "summary": "유저 조회",
+                "summary": "관리자 로그인",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Bearer {jwt_token}",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
+                        "description": "요청 DTO",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AdminLoginRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "성공시 유저 객체 반환/ ture:남성 user_Type- 0:해당없음 1:파킨슨 환자 2:보호자 sns_type- 0:카카오 1:구글 2:애플",
+                        "description": "성공시 JWT 토큰 반환",
                         "schema": {
-                            "$ref": "#/definitions/dto.UserResponse"
+                            "$ref": "#/definitions/dto.SuccessResponse"
                         }
                     },
                     "400": {
@@ -194,6 +200,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "UNAUTHORIZED - 로그인 실패, 계정 없음, 관리자 아님, OTP 불일치, 잠금은 모두 같은 응답 (detail: \"password not set\" = 비밀번호 변경 필요, 기존 방식 비밀번호가 맞을때만)",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "요청 처리 실패시 오류 메시지 반환",
                         "schema": {
@@ -203,24 +215,44 @@ const docTemplate = `{
                 }
             }
         },
-        "/get-version": {
+        "/admin-login-audits": {
             "get": {
-                "description": "최신버전 조회시 호출",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "jwt": []
+                    }
                 ],
+                "description": "관리자 로그인 시도 기록 조회시 호출 (role:manage 권한 필요, 20개씩)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "공통 /user"
+                    "관리자 인증 /user"
+                ],
+                "summary": "관리자 로그인 기록",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {jwt_token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "페이지 번호 default 0",
+                        "name": "page",
+                        "in": "query"
+                    }
                 ],
-                "summary": "최신버전 조회",
                 "responses": {
                     "200": {
-                        "description": "최신 버전 정보",
+                        "description": "로그인 기록",
                         "schema": {
-                            "$ref": "#/definitions/dto.AppVersionResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.AdminLoginAuditResponse"
+                            }
                         }
                     },
                     "400": {
@@ -229,6 +261,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "요청 처리 실패시 오류 메시지 반환",
                         "schema": {
@@ -238,9 +276,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/link-email": {
+        "/admin-reset-password": {
             "post": {
-                "description": "계정 연동시 호출",
+                "security": [
+                    {
+                        "jwt": []
+                    }
+                ],
+                "description": "관리자 비밀번호 초기화시 호출 (role:manage 권한 필요) - TOTP 도 해제됨",
                 "consumes": [
                     "application/json"
                 ],
@@ -248,9 +291,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "계정 연동 /user"
+                    "관리자 인증 /user"
                 ],
-                "summary": "계정 연동",
+                "summary": "관리자 비밀번호 초기화",
                 "parameters": [
                     {
                         "type": "string",
@@ -265,7 +308,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.LinkRequest"
+                            "$ref": "#/definitions/dto.AdminResetRequest"
                         }
                     }
                 ],
@@ -282,6 +325,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "요청 처리 실패시 오류 메시지 반환",
                         "schema": {
@@ -291,9 +340,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/remvoe-user": {
+        "/admin-send-code": {
             "post": {
-                "description": "회원탈퇴시 호출",
+                "description": "비밀번호 미설정 관리자가 첫 비밀번호를 설정하기 전에 호출 - password 에 기존 전화번호 입력, 맞으면 등록된 전화번호로 인증번호 발송\n계정 여부, 전화번호 일치 여부와 관계없이 항상 200 반환",
                 "consumes": [
                     "application/json"
                 ],
@@ -301,16 +350,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "회원탈퇴 /user"
+                    "관리자 인증 /user"
                 ],
-                "summary": "회원탈퇴",
+                "summary": "관리자 첫 비밀번호 설정 인증번호 발송",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Bearer {jwt_token}",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
+                        "description": "요청 DTO",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AdminCodeRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -325,19 +376,18 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
-                    },
-                    "500": {
-                        "description": "요청 처리 실패시 오류 메시지 반환",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
                     }
                 }
             }
         },
-        "/send-code/{number}": {
+        "/admin-totp-confirm": {
             "post": {
-                "description": "인증번호 발송시 호출",
+                "security": [
+                    {
+                        "jwt": []
+                    }
+                ],
+                "description": "인증 앱의 코드로 2단계 인증 활성화시 호출",
                 "consumes": [
                     "application/json"
                 ],
@@ -345,16 +395,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "인증번호 /user"
+                    "관리자 인증 /user"
                 ],
-                "summary": "인증번호 발송",
+                "summary": "TOTP 확인",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "휴대번호",
-                        "name": "number",
-                        "in": "path",
+                        "description": "Bearer {jwt_token}",
+                        "name": "Authorization",
+                        "in": "header",
                         "required": true
+                    },
+                    {
+                        "description": "요청 DTO",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TotpConfirmRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -370,6 +429,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "요청 처리 실패시 오류 메시지 반환",
                         "schema": {
@@ -379,19 +444,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/set-user": {
+        "/admin-totp-enroll": {
             "post": {
-                "description": "유저 상태영구변경시 호출",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "jwt": []
+                    }
                 ],
+                "description": "관리자 2단계 인증 등록 시작시 호출 - 인증 앱에 등록 후 /admin-totp-confirm 으로 확인",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "회원상태 변경(본인)  /user"
+                    "관리자 인증 /user"
                 ],
-                "summary": "유저 데이터 변경",
+                "summary": "TOTP 등록",
                 "parameters": [
                     {
                         "type": "string",
@@ -399,32 +466,23 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "description": "요청 DTO - 업데이트 할 데이터/ ture:남성 user_Type- 0:해당없음 1:파킨슨 환자 2:보호자",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.UserRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "성공시 200 반환",
+                        "description": "TOTP 비밀키, otpauth URL",
                         "schema": {
-                            "$ref": "#/definitions/dto.BasicResponse"
+                            "$ref": "#/definitions/dto.TotpEnrollResponse"
                         }
                     },
-                    "400": {
-                        "description": "요청 처리 실패시 오류 메시지 반환",
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "요청 처리 실패시 오류 메시지 반환: 오류메시지 \"-1\" = 번호인증 필요",
+                        "description": "요청 처리 실패시 오류 메시지 반환",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -432,9 +490,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/sns-login": {
+        "/auto-login": {
             "post": {
-                "description": "sns 로그인 성공시 호출",
+                "description": "최초 로그인 이후 앱 실행시 호출 - 로그인때 받은 refresh 토큰 필요, 사용한 refresh 토큰은 폐기되고 새 refresh 토큰이 발급됨",
                 "consumes": [
                     "application/json"
                 ],
@@ -444,21 +502,21 @@ const docTemplate = `{
                 "tags": [
                     "로그인 /user"
                 ],
-                "summary": "sns 로그인",
+                "summary": "자동로그인",
                 "parameters": [
                     {
-                        "description": "요청 DTO - idToken,기본값 데이터 user_type: 0:해당없음 1:파킨슨 환자 2:보호자 / 최초 로그인 이후 로그인시 fcm_token 만 필요함",
+                        "description": "요청 DTO - refresh_token, fcm_token, device_id 필수",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.LoginRequest"
+                            "$ref": "#/definitions/dto.AutoLoginRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "성공시 JWT 토큰 반환",
+                        "description": "성공시 JWT 토큰, refresh 토큰 반환",
                         "schema": {
                             "$ref": "#/definitions/dto.SuccessResponse"
                         }
@@ -469,8 +527,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "UNAUTHORIZED - 만료, 폐기 또는 재사용된 refresh 토큰 - 재로그인 필요",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "FORBIDDEN - 자동로그인 사용 안함",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
-                        "description": "요청 처리 실패시 오류 메시지 반환: 오류메시지 \"-2\" = 이미 가입한 번호",
+                        "description": "요청 처리 실패시 오류 메시지 반환",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -478,15 +548,1336 @@ const docTemplate = `{
                 }
             }
         },
-        "/verify-code": {
-            "post": {
-                "description": "인증번호 입력 후 호출",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+        "/get-care-audits": {
+            "get": {
+                "security": [
+                    {
+                        "jwt": []
+                    }
+                ],
+                "description": "보호자가 내 계정에 대신 기록한 내역 조회시 호출 (20개씩)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "보호자 /user"
+                ],
+                "summary": "보호자 대리 기록 내역",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {jwt_token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "페이지 번호 default 0",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "대리 기록 내역",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.DelegatedWriteResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/get-cares": {
+            "get": {
+                "security": [
+                    {
+                        "jwt": []
+                    }
+                ],
+                "description": "내가 환자 또는 보호자인 연결 목록 조회시 호출",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "보호자 /user"
+                ],
+                "summary": "보호자 연결 목록",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {jwt_token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "연결 목록",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.CareLinkResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/get-devices": {
+            "get": {
+                "security": [
+                    {
+                        "jwt": []
+                    }
+                ],
+                "description": "푸시를 받는 기기 목록 조회시 호출",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "세션 /user"
+                ],
+                "summary": "푸시 기기 목록 조회",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {jwt_token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "기기 목록 - current: 현재 요청한 기기",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.DeviceResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/get-polices": {
+            "get": {
+                "description": "약관 조회시 호출",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "공통 /user"
+                ],
+                "summary": "약관 조회",
+                "responses": {
+                    "200": {
+                        "description": "최신 약관 정보",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.PoliceResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/get-roles/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "jwt": []
+                    }
+                ],
+                "description": "유저의 역할 조회시 호출 (role:manage 권한 필요)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "역할 관리 /user"
+                ],
+                "summary": "유저 역할 조회",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {jwt_token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "유저ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "역할 목록",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/get-services": {
+            "get": {
+                "description": "이용하고 싶은 서비스 목록 조회시 호출",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "공통 /user"
+                ],
+                "summary": "전체 서비스 목록 조회",
+                "responses": {
+                    "200": {
+                        "description": "서비스 목록",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.MainServiceResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/get-sessions": {
+            "get": {
+                "security": [
+                    {
+                        "jwt": []
+                    }
+                ],
+                "description": "로그인 되어있는 기기(세션) 목록 조회시 호출",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "세션 /user"
+                ],
+                "summary": "로그인 기기 목록 조회",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {jwt_token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "세션 목록 - current: 현재 요청한 기기",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.SessionResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/get-user": {
+            "post": {
+                "description": "내 정보 조회시 호출",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "회원조회(본인)  /user"
+                ],
+                "summary": "유저 조회",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {jwt_token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "성공시 유저 객체 반환/ ture:남성 user_Type- 0:해당없음 1:파킨슨 환자 2:보호자 sns_type- 0:카카오 1:구글 2:애플",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/get-version": {
+            "get": {
+                "description": "최신버전 조회시 호출",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "공통 /user"
+                ],
+                "summary": "최신버전 조회",
+                "responses": {
+                    "200": {
+                        "description": "최신 버전 정보",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AppVersionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/grant-role": {
+            "post": {
+                "security": [
+                    {
+                        "jwt": []
+                    }
+                ],
+                "description": "유저에게 역할 부여시 호출 (role:manage 권한 필요) - 변경된 역할은 토큰 재발급 이후 적용",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "역할 관리 /user"
+                ],
+                "summary": "역할 부여",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {jwt_token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "요청 DTO - role: patient, caregiver, clinician, content-admin, super-admin",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "성공시 200 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BasicResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/invite-caregiver": {
+            "post": {
+                "security": [
+                    {
+                        "jwt": []
+                    }
+                ],
+                "description": "환자가 보호자 초대 코드 발급시 호출 (72시간 유효) - 보호자는 X-Acting-For: 환자uid 헤더로 허용된 도메인 서비스 호출",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "보호자 /user"
+                ],
+                "summary": "보호자 초대",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {jwt_token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "요청 DTO - scopes 도메인: medicine, exercise, sleep, diet, emotion / 권한: read, write",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CareInviteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "초대 코드",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CareInviteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/link-email": {
+            "post": {
+                "description": "계정 연동시 호출",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "계정 연동 /user"
+                ],
+                "summary": "계정 연동",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {jwt_token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "요청 DTO",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.LinkRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "성공시 200 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BasicResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/logout": {
+            "post": {
+                "security": [
+                    {
+                        "jwt": []
+                    }
+                ],
+                "description": "현재 기기의 세션을 폐기할때 호출",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "세션 /user"
+                ],
+                "summary": "로그아웃",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {jwt_token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "성공시 200 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BasicResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/refresh-token": {
+            "post": {
+                "description": "access 토큰 만료시 호출 - 사용한 refresh 토큰은 폐기되고 새 refresh 토큰이 발급됨",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "로그인 /user"
+                ],
+                "summary": "토큰 재발급",
+                "parameters": [
+                    {
+                        "description": "요청 DTO - refresh_token 필수",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RefreshRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "성공시 JWT 토큰, refresh 토큰 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "만료, 폐기 또는 재사용된 refresh 토큰 - 재로그인 필요",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/register-device": {
+            "post": {
+                "security": [
+                    {
+                        "jwt": []
+                    }
+                ],
+                "description": "앱 실행중 fcm 토큰이 갱신되었을때 호출",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "세션 /user"
+                ],
+                "summary": "푸시 기기 등록",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {jwt_token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "요청 DTO - fcm_token,device_id 필수",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.DeviceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "성공시 200 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BasicResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/remove-care/{id}": {
+            "post": {
+                "security": [
+                    {
+                        "jwt": []
+                    }
+                ],
+                "description": "환자 또는 보호자가 연결(초대 포함) 해제시 호출",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "보호자 /user"
+                ],
+                "summary": "보호자 연결 해제",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {jwt_token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "연결ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "성공시 200 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BasicResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/remove-profile": {
+            "post": {
+                "security": [
+                    {
+                        "jwt": []
+                    }
+                ],
+                "description": "기본이미지로 변경시 호출",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "회원상태 변경(본인)  /user"
+                ],
+                "summary": "프로필 사진 삭제",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {jwt_token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "성공시 200 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BasicResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/remvoe-user": {
+            "post": {
+                "description": "회원탈퇴시 호출",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "회원탈퇴 /user"
+                ],
+                "summary": "회원탈퇴",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {jwt_token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "성공시 200 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BasicResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/revoke-role": {
+            "post": {
+                "security": [
+                    {
+                        "jwt": []
+                    }
+                ],
+                "description": "유저의 역할 회수시 호출 (role:manage 권한 필요) - user_type 으로 부여된 기본 역할은 회수 불가, 회수 후 해당 유저의 모든 세션 로그아웃",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "역할 관리 /user"
+                ],
+                "summary": "역할 회수",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {jwt_token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "요청 DTO",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "성공시 200 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BasicResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "권한 없음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "요청 처리 실패시 오류 메시지 반환: 오류메시지 \"last super-admin\" = 마지막 super-admin",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/revoke-session/{id}": {
+            "post": {
+                "security": [
+                    {
+                        "jwt": []
+                    }
+                ],
+                "description": "다른 기기의 세션을 폐기할때 호출",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "세션 /user"
+                ],
+                "summary": "기기 로그아웃",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {jwt_token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "세션ID",
+                        "name": "id",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "성공시 200 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BasicResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/send-code/{number}": {
+            "post": {
+                "description": "인증번호 발송시 호출 - 재발송은 1분 후, 번호당 시간당 5회, IP당 시간당 20회 까지 (이전 인증번호는 무효화)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "인증번호 /user"
+                ],
+                "summary": "인증번호 발송",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "휴대번호",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "성공시 200 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BasicResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "ALREADY_REGISTERED - 이미 가입한 번호",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "TOO_MANY_REQUESTS 발송 제한: retry_after 초 후 재시도 (detail: cooldown, number quota exceeded, ip quota exceeded)",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CooldownResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/set-care-scopes": {
+            "post": {
+                "security": [
+                    {
+                        "jwt": []
+                    }
+                ],
+                "description": "환자가 보호자의 도메인별 권한 변경시 호출",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "보호자 /user"
+                ],
+                "summary": "보호자 권한 변경",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {jwt_token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "요청 DTO - id: 연결ID",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CareScopeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "성공시 200 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BasicResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/set-user": {
+            "post": {
+                "description": "유저 상태영구변경시 호출",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "회원상태 변경(본인)  /user"
+                ],
+                "summary": "유저 데이터 변경",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {jwt_token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "요청 DTO - 업데이트 할 데이터/ ture:남성 user_Type- 0:해당없음 1:파킨슨 환자 2:보호자",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "성공시 200 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BasicResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "UNAUTHORIZED - 토큰이 없거나 유효하지 않음",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "VERIFICATION_REQUIRED - 번호 인증 필요",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sns-login": {
+            "post": {
+                "description": "sns 로그인 성공시 호출",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "로그인 /user"
+                ],
+                "summary": "sns 로그인",
+                "parameters": [
+                    {
+                        "description": "요청 DTO - idToken 필수, user- user_type: 0:해당없음 1:파킨슨 환자 2:보호자 / 최초 로그인 이후 로그인시 fcm_token,device_id 만 필요함",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "성공시 JWT 토큰 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "UNAUTHORIZED - sns 토큰 검증 실패",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "VERIFICATION_REQUIRED - 번호 인증 필요",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "ALREADY_REGISTERED - 이미 가입한 번호",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/verify-code": {
+            "post": {
+                "description": "인증번호 입력 후 호출 (유효시간 3분, 5회 틀리면 인증번호 무효화)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
                     "인증번호 /user"
                 ],
@@ -509,52 +1900,343 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.BasicResponse"
                         }
                     },
-                    "400": {
-                        "description": "요청 처리 실패시 오류 메시지 반환",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
+                    "400": {
+                        "description": "요청 처리 실패시 오류 메시지 반환 (VERIFICATION_MISMATCH - 인증번호 불일치)",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "VERIFICATION_EXPIRED - 만료 또는 시도 횟수 초과 (재발송 필요)",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "요청 처리 실패시 오류 메시지 반환",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.AdminCodeRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "description": "기존 전화번호",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AdminLoginAuditResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "string",
+                    "example": "YYYY-mm-dd HH:mm:ss"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "uid": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.AdminLoginRequest": {
+            "type": "object",
+            "properties": {
+                "device_id": {
+                    "description": "브라우저별 기기 id (없으면 로그인마다 새 세션)",
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "otp_code": {
+                    "description": "TOTP 등록한 관리자만 필요",
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AdminPasswordRequest": {
+            "type": "object",
+            "properties": {
+                "auth_code": {
+                    "description": "비밀번호 미설정 관리자만 (/admin-send-code 로 받은 인증번호)",
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "new_password": {
+                    "type": "string"
+                },
+                "otp_code": {
+                    "type": "string"
+                },
+                "password": {
+                    "description": "비밀번호 미설정 관리자는 기존 전화번호",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AdminResetRequest": {
+            "type": "object",
+            "properties": {
+                "new_password": {
+                    "type": "string"
+                },
+                "uid": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.AppVersionResponse": {
+            "type": "object",
+            "properties": {
+                "android_link": {
+                    "type": "string"
+                },
+                "ios_link": {
+                    "type": "string"
+                },
+                "latest_version": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AutoLoginRequest": {
+            "type": "object",
+            "properties": {
+                "app_version": {
+                    "type": "string",
+                    "example": "1.0.0"
+                },
+                "device_id": {
+                    "type": "string"
+                },
+                "fcm_token": {
+                    "type": "string"
+                },
+                "platform": {
+                    "type": "string",
+                    "example": "ios,android"
+                },
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BasicResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CareAcceptRequest": {
+            "type": "object",
+            "properties": {
+                "invite_code": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CareInviteRequest": {
+            "type": "object",
+            "properties": {
+                "scopes": {
+                    "description": "도메인: medicine, exercise, sleep, diet, emotion / 권한: read, write",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    },
+                    "example": {
+                        "medicine": "write",
+                        "sleep": "read"
+                    }
+                }
+            }
+        },
+        "dto.CareInviteResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "invite_code": {
+                    "type": "string"
+                },
+                "invite_expires": {
+                    "type": "string",
+                    "example": "YYYY-mm-dd HH:mm:ss"
+                }
+            }
+        },
+        "dto.CareLinkResponse": {
+            "type": "object",
+            "properties": {
+                "caregiver_name": {
+                    "type": "string"
+                },
+                "caregiver_uid": {
+                    "type": "integer"
+                },
+                "created": {
+                    "type": "string",
+                    "example": "YYYY-mm-dd HH:mm:ss"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "invite_expires": {
+                    "type": "string"
+                },
+                "patient_name": {
+                    "type": "string"
+                },
+                "patient_uid": {
+                    "type": "integer"
+                },
+                "scopes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "status": {
+                    "description": "0:초대중 1:연결됨",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CareScopeRequest": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "scopes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
                     },
-                    "500": {
-                        "description": "요청 처리 실패시 오류 메시지 반환",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
+                    "example": {
+                        "medicine": "write",
+                        "sleep": "read"
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dto.AppVersionResponse": {
+        },
+        "dto.CooldownResponse": {
             "type": "object",
             "properties": {
-                "android_link": {
+                "code": {
+                    "type": "string",
+                    "example": "TOO_MANY_REQUESTS"
+                },
+                "detail": {
+                    "description": "cooldown, number quota exceeded, ip quota exceeded",
+                    "type": "string",
+                    "example": "cooldown"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "요청이 너무 많아요. 잠시 후 다시 시도해주세요."
+                },
+                "retry_after": {
+                    "type": "integer",
+                    "example": 60
+                }
+            }
+        },
+        "dto.DelegatedWriteResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
                     "type": "string"
                 },
-                "ios_link": {
+                "caregiver_name": {
                     "type": "string"
                 },
-                "latest_version": {
+                "caregiver_uid": {
+                    "type": "integer"
+                },
+                "created": {
+                    "type": "string",
+                    "example": "YYYY-mm-dd HH:mm:ss"
+                },
+                "domain": {
                     "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.AutoLoginRequest": {
+        "dto.DeviceRequest": {
             "type": "object",
             "properties": {
+                "app_version": {
+                    "type": "string",
+                    "example": "1.0.0"
+                },
                 "device_id": {
                     "type": "string"
                 },
                 "fcm_token": {
                     "type": "string"
+                },
+                "platform": {
+                    "type": "string",
+                    "example": "ios,android"
                 }
             }
         },
-        "dto.BasicResponse": {
+        "dto.DeviceResponse": {
             "type": "object",
             "properties": {
-                "code": {
+                "app_version": {
+                    "type": "string"
+                },
+                "current": {
+                    "type": "boolean"
+                },
+                "device_id": {
+                    "type": "string"
+                },
+                "last_seen": {
+                    "type": "string",
+                    "example": "YYYY-mm-dd HH:mm:ss"
+                },
+                "platform": {
                     "type": "string"
                 }
             }
@@ -562,8 +2244,20 @@ const docTemplate = `{
         "dto.ErrorResponse": {
             "type": "object",
             "properties": {
-                "err": {
-                    "type": "string"
+                "code": {
+                    "description": "INVALID_REQUEST(400), UNAUTHORIZED(401), FORBIDDEN(403), NOT_FOUND(404), CONFLICT(409), TOO_MANY_REQUESTS(429), DATABASE_ERROR(500), INTERNAL_ERROR(500), VERIFICATION_REQUIRED(403), ALREADY_REGISTERED(409), VERIFICATION_MISMATCH(400), VERIFICATION_EXPIRED(410)",
+                    "type": "string",
+                    "example": "INVALID_REQUEST"
+                },
+                "detail": {
+                    "description": "원인 (디버깅용, 변경될 수 있음)",
+                    "type": "string",
+                    "example": "invalid date format, should be YYYY-MM-DD"
+                },
+                "message": {
+                    "description": "사용자 안내 문구",
+                    "type": "string",
+                    "example": "요청 값이 올바르지 않아요."
                 }
             }
         },
@@ -600,9 +2294,17 @@ const docTemplate = `{
         "dto.LoginRequest": {
             "type": "object",
             "properties": {
+                "app_version": {
+                    "type": "string",
+                    "example": "1.0.0"
+                },
                 "id_token": {
                     "type": "string"
                 },
+                "platform": {
+                    "type": "string",
+                    "example": "ios,android"
+                },
                 "user": {
                     "$ref": "#/definitions/dto.UserRequest"
                 }
@@ -619,11 +2321,97 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.PoliceResponse": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string"
+                },
+                "police_type": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.RefreshRequest": {
+            "type": "object",
+            "properties": {
+                "device_id": {
+                    "type": "string"
+                },
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.RoleRequest": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "example": "patient, caregiver, clinician, content-admin, super-admin"
+                },
+                "uid": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.SessionResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "string",
+                    "example": "YYYY-mm-dd HH:mm:ss"
+                },
+                "current": {
+                    "type": "boolean"
+                },
+                "device_id": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "YYYY-mm-dd HH:mm:ss"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_used": {
+                    "type": "string",
+                    "example": "YYYY-mm-dd HH:mm:ss"
+                }
+            }
+        },
         "dto.SuccessResponse": {
             "type": "object",
             "properties": {
                 "jwt": {
                     "type": "string"
+                },
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.TotpConfirmRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.TotpEnrollResponse": {
+            "type": "object",
+            "properties": {
+                "secret": {
+                    "type": "string"
+                },
+                "url": {
+                    "description": "otpauth:// QR 코드용",
+                    "type": "string"
                 }
             }
         },
@@ -644,6 +2432,9 @@ const docTemplate = `{
                     "description": "true:남 false: 여",
                     "type": "boolean"
                 },
+                "indemnification_clause": {
+                    "type": "boolean"
+                },
                 "is_first": {
                     "type": "boolean"
                 },
@@ -658,21 +2449,29 @@ const docTemplate = `{
                     "type": "string",
                     "example": "base64 encoding string"
                 },
+                "sns_type": {
+                    "type": "integer"
+                },
+                "time_zone": {
+                    "description": "IANA 시간대",
+                    "type": "string",
+                    "example": "Asia/Seoul"
+                },
                 "use_auto_login": {
                     "type": "boolean"
                 },
-                "use_services": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
-                },
                 "use_sleep_tracking": {
                     "type": "boolean"
                 },
                 "user_privacy_protection": {
                     "type": "boolean"
                 },
+                "user_services": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
                 "user_type": {
                     "type": "integer"
                 }
@@ -702,6 +2501,9 @@ const docTemplate = `{
                     "description": "true:남 false: 여",
                     "type": "boolean"
                 },
+                "indemnification_clause": {
+                    "type": "boolean"
+                },
                 "is_first": {
                     "type": "boolean"
                 },
@@ -721,9 +2523,19 @@ const docTemplate = `{
                 "profile_image": {
                     "$ref": "#/definitions/dto.ImageResponse"
                 },
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "sns_type": {
                     "type": "integer"
                 },
+                "time_zone": {
+                    "type": "string",
+                    "example": "Asia/Seoul"
+                },
                 "updated": {
                     "type": "string",
                     "example": "YYYY-mm-ddTHH:mm:ss "
@@ -731,18 +2543,18 @@ const docTemplate = `{
                 "use_auto_login": {
                     "type": "boolean"
                 },
-                "use_services": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.MainServiceResponse"
-                    }
-                },
                 "use_sleep_tracking": {
                     "type": "boolean"
                 },
                 "user_privacy_protection": {
                     "type": "boolean"
                 },
+                "user_services": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.MainServiceResponse"
+                    }
+                },
                 "user_type": {
                     "type": "integer"
                 }
@@ -760,6 +2572,46 @@ const docTemplate = `{
                     "example": "01000000000"
                 }
             }
+        },
+        "util.JWK": {
+            "type": "object",
+            "properties": {
+                "alg": {
+                    "type": "string"
+                },
+                "crv": {
+                    "type": "string"
+                },
+                "e": {
+                    "type": "string"
+                },
+                "kid": {
+                    "type": "string"
+                },
+                "kty": {
+                    "type": "string"
+                },
+                "n": {
+                    "type": "string"
+                },
+                "use": {
+                    "type": "string"
+                },
+                "x": {
+                    "type": "string"
+                }
+            }
+        },
+        "util.JWKS": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/util.JWK"
+                    }
+                }
+            }
         }
     }
 }`
@@ -17,6 +17,7 @@ type UserRequest struct {
 	UsePrivacyProtection  *bool  `json:"user_privacy_protection"`
 	UseSleepTracking      *bool  `json:"use_sleep_tracking"`
 	UserType              *uint  `json:"user_type"`
+	TimeZone              string `json:"time_zone" example:"Asia/Seoul"` // IANA 시간대
 	UserServices          []int  `json:"user_services"`
 	ProfileImage          string `json:"profile_image" example:"base64 encoding string"`
 }
@@ -34,6 +35,7 @@ type UserResponse struct {
 	UsePrivacyProtection  bool                  `json:"user_privacy_protection"`
 	UseSleepTracking      bool                  `json:"use_sleep_tracking"`
 	UserType              uint                  `json:"user_type"`
+	TimeZone              string                `json:"time_zone" example:"Asia/Seoul"`
 	SnsType               uint                  `json:"sns_type"`
 	Email                 string                `json:"email"`
 	Created               string                `json:"created" example:"YYYY-mm-ddTHH:mm:ss "`
@@ -41,6 +43,7 @@ type UserResponse struct {
 	UserServices          []MainServiceResponse `json:"user_services"`
 	ProfileImage          ImageResponse         `json:"profile_image"`
 	LinkedEmails          []LinkedResponse      `json:"linked_emails"`
+	Roles                 []string              `json:"roles"`
 }
 
 // func (r *UserResponse) MarshalJSON() ([]byte, error) {
@@ -88,12 +91,15 @@ type TempUser struct {
 	UsePrivacyProtection  bool   `json:"user_privacy_protection"`
 	UseSleepTracking      bool   `json:"use_sleep_tracking"`
 	UserType              uint   `json:"user_type"`
+	TimeZone              string `json:"time_zone"`
 	UserServices          []int  `json:"user_services"`
 }
 
 type LoginRequest struct {
 	IdToken     string      `json:"id_token"`
 	UserRequest UserRequest `json:"user"`
+	Platform    string      `json:"platform" example:"ios,android"`
+	AppVersion  string      `json:"app_version" example:"1.0.0"`
 }
 
 type VerifyRequest struct {
@@ -119,23 +125,170 @@ type PoliceResponse struct {
 }
 
 type LoginResponse struct {
-	Jwt string `json:"jwt,omitempty"`
-	Err string `json:"err,omitempty"`
+	Jwt          string `json:"jwt,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	Err          string `json:"err,omitempty"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+	DeviceId     string `json:"device_id"`
+}
+
+type SessionResponse struct {
+	Id        uint   `json:"id"`
+	DeviceId  string `json:"device_id"`
+	Current   bool   `json:"current"`
+	LastUsed  string `json:"last_used" example:"YYYY-mm-dd HH:mm:ss"`
+	ExpiresAt string `json:"expires_at" example:"YYYY-mm-dd HH:mm:ss"`
+	Created   string `json:"created" example:"YYYY-mm-dd HH:mm:ss"`
 }
 
 type AutoLoginRequest struct {
-	Email    string `json:"-"`
-	FcmToken string `json:"fcm_token"`
-	DeviceId string `json:"device_id"`
+	RefreshToken string `json:"refresh_token"`
+	FcmToken     string `json:"fcm_token"`
+	DeviceId     string `json:"device_id"`
+	Platform     string `json:"platform" example:"ios,android"`
+	AppVersion   string `json:"app_version" example:"1.0.0"`
+}
+
+type DeviceRequest struct {
+	Uid        uint   `json:"-"`
+	DeviceId   string `json:"device_id"`
+	FcmToken   string `json:"fcm_token"`
+	Platform   string `json:"platform" example:"ios,android"`
+	AppVersion string `json:"app_version" example:"1.0.0"`
+}
+
+type DeviceResponse struct {
+	DeviceId   string `json:"device_id"`
+	Platform   string `json:"platform"`
+	AppVersion string `json:"app_version"`
+	LastSeen   string `json:"last_seen" example:"YYYY-mm-dd HH:mm:ss"`
+	Current    bool   `json:"current"`
 }
 
 type SuccessResponse struct {
-	Jwt string `json:"jwt"`
+	Jwt          string `json:"jwt"`
+	RefreshToken string `json:"refresh_token"`
 }
+
+// 오류 응답 - code 로 분기하고 message 는 사용자에게 표시 (Accept-Language: en 이면 영어)
 type ErrorResponse struct {
-	Err string `json:"err"`
+	Code    string `json:"code" example:"INVALID_REQUEST"`                                       // INVALID_REQUEST(400), UNAUTHORIZED(401), FORBIDDEN(403), NOT_FOUND(404), CONFLICT(409), TOO_MANY_REQUESTS(429), DATABASE_ERROR(500), INTERNAL_ERROR(500), VERIFICATION_REQUIRED(403), ALREADY_REGISTERED(409), VERIFICATION_MISMATCH(400), VERIFICATION_EXPIRED(410)
+	Message string `json:"message" example:"요청 값이 올바르지 않아요."`                                    // 사용자 안내 문구
+	Detail  string `json:"detail,omitempty" example:"invalid date format, should be YYYY-MM-DD"` // 원인 (디버깅용, 변경될 수 있음)
 }
 
 type BasicResponse struct {
 	Code string `json:"code"`
 }
+
+// 발송 제한 응답 - ErrorResponse 에 재시도 시간 추가
+type CooldownResponse struct {
+	Code       string `json:"code" example:"TOO_MANY_REQUESTS"`
+	Message    string `json:"message" example:"요청이 너무 많아요. 잠시 후 다시 시도해주세요."`
+	Detail     string `json:"detail,omitempty" example:"cooldown"` // cooldown, number quota exceeded, ip quota exceeded
+	RetryAfter int    `json:"retry_after" example:"60"`
+}
+
+type RoleRequest struct {
+	Uid       uint   `json:"uid"`
+	Role      string `json:"role" example:"patient, caregiver, clinician, content-admin, super-admin"`
+	GrantedBy uint   `json:"-"`
+}
+
+type CareInviteRequest struct {
+	Uid    uint              `json:"-"`
+	Scopes map[string]string `json:"scopes" example:"medicine:write,sleep:read"` // 도메인: medicine, exercise, sleep, diet, emotion / 권한: read, write
+}
+
+type CareInviteResponse struct {
+	Id            uint   `json:"id"`
+	InviteCode    string `json:"invite_code"`
+	InviteExpires string `json:"invite_expires" example:"YYYY-mm-dd HH:mm:ss"`
+}
+
+type CareAcceptRequest struct {
+	Uid        uint   `json:"-"`
+	InviteCode string `json:"invite_code"`
+}
+
+type CareScopeRequest struct {
+	Uid    uint              `json:"-"`
+	Id     uint              `json:"id"`
+	Scopes map[string]string `json:"scopes" example:"medicine:write,sleep:read"`
+}
+
+type CareLinkResponse struct {
+	Id            uint              `json:"id"`
+	PatientUid    uint              `json:"patient_uid"`
+	PatientName   string            `json:"patient_name"`
+	CaregiverUid  uint              `json:"caregiver_uid"`
+	CaregiverName string            `json:"caregiver_name"`
+	Status        uint              `json:"status"` // 0:초대중 1:연결됨
+	Scopes        map[string]string `json:"scopes"`
+	InviteExpires string            `json:"invite_expires"`
+	Created       string            `json:"created" example:"YYYY-mm-dd HH:mm:ss"`
+}
+
+type DelegatedWriteResponse struct {
+	Id            uint   `json:"id"`
+	CaregiverUid  uint   `json:"caregiver_uid"`
+	CaregiverName string `json:"caregiver_name"`
+	Domain        string `json:"domain"`
+	Action        string `json:"action"`
+	Status        int    `json:"status"`
+	Created       string `json:"created" example:"YYYY-mm-dd HH:mm:ss"`
+}
+
+type GetPageParams struct {
+	Page uint `form:"page"`
+}
+
+type AdminLoginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+	OtpCode  string `json:"otp_code"`  // TOTP 등록한 관리자만 필요
+	DeviceId string `json:"device_id"` // 브라우저별 기기 id (없으면 로그인마다 새 세션)
+	Ip       string `json:"-"`
+}
+
+type AdminPasswordRequest struct {
+	Email       string `json:"email"`
+	Password    string `json:"password"` // 비밀번호 미설정 관리자는 기존 전화번호
+	NewPassword string `json:"new_password"`
+	OtpCode     string `json:"otp_code"`
+	AuthCode    string `json:"auth_code"` // 비밀번호 미설정 관리자만 (/admin-send-code 로 받은 인증번호)
+	Ip          string `json:"-"`
+}
+
+type AdminCodeRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"` // 기존 전화번호
+	Ip       string `json:"-"`
+}
+
+type AdminResetRequest struct {
+	Uid         uint   `json:"uid"`
+	NewPassword string `json:"new_password"`
+}
+
+type TotpEnrollResponse struct {
+	Secret string `json:"secret"`
+	Url    string `json:"url"` // otpauth:// QR 코드용
+}
+
+type TotpConfirmRequest struct {
+	Code string `json:"code"`
+}
+
+type AdminLoginAuditResponse struct {
+	Id      uint   `json:"id"`
+	Uid     uint   `json:"uid"`
+	Email   string `json:"email"`
+	Ip      string `json:"ip"`
+	Success bool   `json:"success"`
+	Reason  string `json:"reason"`
+	Created string `json:"created" example:"YYYY-mm-dd HH:mm:ss"`
+}
@@ -12,16 +12,13 @@ import (
 
 func MakeAdminLoginEndpoint(s service.UserService) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
-		reqMap := request.(map[string]interface{})
-		email := reqMap["email"].(string)
-		password := reqMap["password"].(string)
-
-		token, err := s.AdminLogin(email, password)
+		req := request.(dto.AdminLoginRequest)
+		token, err := s.AdminLogin(req)
 
 		if err != nil {
 			return dto.LoginResponse{Err: err.Error()}, err
 		}
-		return dto.LoginResponse{Jwt: token}, nil
+		return token, nil
 	}
 }
 
@@ -32,7 +29,7 @@ func MakeAutoLoginEndpoint(s service.UserService) endpoint.Endpoint {
 		if err != nil {
 			return dto.LoginResponse{Err: err.Error()}, err
 		}
-		return dto.LoginResponse{Jwt: token}, nil
+		return token, nil
 	}
 }
 
@@ -49,11 +46,11 @@ func MakeGetUserEndpoint(s service.UserService) endpoint.Endpoint {
 func MakeSnsLoginEndpoint(s service.UserService) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(dto.LoginRequest)
-		token, err := s.SnsLogin(req.IdToken, req.UserRequest)
+		token, err := s.SnsLogin(req)
 		if err != nil {
 			return dto.LoginResponse{Err: err.Error()}, err
 		}
-		return dto.LoginResponse{Jwt: token}, nil
+		return token, nil
 	}
 }
 
@@ -80,8 +77,10 @@ func GetMainServicesEndpoint(s service.UserService) endpoint.Endpoint {
 
 func SendCodeEndpoint(s service.UserService) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
-		number := request.(string)
-		code, err := s.SendAuthCode(number)
+		reqMap := request.(map[string]interface{})
+		number := reqMap["number"].(string)
+		ip := reqMap["ip"].(string)
+		code, err := s.SendAuthCode(number, ip)
 		if err != nil {
 			return dto.BasicResponse{Code: err.Error()}, err
 		}
@@ -154,3 +153,245 @@ func RemoveProfileEndpoint(s service.UserService) endpoint.Endpoint {
 		return dto.BasicResponse{Code: code}, nil
 	}
 }
+
+func RefreshTokenEndpoint(s service.UserService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(dto.RefreshRequest)
+		token, err := s.RefreshToken(req.RefreshToken, req.DeviceId)
+		if err != nil {
+			return dto.LoginResponse{Err: err.Error()}, err
+		}
+		return token, nil
+	}
+}
+
+func GetSessionsEndpoint(s service.UserService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		reqMap := request.(map[string]interface{})
+		uid := reqMap["uid"].(uint)
+		sid := reqMap["sid"].(uint)
+		sessions, err := s.GetSessions(uid, sid)
+		if err != nil {
+			return dto.BasicResponse{Code: err.Error()}, err
+		}
+		return sessions, nil
+	}
+}
+
+func RevokeSessionEndpoint(s service.UserService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		reqMap := request.(map[string]interface{})
+		uid := reqMap["uid"].(uint)
+		sid := reqMap["sid"].(uint)
+		code, err := s.RevokeSession(uid, sid)
+		if err != nil {
+			return dto.BasicResponse{Code: err.Error()}, err
+		}
+		return dto.BasicResponse{Code: code}, nil
+	}
+}
+
+func GetRevokedSessionsEndpoint(s service.UserService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		ids, err := s.GetRevokedSessions()
+		if err != nil {
+			return dto.BasicResponse{Code: err.Error()}, err
+		}
+		return ids, nil
+	}
+}
+
+func GetUserRolesEndpoint(s service.UserService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		uid := request.(uint)
+		roles, err := s.GetUserRoles(uid)
+		if err != nil {
+			return dto.BasicResponse{Code: err.Error()}, err
+		}
+		return roles, nil
+	}
+}
+
+func GrantRoleEndpoint(s service.UserService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(dto.RoleRequest)
+		code, err := s.GrantRole(req)
+		if err != nil {
+			return dto.BasicResponse{Code: err.Error()}, err
+		}
+		return dto.BasicResponse{Code: code}, nil
+	}
+}
+
+func RevokeRoleEndpoint(s service.UserService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(dto.RoleRequest)
+		code, err := s.RevokeRole(req)
+		if err != nil {
+			return dto.BasicResponse{Code: err.Error()}, err
+		}
+		return dto.BasicResponse{Code: code}, nil
+	}
+}
+
+func InviteCaregiverEndpoint(s service.UserService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(dto.CareInviteRequest)
+		invite, err := s.InviteCaregiver(req)
+		if err != nil {
+			return dto.BasicResponse{Code: err.Error()}, err
+		}
+		return invite, nil
+	}
+}
+
+func AcceptCareEndpoint(s service.UserService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(dto.CareAcceptRequest)
+		code, err := s.AcceptCare(req)
+		if err != nil {
+			return dto.BasicResponse{Code: err.Error()}, err
+		}
+		return dto.BasicResponse{Code: code}, nil
+	}
+}
+
+func SetCareScopesEndpoint(s service.UserService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(dto.CareScopeRequest)
+		code, err := s.SetCareScopes(req)
+		if err != nil {
+			return dto.BasicResponse{Code: err.Error()}, err
+		}
+		return dto.BasicResponse{Code: code}, nil
+	}
+}
+
+func RemoveCareEndpoint(s service.UserService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		reqMap := request.(map[string]interface{})
+		uid := reqMap["uid"].(uint)
+		id := reqMap["id"].(uint)
+		code, err := s.RemoveCare(uid, id)
+		if err != nil {
+			return dto.BasicResponse{Code: err.Error()}, err
+		}
+		return dto.BasicResponse{Code: code}, nil
+	}
+}
+
+func GetCaresEndpoint(s service.UserService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		uid := request.(uint)
+		cares, err := s.GetCares(uid)
+		if err != nil {
+			return dto.BasicResponse{Code: err.Error()}, err
+		}
+		return cares, nil
+	}
+}
+
+func GetCareAuditsEndpoint(s service.UserService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		reqMap := request.(map[string]interface{})
+		uid := reqMap["uid"].(uint)
+		page := reqMap["page"].(uint)
+		audits, err := s.GetCareAudits(uid, page)
+		if err != nil {
+			return dto.BasicResponse{Code: err.Error()}, err
+		}
+		return audits, nil
+	}
+}
+
+func SendAdminAuthCodeEndpoint(s service.UserService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(dto.AdminCodeRequest)
+		code, err := s.SendAdminAuthCode(req)
+		if err != nil {
+			return dto.BasicResponse{Code: err.Error()}, err
+		}
+		return dto.BasicResponse{Code: code}, nil
+	}
+}
+
+func ChangeAdminPasswordEndpoint(s service.UserService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(dto.AdminPasswordRequest)
+		code, err := s.ChangeAdminPassword(req)
+		if err != nil {
+			return dto.BasicResponse{Code: err.Error()}, err
+		}
+		return dto.BasicResponse{Code: code}, nil
+	}
+}
+
+func ResetAdminPasswordEndpoint(s service.UserService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(dto.AdminResetRequest)
+		code, err := s.ResetAdminPassword(req)
+		if err != nil {
+			return dto.BasicResponse{Code: err.Error()}, err
+		}
+		return dto.BasicResponse{Code: code}, nil
+	}
+}
+
+func EnrollTOTPEndpoint(s service.UserService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		uid := request.(uint)
+		enroll, err := s.EnrollTOTP(uid)
+		if err != nil {
+			return dto.BasicResponse{Code: err.Error()}, err
+		}
+		return enroll, nil
+	}
+}
+
+func ConfirmTOTPEndpoint(s service.UserService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		reqMap := request.(map[string]interface{})
+		uid := reqMap["uid"].(uint)
+		code := reqMap["code"].(string)
+		result, err := s.ConfirmTOTP(uid, code)
+		if err != nil {
+			return dto.BasicResponse{Code: err.Error()}, err
+		}
+		return dto.BasicResponse{Code: result}, nil
+	}
+}
+
+func GetAdminLoginAuditsEndpoint(s service.UserService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		page := request.(uint)
+		audits, err := s.GetAdminLoginAudits(page)
+		if err != nil {
+			return dto.BasicResponse{Code: err.Error()}, err
+		}
+		return audits, nil
+	}
+}
+
+func RegisterDeviceEndpoint(s service.UserService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(dto.DeviceRequest)
+		code, err := s.RegisterDevice(req)
+		if err != nil {
+			return dto.BasicResponse{Code: err.Error()}, err
+		}
+		return dto.BasicResponse{Code: code}, nil
+	}
+}
+
+func GetDevicesEndpoint(s service.UserService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		reqMap := request.(map[string]interface{})
+		uid := reqMap["uid"].(uint)
+		sid := reqMap["sid"].(uint)
+		devices, err := s.GetDevices(uid, sid)
+		if err != nil {
+			return dto.BasicResponse{Code: err.Error()}, err
+		}
+		return devices, nil
+	}
+}
@@ -4,7 +4,6 @@ package main
 
 import (
 	"log"
-	"net/http"
 	"os"
 	"strings"
 	"sync"
@@ -27,28 +26,31 @@ import (
 
 	ginSwagger "github.com/swaggo/gin-swagger"
 
+	"github.com/disterbia/wellkinson/common/model"
+	"github.com/disterbia/wellkinson/common/util"
 	swaggerFiles "github.com/swaggo/files"
 )
 
 var ipLimiters = make(map[string]*rate.Limiter)
 var ipLimitersMutex sync.Mutex
 
-func getClientIP(c *gin.Context) string {
-	// X-Real-IP 헤더를 확인
-	if ip := c.GetHeader("X-Real-IP"); ip != "" {
-		return ip
+// 신뢰할 프록시 (게이트웨이 주소) - 이 주소에서 온 요청만 X-Forwarded-For 로 클라이언트 IP 확인
+func trustedProxies() []string {
+	var proxies []string
+	for _, v := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			proxies = append(proxies, v)
+		}
 	}
-	// X-Forwarded-For 헤더를 확인
-	if ip := c.GetHeader("X-Forwarded-For"); ip != "" {
-		return strings.Split(ip, ",")[0] // 여러 IP가 쉼표로 구분되어 있을 수 있음
+	if len(proxies) == 0 {
+		log.Println("TRUSTED_PROXIES not set, client ip is the remote address")
 	}
-	// 헤더가 없는 경우 Gin의 기본 메서드 사용
-	return c.ClientIP()
+	return proxies
 }
 
 func RateLimitMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ip := getClientIP(c)
+		ip := c.ClientIP()
 
 		// IP별 리미터가 있는지 확인
 		ipLimitersMutex.Lock()
@@ -62,7 +64,7 @@ func RateLimitMiddleware() gin.HandlerFunc {
 
 		// 요청 허용 여부 확인
 		if !limiter.Allow() {
-			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "요청 횟수 초과"})
+			util.AbortError(c, util.NewError(util.ErrTooManyRequests, "rate limit exceeded"))
 			return
 		}
 
@@ -75,10 +77,44 @@ func main() {
 	if err != nil {
 		log.Println("Error loading .env file")
 	}
+	// 토큰 서명키 로드
+	if err := util.LoadSigningKeys(); err != nil {
+		log.Fatalf("failed to load signing keys: %v", err)
+	}
+
 	dbPath := os.Getenv("DB_PATH")
 	database, err := db.NewDB(dbPath)
 	if err != nil {
 		log.Println("Database connection error:", err)
+	} else {
+		hasDevices := database.Migrator().HasTable(&model.Device{})
+		if err := database.AutoMigrate(&model.Session{}, &model.UserRole{}, &model.CareLink{}, &model.DelegatedWrite{},
+			&model.AdminCredential{}, &model.AdminLoginAudit{}, &model.AuthCode{}, &model.MessageDelivery{}, &model.Device{}); err != nil {
+			log.Println("migration error:", err)
+		}
+		// 기기 테이블 최초 생성시 기존 유저의 fcm 토큰을 기기로 이전
+		if !hasDevices {
+			now := time.Now().In(model.ServerLocation).Format("2006-01-02 15:04:05")
+			if err := database.Exec(`INSERT INTO devices (uid, device_id, fcm_token, platform, app_version, last_seen, created, updated)
+				SELECT id, device_id, fcm_token, '', '', ?, ?, ? FROM users WHERE fcm_token <> '' AND device_id <> ''
+				ON CONFLICT DO NOTHING`, now, now, now).Error; err != nil {
+				log.Println("device migration error:", err)
+			}
+		}
+		// 유저 시간대 컬럼 추가 (기존 유저는 기본값 Asia/Seoul)
+		if !database.Migrator().HasColumn(&model.User{}, "TimeZone") {
+			if err := database.Migrator().AddColumn(&model.User{}, "TimeZone"); err != nil {
+				log.Println("time_zone migration error:", err)
+			}
+		}
+		// 기존 is_admin 유저는 super-admin 역할로 이전
+		now := time.Now().In(model.ServerLocation).Format("2006-01-02 15:04:05")
+		if err := database.Exec(`INSERT INTO user_roles (uid, role, granted_by, created, updated)
+			SELECT u.id, ?, 0, ?, ? FROM users u WHERE u.is_admin AND NOT EXISTS
+			(SELECT 1 FROM user_roles r WHERE r.uid = u.id AND r.role IN ?)`,
+			util.RoleSuperAdmin, now, now, []string{util.RoleContentAdmin, util.RoleSuperAdmin}).Error; err != nil {
+			log.Println("admin role migration error:", err)
+		}
 	}
 
 	accessKey := os.Getenv("S3_ACCESS_KEY")
@@ -94,7 +130,22 @@ func main() {
 	}
 
 	s3svc := s3.New(s3sess)
-	usvc := service.NewUserService(database, s3svc, bucket, bucketUrl)
+	// 문자/알림톡 발송기 (MESSAGE_PROVIDER)
+	sender, err := util.NewMessageSender()
+	if err != nil {
+		log.Fatalf("failed to create message sender: %v", err)
+	}
+	usvc := service.NewUserService(database, s3svc, bucket, bucketUrl, sender)
+	// 실패한 문자 발송 재시도
+	go func() {
+		ticker := time.NewTicker(15 * time.Second)
+		defer ticker.Stop()
+		for range ticker.C {
+			usvc.RetryMessages()
+		}
+	}()
+	// 폐기된 세션의 access 토큰 차단
+	util.SetRevocationChecker(usvc.IsSessionRevoked)
 
 	adminLoginEndpoint := endpoint.MakeAdminLoginEndpoint(usvc)
 	snsLoginEndpoint := endpoint.MakeSnsLoginEndpoint(usvc)
@@ -109,12 +160,43 @@ func main() {
 	removeEndpoint := endpoint.RemoveEndpoint(usvc)
 	linkEndpoint := endpoint.LinkEndpoint(usvc)
 	removeProfileEndpoint := endpoint.RemoveProfileEndpoint(usvc)
+	refreshTokenEndpoint := endpoint.RefreshTokenEndpoint(usvc)
+	getSessionsEndpoint := endpoint.GetSessionsEndpoint(usvc)
+	revokeSessionEndpoint := endpoint.RevokeSessionEndpoint(usvc)
+	getRevokedSessionsEndpoint := endpoint.GetRevokedSessionsEndpoint(usvc)
+	getUserRolesEndpoint := endpoint.GetUserRolesEndpoint(usvc)
+	grantRoleEndpoint := endpoint.GrantRoleEndpoint(usvc)
+	revokeRoleEndpoint := endpoint.RevokeRoleEndpoint(usvc)
+	inviteCaregiverEndpoint := endpoint.InviteCaregiverEndpoint(usvc)
+	acceptCareEndpoint := endpoint.AcceptCareEndpoint(usvc)
+	setCareScopesEndpoint := endpoint.SetCareScopesEndpoint(usvc)
+	removeCareEndpoint := endpoint.RemoveCareEndpoint(usvc)
+	getCaresEndpoint := endpoint.GetCaresEndpoint(usvc)
+	getCareAuditsEndpoint := endpoint.GetCareAuditsEndpoint(usvc)
+	sendAdminAuthCodeEndpoint := endpoint.SendAdminAuthCodeEndpoint(usvc)
+	changeAdminPasswordEndpoint := endpoint.ChangeAdminPasswordEndpoint(usvc)
+	resetAdminPasswordEndpoint := endpoint.ResetAdminPasswordEndpoint(usvc)
+	enrollTOTPEndpoint := endpoint.EnrollTOTPEndpoint(usvc)
+	confirmTOTPEndpoint := endpoint.ConfirmTOTPEndpoint(usvc)
+	getAdminLoginAuditsEndpoint := endpoint.GetAdminLoginAuditsEndpoint(usvc)
+	registerDeviceEndpoint := endpoint.RegisterDeviceEndpoint(usvc)
+	getDevicesEndpoint := endpoint.GetDevicesEndpoint(usvc)
 
 	router := gin.Default()
+	// 발송 제한, 로그인 제한의 IP 는 게이트웨이가 넘겨준 값만 사용
+	if err := router.SetTrustedProxies(trustedProxies()); err != nil {
+		log.Fatalf("invalid TRUSTED_PROXIES: %v", err)
+	}
 	router.Use(cors.Default())
 	rateLimiterMiddleware := RateLimitMiddleware()
-
-	router.POST("/admin-login", transport.AdminLoginHandler(adminLoginEndpoint))
+	roleManager := util.RequirePermission(util.PermRoleManage)
+
+	router.POST("/admin-login", rateLimiterMiddleware, transport.AdminLoginHandler(adminLoginEndpoint))
+	router.POST("/admin-send-code", rateLimiterMiddleware, transport.SendAdminAuthCodeHandler(sendAdminAuthCodeEndpoint))
+	router.POST("/admin-change-password", rateLimiterMiddleware, transport.ChangeAdminPasswordHandler(changeAdminPasswordEndpoint))
+	router.POST("/admin-reset-password", roleManager, transport.ResetAdminPasswordHandler(resetAdminPasswordEndpoint))
+	router.POST("/admin-totp-enroll", transport.EnrollTOTPHandler(enrollTOTPEndpoint))
+	router.POST("/admin-totp-confirm", transport.ConfirmTOTPHandler(confirmTOTPEndpoint))
 	router.POST("/sns-login", transport.SnsLoginHandler(snsLoginEndpoint))
 	router.POST("/auto-login", transport.AutoLoginHandler(autoLoginEndpoint))
 	router.POST("/set-user", transport.SetUserHandler(setUserEndpoint))
@@ -123,12 +205,30 @@ func main() {
 	router.POST("/remove-user", transport.RemoveHandler(removeEndpoint))
 	router.POST("/link-email", transport.LinkHandler(linkEndpoint))
 	router.POST("/remove-profile", transport.RemoveProfileHandler(removeProfileEndpoint))
+	router.POST("/refresh-token", transport.RefreshTokenHandler(refreshTokenEndpoint))
+	router.POST("/revoke-session/:id", transport.RevokeSessionHandler(revokeSessionEndpoint))
+	router.POST("/logout", transport.LogoutHandler(revokeSessionEndpoint))
+	router.POST("/register-device", transport.RegisterDeviceHandler(registerDeviceEndpoint))
+	router.POST("/grant-role", roleManager, transport.GrantRoleHandler(grantRoleEndpoint))
+	router.POST("/revoke-role", roleManager, transport.RevokeRoleHandler(revokeRoleEndpoint))
+	router.POST("/invite-caregiver", transport.InviteCaregiverHandler(inviteCaregiverEndpoint))
+	router.POST("/accept-care", transport.AcceptCareHandler(acceptCareEndpoint))
+	router.POST("/set-care-scopes", transport.SetCareScopesHandler(setCareScopesEndpoint))
+	router.POST("/remove-care/:id", transport.RemoveCareHandler(removeCareEndpoint))
 
 	router.GET("/get-user", transport.GetUserHandler(getUserEndpoint))
 	router.GET("/get-polices", transport.GetPolicesHandeler(getpolicesEndpoint))
 	router.GET("/get-version", transport.GetVersionHandeler(getversionEndpoint))
 	router.GET("/get-services", transport.GetMainServicesHandeler(getMainServicesEndpoint))
-
+	router.GET("/get-sessions", transport.GetSessionsHandler(getSessionsEndpoint))
+	router.GET("/get-devices", transport.GetDevicesHandler(getDevicesEndpoint))
+	router.GET("/get-cares", transport.GetCaresHandler(getCaresEndpoint))
+	router.GET("/get-care-audits", transport.GetCareAuditsHandler(getCareAuditsEndpoint))
+	router.GET("/admin-login-audits", roleManager, transport.GetAdminLoginAuditsHandler(getAdminLoginAuditsEndpoint))
+	router.GET("/get-roles/:id", roleManager, transport.GetUserRolesHandler(getUserRolesEndpoint))
+	router.GET("/revoked-sessions", transport.GetRevokedSessionsHandler(getRevokedSessionsEndpoint))
+
+	router.GET("/.well-known/jwks.json", transport.GetJWKSHandler())
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 	router.Run(":44409")
 	// router.RunTLS(":8080", "cert.pem", "key.pem")
@@ -0,0 +1,339 @@
+// /user-service/service/admin.go
+
+package service
+
+import (
+	"crypto/subtle"
+	"log"
+	"time"
+	"user-service/dto"
+
+	"github.com/disterbia/wellkinson/common/model"
+	"github.com/disterbia/wellkinson/common/util"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	adminMaxFailures       = 5
+	adminLockDuration      = 15 * time.Minute
+	adminMinPasswordLength = 10
+	totpIssuer             = "Wellkinson Admin"
+)
+
+// 로그인 실패 응답 - 계정이 없거나 관리자가 아니어도 비밀번호 불일치와 같은 응답
+var errAdminCredentials = util.NewError(util.ErrUnauthorized, "invalid credentials")
+
+// 비밀번호 미설정 관리자 (기존 방식 확인 후 비밀번호 변경 필요)
+const adminPasswordNotSet = "password not set"
+
+// 없는 계정도 비밀번호 확인 시간이 같도록 비교할 해시
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("wellkinson-dummy-password"), bcrypt.DefaultCost)
+
+// 관리자 유저와 로그인 정보 조회 (로그인 정보가 없으면 생성)
+func (service *userService) findAdmin(email string) (model.User, model.AdminCredential, error) {
+	var u model.User
+	if err := service.db.Where("email = ?", email).First(&u).Error; err != nil {
+		return model.User{}, model.AdminCredential{}, util.NewError(util.ErrUnauthorized, "unknown account")
+	}
+	return service.findAdminById(u)
+}
+
+func (service *userService) findAdminById(u model.User) (model.User, model.AdminCredential, error) {
+	roles, err := userRoles(service.db, u)
+	if err != nil {
+		return model.User{}, model.AdminCredential{}, util.NewError(util.ErrDatabase, "db error")
+	}
+	if !hasAdminRole(roles) {
+		return model.User{}, model.AdminCredential{}, util.NewError(util.ErrForbidden, "not admin")
+	}
+
+	credential := model.AdminCredential{Uid: u.Id}
+	if err := service.db.Where(model.AdminCredential{Uid: u.Id}).FirstOrCreate(&credential).Error; err != nil {
+		return model.User{}, model.AdminCredential{}, util.NewError(util.ErrDatabase, "db error2")
+	}
+	return u, credential, nil
+}
+
+// 로그인, 비밀번호 변경 실패를 앱에 돌려줄 오류로 변환 (기록에는 원래 사유를 남김)
+// 비밀번호 미설정 외의 인증 실패는 잠금을 포함해 모두 같은 오류라 계정 존재 여부나 OTP 단계 도달 여부를 알 수 없음
+// (잠금은 있는 관리자 계정에만 기록되므로 잠금을 알려주면 계정이 있다는 뜻이 됨)
+func adminAuthError(err error) error {
+	appErr := util.ToAppError(err)
+	switch {
+	case appErr.Code == util.ErrForbidden, appErr.Code == util.ErrTooManyRequests:
+		return errAdminCredentials
+	case appErr.Code == util.ErrUnauthorized && appErr.Detail != adminPasswordNotSet:
+		return errAdminCredentials
+	}
+	return err
+}
+
+func adminLocked(credential model.AdminCredential, now time.Time) bool {
+	return credential.LockedUntil != "" && credential.LockedUntil > now.Format(timeLayout)
+}
+
+// 비밀번호, OTP 확인 (실패 누적시 잠금)
+func (service *userService) verifyAdmin(u model.User, credential *model.AdminCredential, password, otpCode string) error {
+	now := time.Now().In(model.ServerLocation)
+	if adminLocked(*credential, now) {
+		return util.NewError(util.ErrTooManyRequests, "locked")
+	}
+
+	if credential.PasswordHash == "" {
+		// 비밀번호 미설정 관리자는 기존 방식(전화번호)이 맞을때만 비밀번호 변경 안내
+		if u.PhoneNum == "" || subtle.ConstantTimeCompare([]byte(password), []byte(u.PhoneNum)) != 1 {
+			bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(password))
+			return service.adminFailure(credential, "invalid credentials")
+		}
+		return util.NewError(util.ErrUnauthorized, adminPasswordNotSet)
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(credential.PasswordHash), []byte(password)); err != nil {
+		return service.adminFailure(credential, "invalid credentials")
+	}
+
+	if credential.TotpEnabled {
+		step, ok := util.VerifyTOTP(credential.TotpSecret, otpCode, credential.TotpLastStep)
+		if !ok {
+			return service.adminFailure(credential, "invalid otp")
+		}
+		credential.TotpLastStep = step
+	}
+
+	// 확인하는 동안 다른 요청의 실패로 잠겼다면 성공 처리하지 않음
+	credential.FailedAttempts = 0
+	credential.LockedUntil = ""
+	result := service.db.Model(credential).Where("locked_until = '' OR locked_until <= ?", now.Format(timeLayout)).
+		Select("failed_attempts", "locked_until", "totp_last_step").Updates(credential)
+	if result.Error != nil {
+		return util.NewError(util.ErrDatabase, "db error")
+	}
+	if result.RowsAffected == 0 {
+		return util.NewError(util.ErrTooManyRequests, "locked")
+	}
+	return nil
+}
+
+// 실패 횟수는 한 문장에서 증가시키고 잠금까지 처리 - 동시에 틀려도 모두 집계됨
+func (service *userService) adminFailure(credential *model.AdminCredential, reason string) error {
+	lockedUntil := time.Now().In(model.ServerLocation).Add(adminLockDuration).Format(timeLayout)
+	var result struct {
+		FailedAttempts int
+		LockedUntil    string
+	}
+	if err := service.db.Raw(`UPDATE admin_credentials SET
+			failed_attempts = CASE WHEN failed_attempts + 1 >= ? THEN 0 ELSE failed_attempts + 1 END,
+			locked_until = CASE WHEN failed_attempts + 1 >= ? THEN ? ELSE locked_until END
+		WHERE id = ? RETURNING failed_attempts, locked_until`,
+		adminMaxFailures, adminMaxFailures, lockedUntil, credential.Id).Scan(&result).Error; err != nil {
+		log.Println(err)
+	} else if result.LockedUntil == lockedUntil {
+		log.Printf("admin %d locked until %s", credential.Uid, lockedUntil)
+	}
+	credential.FailedAttempts, credential.LockedUntil = result.FailedAttempts, result.LockedUntil
+	return util.NewError(util.ErrUnauthorized, reason)
+}
+
+func (service *userService) auditAdminLogin(uid uint, email, ip string, err error) {
+	audit := model.AdminLoginAudit{Uid: uid, Email: email, Ip: ip, Success: err == nil}
+	if err != nil {
+		audit.Reason = util.ToAppError(err).Detail
+	}
+	if err := service.db.Create(&audit).Error; err != nil {
+		log.Println("admin login audit error:", err)
+	}
+}
+
+func (service *userService) AdminLogin(adminLoginRequest dto.AdminLoginRequest) (dto.LoginResponse, error) {
+	u, credential, err := service.findAdmin(adminLoginRequest.Email)
+	if err == nil {
+		err = service.verifyAdmin(u, &credential, adminLoginRequest.Password, adminLoginRequest.OtpCode)
+	} else {
+		bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(adminLoginRequest.Password))
+	}
+	service.auditAdminLogin(u.Id, adminLoginRequest.Email, adminLoginRequest.Ip, err)
+	if err != nil {
+		return dto.LoginResponse{}, adminAuthError(err)
+	}
+
+	// 새로운 세션 및 토큰 생성 - 같은 기기 id 의 이전 세션만 폐기
+	deviceId := adminLoginRequest.DeviceId
+	if deviceId == "" {
+		if deviceId, err = newAdminDeviceId(); err != nil {
+			return dto.LoginResponse{}, err
+		}
+	}
+	return service.issueTokens(u, deviceId)
+}
+
+// 비밀번호 미설정 관리자의 첫 비밀번호 설정용 인증번호를 등록된 전화번호로 발송
+// 기존 방식(전화번호)이 맞을때만 보내고, 응답은 계정 여부와 관계없이 항상 같음
+func (service *userService) SendAdminAuthCode(adminCodeRequest dto.AdminCodeRequest) (string, error) {
+	u, credential, err := service.findAdmin(adminCodeRequest.Email)
+	if err == nil {
+		err = service.verifyAdmin(u, &credential, adminCodeRequest.Password, "")
+	} else {
+		bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(adminCodeRequest.Password))
+	}
+	if err == nil || util.ToAppError(err).Detail != adminPasswordNotSet {
+		if err != nil {
+			appErr := util.ToAppError(err)
+			service.auditAdminLogin(u.Id, adminCodeRequest.Email, adminCodeRequest.Ip, util.NewError(appErr.Code, "send code: "+appErr.Detail))
+		}
+		return "200", nil
+	}
+	if err := service.issueAuthCode(u.PhoneNum, adminCodeRequest.Ip); err != nil {
+		log.Println("admin auth code error:", err)
+	}
+	return "200", nil
+}
+
+// 비밀번호 변경 - 비밀번호 미설정 관리자는 기존 방식(전화번호)과 문자 인증번호를 확인 후 설정
+func (service *userService) ChangeAdminPassword(adminPasswordRequest dto.AdminPasswordRequest) (string, error) {
+	u, credential, err := service.findAdmin(adminPasswordRequest.Email)
+	if err == nil {
+		err = service.verifyAdmin(u, &credential, adminPasswordRequest.Password, adminPasswordRequest.OtpCode)
+		// 기존 방식 확인이 끝난 비밀번호 미설정 관리자는 /admin-send-code 로 받은 인증번호까지 맞아야 설정
+		if err != nil && util.ToAppError(err).Detail == adminPasswordNotSet {
+			err = service.verifyAdminAuthCode(u, adminPasswordRequest.AuthCode)
+		}
+	} else {
+		bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(adminPasswordRequest.Password))
+	}
+	if err != nil {
+		appErr := util.ToAppError(err)
+		service.auditAdminLogin(u.Id, adminPasswordRequest.Email, adminPasswordRequest.Ip, util.NewError(appErr.Code, "password change: "+appErr.Detail))
+		return "", adminAuthError(err)
+	}
+	if err := validateAdminPassword(adminPasswordRequest.NewPassword, adminPasswordRequest.Password); err != nil {
+		return "", err
+	}
+
+	if err := service.setAdminPassword(u.Id, adminPasswordRequest.NewPassword, false); err != nil {
+		return "", err
+	}
+	service.auditAdminLogin(u.Id, adminPasswordRequest.Email, adminPasswordRequest.Ip, nil)
+	return "200", nil
+}
+
+// super-admin 이 관리자 비밀번호 초기화 (TOTP 도 해제)
+func (service *userService) ResetAdminPassword(adminResetRequest dto.AdminResetRequest) (string, error) {
+	var u model.User
+	if err := service.db.Where("id = ?", adminResetRequest.Uid).First(&u).Error; err != nil {
+		return "", util.NewError(util.ErrNotFound, "user not found")
+	}
+	if _, _, err := service.findAdminById(u); err != nil {
+		return "", err
+	}
+	if err := validateAdminPassword(adminResetRequest.NewPassword, ""); err != nil {
+		return "", err
+	}
+	if err := service.setAdminPassword(u.Id, adminResetRequest.NewPassword, true); err != nil {
+		return "", err
+	}
+	return "200", nil
+}
+
+func (service *userService) verifyAdminAuthCode(u model.User, code string) error {
+	if code == "" {
+		return util.NewError(util.ErrVerificationRequired, "auth code required")
+	}
+	return service.consumeAuthCode(u.PhoneNum, code)
+}
+
+func validateAdminPassword(newPassword, oldPassword string) error {
+	if len(newPassword) < adminMinPasswordLength {
+		return util.NewError(util.ErrInvalidRequest, "password too short")
+	}
+	if newPassword == oldPassword {
+		return util.NewError(util.ErrInvalidRequest, "same password")
+	}
+	return nil
+}
+
+// 비밀번호 저장 후 기존 세션 모두 폐기
+func (service *userService) setAdminPassword(uid uint, password string, resetTotp bool) error {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	updates := map[string]interface{}{"password_hash": string(hash), "failed_attempts": 0, "locked_until": ""}
+	if resetTotp {
+		updates["totp_enabled"] = false
+		updates["totp_secret"] = ""
+		updates["totp_last_step"] = 0
+	}
+
+	return service.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.AdminCredential{}).Where("uid = ?", uid).Updates(updates).Error; err != nil {
+			return util.NewError(util.ErrDatabase, "db error")
+		}
+		if err := revokeSessions(tx.Where("uid = ?", uid)); err != nil {
+			return util.NewError(util.ErrDatabase, "db error2")
+		}
+		return nil
+	})
+}
+
+// TOTP 등록 시작 - ConfirmTOTP 로 코드 확인 후 활성화
+func (service *userService) EnrollTOTP(uid uint) (dto.TotpEnrollResponse, error) {
+	var u model.User
+	if err := service.db.Where("id = ?", uid).First(&u).Error; err != nil {
+		return dto.TotpEnrollResponse{}, util.NewError(util.ErrDatabase, "db error")
+	}
+	_, credential, err := service.findAdminById(u)
+	if err != nil {
+		return dto.TotpEnrollResponse{}, err
+	}
+	if credential.TotpEnabled {
+		return dto.TotpEnrollResponse{}, util.NewError(util.ErrConflict, "totp already enabled")
+	}
+
+	secret, err := util.GenerateTOTPSecret()
+	if err != nil {
+		return dto.TotpEnrollResponse{}, err
+	}
+	if err := service.db.Model(&credential).Updates(map[string]interface{}{"totp_secret": secret, "totp_last_step": 0}).Error; err != nil {
+		return dto.TotpEnrollResponse{}, util.NewError(util.ErrDatabase, "db error2")
+	}
+
+	return dto.TotpEnrollResponse{Secret: secret, Url: util.TOTPURL(totpIssuer, u.Email, secret)}, nil
+}
+
+func (service *userService) ConfirmTOTP(uid uint, code string) (string, error) {
+	var credential model.AdminCredential
+	if err := service.db.Where("uid = ?", uid).First(&credential).Error; err != nil {
+		return "", util.NewError(util.ErrInvalidRequest, "totp not enrolled")
+	}
+	if credential.TotpEnabled {
+		return "", util.NewError(util.ErrConflict, "totp already enabled")
+	}
+	if credential.TotpSecret == "" {
+		return "", util.NewError(util.ErrInvalidRequest, "totp not enrolled")
+	}
+
+	step, ok := util.VerifyTOTP(credential.TotpSecret, code, credential.TotpLastStep)
+	if !ok {
+		return "", util.NewError(util.ErrUnauthorized, "invalid otp")
+	}
+	if err := service.db.Model(&credential).Updates(map[string]interface{}{"totp_enabled": true, "totp_last_step": step}).Error; err != nil {
+		return "", util.NewError(util.ErrDatabase, "db error")
+	}
+	return "200", nil
+}
+
+// 관리자 로그인 기록 (20개씩)
+func (service *userService) GetAdminLoginAudits(page uint) ([]dto.AdminLoginAuditResponse, error) {
+	pageSize := uint(20)
+	var audits []model.AdminLoginAudit
+	if err := service.db.Order("id DESC").Offset(int(page * pageSize)).Limit(int(pageSize)).Find(&audits).Error; err != nil {
+		return nil, util.NewError(util.ErrDatabase, "db error")
+	}
+
+	var auditResponses []dto.AdminLoginAuditResponse
+	if err := util.CopyStruct(audits, &auditResponses); err != nil {
+		return nil, err
+	}
+	return auditResponses, nil
+}
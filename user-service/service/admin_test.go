@@ -0,0 +1,99 @@
+// /user-service/service/admin_test.go
+
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/disterbia/wellkinson/common/model"
+	"github.com/disterbia/wellkinson/common/util"
+)
+
+func TestAdminLocked(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		lockedUntil string
+		want        bool
+	}{
+		{"never locked", "", false},
+		{"lock active", now.Add(time.Minute).Format(timeLayout), true},
+		{"lock ends now", now.Format(timeLayout), false},
+		{"lock expired", now.Add(-adminLockDuration).Format(timeLayout), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := adminLocked(model.AdminCredential{LockedUntil: tt.lockedUntil}, now); got != tt.want {
+				t.Fatalf("adminLocked(%q) = %v, want %v", tt.lockedUntil, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdminAuthError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantCode   string
+		wantDetail string
+	}{
+		{"unknown account", util.NewError(util.ErrUnauthorized, "unknown account"), util.ErrUnauthorized, "invalid credentials"},
+		{"not admin", util.NewError(util.ErrForbidden, "not admin"), util.ErrUnauthorized, "invalid credentials"},
+		{"wrong password", util.NewError(util.ErrUnauthorized, "invalid credentials"), util.ErrUnauthorized, "invalid credentials"},
+		{"wrong otp", util.NewError(util.ErrUnauthorized, "invalid otp"), util.ErrUnauthorized, "invalid credentials"},
+		{"password not set", util.NewError(util.ErrUnauthorized, adminPasswordNotSet), util.ErrUnauthorized, adminPasswordNotSet},
+		{"locked", util.NewError(util.ErrTooManyRequests, "locked"), util.ErrUnauthorized, "invalid credentials"},
+		{"db error", util.NewError(util.ErrDatabase, "db error"), util.ErrDatabase, "db error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := util.ToAppError(adminAuthError(tt.err))
+			if got.Code != tt.wantCode || got.Detail != tt.wantDetail {
+				t.Fatalf("adminAuthError = %s: %s, want %s: %s", got.Code, got.Detail, tt.wantCode, tt.wantDetail)
+			}
+		})
+	}
+
+	// 관리자 아님과 비밀번호 불일치는 구분할 수 없어야 함
+	notAdmin := adminAuthError(util.NewError(util.ErrForbidden, "not admin"))
+	wrongPassword := adminAuthError(util.NewError(util.ErrUnauthorized, "invalid credentials"))
+	locked := adminAuthError(util.NewError(util.ErrTooManyRequests, "locked"))
+	if !errors.Is(notAdmin, errAdminCredentials) || !errors.Is(wrongPassword, errAdminCredentials) || !errors.Is(locked, errAdminCredentials) {
+		t.Fatal("not admin, wrong password and locked must return the same error")
+	}
+}
+
+func TestValidateAdminPassword(t *testing.T) {
+	tests := []struct {
+		name        string
+		newPassword string
+		oldPassword string
+		wantErr     bool
+	}{
+		{"valid", "correct-horse-battery", "01012345678", false},
+		{"too short", "short", "", true},
+		{"same as old", "correct-horse-battery", "correct-horse-battery", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAdminPassword(tt.newPassword, tt.oldPassword)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateAdminPassword error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && util.ToAppError(err).Code != util.ErrInvalidRequest {
+				t.Fatalf("code = %s, want %s", util.ToAppError(err).Code, util.ErrInvalidRequest)
+			}
+		})
+	}
+}
+
+// 비밀번호 미설정 관리자는 전화번호만으로 첫 비밀번호를 설정할 수 없음
+func TestVerifyAdminAuthCodeRequired(t *testing.T) {
+	service := &userService{}
+	err := service.verifyAdminAuthCode(model.User{Id: 1, PhoneNum: "01012345678"}, "")
+	if code := util.ToAppError(err).Code; code != util.ErrVerificationRequired {
+		t.Fatalf("verifyAdminAuthCode code = %s, want %s", code, util.ErrVerificationRequired)
+	}
+}
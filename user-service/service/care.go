@@ -0,0 +1,182 @@
+// /user-service/service/care.go
+
+package service
+
+import (
+	"encoding/json"
+	"time"
+	"user-service/dto"
+
+	"github.com/disterbia/wellkinson/common/model"
+	"github.com/disterbia/wellkinson/common/util"
+)
+
+// 보호자 연결 상태
+const (
+	careLinkPending  = 0
+	careLinkAccepted = 1
+	careLinkRemoved  = 2
+)
+
+const careInviteTTL = 72 * time.Hour
+
+// 환자가 보호자 초대 코드 발급
+func (service *userService) InviteCaregiver(careInviteRequest dto.CareInviteRequest) (dto.CareInviteResponse, error) {
+	if err := util.ValidateCareScopes(careInviteRequest.Scopes); err != nil {
+		return dto.CareInviteResponse{}, err
+	}
+	scopes, err := json.Marshal(careInviteRequest.Scopes)
+	if err != nil {
+		return dto.CareInviteResponse{}, err
+	}
+	code, err := util.GenerateInviteCode()
+	if err != nil {
+		return dto.CareInviteResponse{}, err
+	}
+
+	link := model.CareLink{
+		PatientUid:     careInviteRequest.Uid,
+		InviteCodeHash: util.HashToken(code),
+		InviteExpires:  time.Now().In(model.ServerLocation).Add(careInviteTTL).Format(timeLayout),
+		Status:         careLinkPending,
+		Scopes:         scopes,
+	}
+	if err := service.db.Create(&link).Error; err != nil {
+		return dto.CareInviteResponse{}, util.NewError(util.ErrDatabase, "db error")
+	}
+
+	return dto.CareInviteResponse{Id: link.Id, InviteCode: code, InviteExpires: link.InviteExpires}, nil
+}
+
+// 보호자가 초대 코드로 연결 수락
+func (service *userService) AcceptCare(careAcceptRequest dto.CareAcceptRequest) (string, error) {
+	var link model.CareLink
+	if err := service.db.Where("invite_code_hash = ? AND status = ? AND invite_expires > ?",
+		util.HashToken(careAcceptRequest.InviteCode), careLinkPending, time.Now().In(model.ServerLocation).Format(timeLayout)).First(&link).Error; err != nil {
+		return "", util.NewError(util.ErrInvalidRequest, "invalid invite code")
+	}
+	if link.PatientUid == careAcceptRequest.Uid {
+		return "", util.NewError(util.ErrInvalidRequest, "cannot accept own invite")
+	}
+
+	var count int64
+	if err := service.db.Model(&model.CareLink{}).Where("patient_uid = ? AND caregiver_uid = ? AND status = ?",
+		link.PatientUid, careAcceptRequest.Uid, careLinkAccepted).Count(&count).Error; err != nil {
+		return "", util.NewError(util.ErrDatabase, "db error")
+	}
+	if count > 0 {
+		return "", util.NewError(util.ErrConflict, "already linked")
+	}
+
+	// 동시에 같은 코드로 수락해도 한 명만 연결
+	result := service.db.Model(&model.CareLink{}).Where("id = ? AND status = ?", link.Id, careLinkPending).
+		Updates(map[string]interface{}{"caregiver_uid": careAcceptRequest.Uid, "status": careLinkAccepted, "invite_code_hash": ""})
+	if result.Error != nil {
+		return "", util.NewError(util.ErrDatabase, "db error2")
+	}
+	if result.RowsAffected == 0 {
+		return "", util.NewError(util.ErrInvalidRequest, "invalid invite code")
+	}
+	return "200", nil
+}
+
+// 환자만 권한 변경 가능
+func (service *userService) SetCareScopes(careScopeRequest dto.CareScopeRequest) (string, error) {
+	if err := util.ValidateCareScopes(careScopeRequest.Scopes); err != nil {
+		return "", err
+	}
+	scopes, err := json.Marshal(careScopeRequest.Scopes)
+	if err != nil {
+		return "", err
+	}
+
+	result := service.db.Model(&model.CareLink{}).Where("id = ? AND patient_uid = ? AND status <> ?", careScopeRequest.Id, careScopeRequest.Uid, careLinkRemoved).
+		Update("scopes", scopes)
+	if result.Error != nil {
+		return "", util.NewError(util.ErrDatabase, "db error")
+	}
+	if result.RowsAffected == 0 {
+		return "", util.NewError(util.ErrNotFound, "care link not found")
+	}
+	return "200", nil
+}
+
+// 환자, 보호자 모두 연결 해제 가능
+func (service *userService) RemoveCare(uid, id uint) (string, error) {
+	result := service.db.Model(&model.CareLink{}).Where("id = ? AND (patient_uid = ? OR caregiver_uid = ?) AND status <> ?", id, uid, uid, careLinkRemoved).
+		Updates(map[string]interface{}{"status": careLinkRemoved, "invite_code_hash": ""})
+	if result.Error != nil {
+		return "", util.NewError(util.ErrDatabase, "db error")
+	}
+	if result.RowsAffected == 0 {
+		return "", util.NewError(util.ErrNotFound, "care link not found")
+	}
+	return "200", nil
+}
+
+// 내가 환자 또는 보호자인 연결 목록
+func (service *userService) GetCares(uid uint) ([]dto.CareLinkResponse, error) {
+	var links []model.CareLink
+	if err := service.db.Where("(patient_uid = ? OR caregiver_uid = ?) AND status <> ?", uid, uid, careLinkRemoved).Order("id DESC").Find(&links).Error; err != nil {
+		return nil, util.NewError(util.ErrDatabase, "db error")
+	}
+
+	var uids []uint
+	for _, v := range links {
+		uids = append(uids, v.PatientUid, v.CaregiverUid)
+	}
+	names, err := service.userNames(uids)
+	if err != nil {
+		return nil, util.NewError(util.ErrDatabase, "db error2")
+	}
+
+	careLinkResponses := make([]dto.CareLinkResponse, 0, len(links))
+	for _, v := range links {
+		var scopes map[string]string
+		json.Unmarshal(v.Scopes, &scopes)
+		careLinkResponses = append(careLinkResponses, dto.CareLinkResponse{Id: v.Id, PatientUid: v.PatientUid, PatientName: names[v.PatientUid],
+			CaregiverUid: v.CaregiverUid, CaregiverName: names[v.CaregiverUid], Status: v.Status, Scopes: scopes, InviteExpires: v.InviteExpires, Created: v.Created})
+	}
+	return careLinkResponses, nil
+}
+
+// 보호자가 내 계정에 대신 기록한 내역 (20개씩)
+func (service *userService) GetCareAudits(uid uint, page uint) ([]dto.DelegatedWriteResponse, error) {
+	pageSize := uint(20)
+	var writes []model.DelegatedWrite
+	if err := service.db.Where("patient_uid = ?", uid).Order("id DESC").Offset(int(page * pageSize)).Limit(int(pageSize)).Find(&writes).Error; err != nil {
+		return nil, util.NewError(util.ErrDatabase, "db error")
+	}
+
+	var uids []uint
+	for _, v := range writes {
+		uids = append(uids, v.CaregiverUid)
+	}
+	names, err := service.userNames(uids)
+	if err != nil {
+		return nil, util.NewError(util.ErrDatabase, "db error2")
+	}
+
+	delegatedWriteResponses := make([]dto.DelegatedWriteResponse, 0, len(writes))
+	for _, v := range writes {
+		delegatedWriteResponses = append(delegatedWriteResponses, dto.DelegatedWriteResponse{Id: v.Id, CaregiverUid: v.CaregiverUid, CaregiverName: names[v.CaregiverUid],
+			Domain: v.Domain, Action: v.Action, Status: v.Status, Created: v.Created})
+	}
+	return delegatedWriteResponses, nil
+}
+
+// uid -> 이름
+func (service *userService) userNames(uids []uint) (map[uint]string, error) {
+	names := make(map[uint]string)
+	if len(uids) == 0 {
+		return names, nil
+	}
+	var users []model.User
+	if err := service.db.Select("id", "name").Where("id IN ?", uids).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	for _, u := range users {
+		names[u.Id] = u.Name
+	}
+	return names, nil
+}
@@ -0,0 +1,83 @@
+// /user-service/service/device.go
+
+package service
+
+import (
+	"errors"
+	"time"
+	"user-service/dto"
+
+	"github.com/disterbia/wellkinson/common/model"
+	"github.com/disterbia/wellkinson/common/util"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 푸시 수신 기기 등록/갱신 - 같은 토큰이나 같은 기기를 쓰던 다른 등록은 삭제
+func registerDevice(db *gorm.DB, device dto.DeviceRequest) error {
+	if device.Uid == 0 || device.DeviceId == "" || device.FcmToken == "" {
+		return util.NewError(util.ErrInvalidRequest, "check fcm_token,device_id")
+	}
+	now := time.Now().In(model.ServerLocation).Format(timeLayout)
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		// 토큰이 다른 기기로 옮겨졌거나 기기에 다른 계정으로 로그인한 경우
+		if err := tx.Where("(fcm_token = ? OR device_id = ?) AND NOT (uid = ? AND device_id = ?)",
+			device.FcmToken, device.DeviceId, device.Uid, device.DeviceId).Delete(&model.Device{}).Error; err != nil {
+			return err
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "uid"}, {Name: "device_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"fcm_token", "platform", "app_version", "last_seen", "updated"}),
+		}).Create(&model.Device{Uid: device.Uid, DeviceId: device.DeviceId, FcmToken: device.FcmToken,
+			Platform: device.Platform, AppVersion: device.AppVersion, LastSeen: now}).Error
+	})
+	if err != nil {
+		return util.WrapError(util.ErrDatabase, err)
+	}
+	return nil
+}
+
+// 기기 등록 해제 - 기존 users.fcm_token 도 같은 토큰이면 비움
+func unregisterDevice(db *gorm.DB, uid uint, deviceId string) error {
+	var device model.Device
+	if err := db.Where("uid = ? AND device_id = ?", uid, deviceId).First(&device).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if err := db.Delete(&device).Error; err != nil {
+		return err
+	}
+	return db.Model(&model.User{}).Where("id = ? AND fcm_token = ?", uid, device.FcmToken).Update("fcm_token", "").Error
+}
+
+// 앱 실행중 토큰이 갱신되었을때 호출
+func (service *userService) RegisterDevice(deviceRequest dto.DeviceRequest) (string, error) {
+	if err := registerDevice(service.db, deviceRequest); err != nil {
+		return "", err
+	}
+	if err := service.db.Model(&model.User{}).Where("id = ? AND device_id = ?", deviceRequest.Uid, deviceRequest.DeviceId).
+		Update("fcm_token", deviceRequest.FcmToken).Error; err != nil {
+		return "", util.NewError(util.ErrDatabase, "db error")
+	}
+	return "200", nil
+}
+
+func (service *userService) GetDevices(uid, currentSid uint) ([]dto.DeviceResponse, error) {
+	var devices []model.Device
+	if err := service.db.Where("uid = ?", uid).Order("last_seen DESC").Find(&devices).Error; err != nil {
+		return nil, util.NewError(util.ErrDatabase, "db error")
+	}
+
+	var session model.Session
+	service.db.Select("device_id").Where("id = ?", currentSid).First(&session)
+
+	var deviceResponses []dto.DeviceResponse
+	for _, v := range devices {
+		deviceResponses = append(deviceResponses, dto.DeviceResponse{DeviceId: v.DeviceId, Platform: v.Platform, AppVersion: v.AppVersion,
+			LastSeen: v.LastSeen, Current: v.DeviceId == session.DeviceID})
+	}
+	return deviceResponses, nil
+}
@@ -0,0 +1,126 @@
+// /user-service/service/message.go
+
+package service
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/disterbia/wellkinson/common/model"
+	"github.com/disterbia/wellkinson/common/util"
+)
+
+// 발송 상태
+const (
+	messagePending = 0
+	messageSent    = 1
+	messageFailed  = 2
+	messageExpired = 3
+)
+
+const (
+	messageMaxAttempts = 5
+	messageRetryDelay  = 30 * time.Second
+)
+
+// 메시지 템플릿 (message_deliveries.purpose)
+const messageAuthCode = "auth_code"
+
+func authCodeText(code string) string {
+	return "인증번호는 [" + code + "] 입니다."
+}
+
+// 발송 기록을 남기고 즉시 발송 - 실패하면 만료 전까지 RetryMessages 에서 재시도
+// 기록에는 템플릿과 참조 id 만 남기고 본문(인증번호 등)은 저장하지 않음
+func (service *userService) sendMessage(receiver, purpose string, refId uint, text string, ttl time.Duration) error {
+	now := time.Now().In(model.ServerLocation)
+	delivery := model.MessageDelivery{Receiver: receiver, Purpose: purpose, RefId: refId, Provider: service.sender.Name(),
+		Status: messagePending, ExpiresAt: now.Add(ttl).Format(timeLayout)}
+	if err := service.db.Create(&delivery).Error; err != nil {
+		return util.NewError(util.ErrDatabase, "db error")
+	}
+
+	return service.deliver(&delivery, text)
+}
+
+// 재시도할 본문 생성 - 인증번호는 해시만 있으므로 새 번호를 발급해 교체 (이전 번호는 더 이상 맞지 않음)
+func (service *userService) renderMessage(delivery model.MessageDelivery) (string, error) {
+	switch delivery.Purpose {
+	case messageAuthCode:
+		code, err := util.GenerateAuthCode()
+		if err != nil {
+			return "", err
+		}
+		// 이미 사용했거나 새로 요청해 무효화된 인증번호는 재발송하지 않음
+		result := service.db.Model(&model.AuthCode{}).Where("id = ? AND invalidated = false", delivery.RefId).Update("code", util.HashToken(code))
+		if result.Error != nil {
+			return "", util.NewError(util.ErrDatabase, "db error")
+		}
+		if result.RowsAffected == 0 {
+			return "", errMessageObsolete
+		}
+		return authCodeText(code), nil
+	}
+	return "", errMessageObsolete
+}
+
+var errMessageObsolete = errors.New("message no longer needed")
+
+func (service *userService) deliver(delivery *model.MessageDelivery, text string) error {
+	now := time.Now().In(model.ServerLocation)
+	sendErr := service.sender.Send(delivery.Receiver, text)
+
+	updates := map[string]interface{}{"attempts": delivery.Attempts + 1, "provider": service.sender.Name()}
+	if sendErr == nil {
+		updates["status"] = messageSent
+		updates["last_error"] = ""
+	} else {
+		log.Printf("message delivery %d failed: %v", delivery.Id, sendErr)
+		updates["last_error"] = sendErr.Error()
+		if delivery.Attempts+1 >= messageMaxAttempts {
+			updates["status"] = messageExpired
+		} else {
+			updates["status"] = messageFailed
+			updates["next_retry"] = now.Add(messageRetryDelay * time.Duration(delivery.Attempts+1)).Format(timeLayout)
+		}
+	}
+	if err := service.db.Model(&model.MessageDelivery{}).Where("id = ?", delivery.Id).Updates(updates).Error; err != nil {
+		log.Println("message delivery update error:", err)
+	}
+	return sendErr
+}
+
+// 실패한 발송 재시도 (main 에서 주기적으로 호출)
+func (service *userService) RetryMessages() {
+	now := time.Now().In(model.ServerLocation).Format(timeLayout)
+
+	// 유효시간이 지난 메시지는 재시도하지 않음
+	if err := service.db.Model(&model.MessageDelivery{}).Where("status IN ? AND expires_at <= ?", []int{messagePending, messageFailed}, now).
+		Update("status", messageExpired).Error; err != nil {
+		log.Println("message expire error:", err)
+		return
+	}
+
+	var deliveries []model.MessageDelivery
+	if err := service.db.Where("status = ? AND next_retry <= ?", messageFailed, now).Order("id").Limit(100).Find(&deliveries).Error; err != nil {
+		log.Println("message retry error:", err)
+		return
+	}
+	for i := range deliveries {
+		// 여러 인스턴스가 동시에 재시도하지 않도록 상태를 먼저 선점
+		result := service.db.Model(&model.MessageDelivery{}).Where("id = ? AND status = ?", deliveries[i].Id, messageFailed).Update("status", messagePending)
+		if result.Error != nil || result.RowsAffected == 0 {
+			continue
+		}
+		text, err := service.renderMessage(deliveries[i])
+		if err != nil {
+			log.Printf("message delivery %d not retried: %v", deliveries[i].Id, err)
+			if err := service.db.Model(&model.MessageDelivery{}).Where("id = ?", deliveries[i].Id).Update("status", messageExpired).Error; err != nil {
+				log.Println("message delivery update error:", err)
+			}
+			continue
+		}
+		service.deliver(&deliveries[i], text)
+	}
+}
@@ -0,0 +1,148 @@
+// /user-service/service/role.go
+
+package service
+
+import (
+	"user-service/dto"
+
+	"github.com/disterbia/wellkinson/common/model"
+	"github.com/disterbia/wellkinson/common/util"
+	"gorm.io/gorm"
+)
+
+// 관리자 페이지 로그인 가능한 역할
+var adminRoles = []string{util.RoleContentAdmin, util.RoleSuperAdmin}
+
+// user_type 에서 나오는 기본 역할
+func baseRole(userType uint) string {
+	switch userType {
+	case 1:
+		return util.RolePatient
+	case 2:
+		return util.RoleCaregiver
+	}
+	return ""
+}
+
+// 기본 역할 + 부여된 역할
+func userRoles(db *gorm.DB, u model.User) ([]string, error) {
+	var granted []string
+	if err := db.Model(&model.UserRole{}).Where("uid = ?", u.Id).Order("id").Pluck("role", &granted).Error; err != nil {
+		return nil, err
+	}
+
+	roles := make([]string, 0, len(granted)+1)
+	if role := baseRole(u.UserType); role != "" {
+		roles = append(roles, role)
+	}
+	for _, role := range granted {
+		if !containsRole(roles, role) {
+			roles = append(roles, role)
+		}
+	}
+	return roles, nil
+}
+
+func containsRole(roles []string, role string) bool {
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAdminRole(roles []string) bool {
+	for _, role := range adminRoles {
+		if containsRole(roles, role) {
+			return true
+		}
+	}
+	return false
+}
+
+// is_admin 컬럼을 관리자 역할 보유 여부와 맞춤
+func syncIsAdmin(tx *gorm.DB, uid uint) error {
+	var count int64
+	if err := tx.Model(&model.UserRole{}).Where("uid = ? AND role IN ?", uid, adminRoles).Count(&count).Error; err != nil {
+		return err
+	}
+	return tx.Model(&model.User{}).Where("id = ?", uid).Update("is_admin", count > 0).Error
+}
+
+func (service *userService) GetUserRoles(uid uint) ([]string, error) {
+	var u model.User
+	if err := service.db.Where("id = ?", uid).First(&u).Error; err != nil {
+		return nil, util.NewError(util.ErrDatabase, "db error")
+	}
+	roles, err := userRoles(service.db, u)
+	if err != nil {
+		return nil, util.NewError(util.ErrDatabase, "db error2")
+	}
+	return roles, nil
+}
+
+func (service *userService) GrantRole(roleRequest dto.RoleRequest) (string, error) {
+	if !util.IsValidRole(roleRequest.Role) {
+		return "", util.NewError(util.ErrInvalidRequest, "invalid role")
+	}
+	if err := service.db.Where("id = ?", roleRequest.Uid).First(&model.User{}).Error; err != nil {
+		return "", util.NewError(util.ErrNotFound, "user not found")
+	}
+
+	tx := service.db.Begin()
+	userRole := model.UserRole{Uid: roleRequest.Uid, Role: roleRequest.Role, GrantedBy: roleRequest.GrantedBy}
+	if err := tx.Where(model.UserRole{Uid: roleRequest.Uid, Role: roleRequest.Role}).FirstOrCreate(&userRole).Error; err != nil {
+		tx.Rollback()
+		return "", util.NewError(util.ErrDatabase, "db error")
+	}
+	if err := syncIsAdmin(tx, roleRequest.Uid); err != nil {
+		tx.Rollback()
+		return "", util.NewError(util.ErrDatabase, "db error2")
+	}
+	tx.Commit()
+
+	return "200", nil
+}
+
+func (service *userService) RevokeRole(roleRequest dto.RoleRequest) (string, error) {
+	if !util.IsValidRole(roleRequest.Role) {
+		return "", util.NewError(util.ErrInvalidRequest, "invalid role")
+	}
+
+	tx := service.db.Begin()
+	if roleRequest.Role == util.RoleSuperAdmin {
+		// 마지막 super-admin 은 회수 불가
+		var count int64
+		if err := tx.Model(&model.UserRole{}).Where("role = ? AND uid <> ?", util.RoleSuperAdmin, roleRequest.Uid).Count(&count).Error; err != nil {
+			tx.Rollback()
+			return "", util.NewError(util.ErrDatabase, "db error")
+		}
+		if count == 0 {
+			tx.Rollback()
+			return "", util.NewError(util.ErrConflict, "last super-admin")
+		}
+	}
+
+	result := tx.Where("uid = ? AND role = ?", roleRequest.Uid, roleRequest.Role).Delete(&model.UserRole{})
+	if result.Error != nil {
+		tx.Rollback()
+		return "", util.NewError(util.ErrDatabase, "db error2")
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return "", util.NewError(util.ErrNotFound, "role not granted")
+	}
+	if err := syncIsAdmin(tx, roleRequest.Uid); err != nil {
+		tx.Rollback()
+		return "", util.NewError(util.ErrDatabase, "db error3")
+	}
+	// 회수한 역할이 남은 access 토큰으로 쓰이지 않도록 모든 세션 폐기 (게이트웨이는 폐기 목록으로 차단)
+	if err := revokeSessions(tx.Where("uid = ?", roleRequest.Uid)); err != nil {
+		tx.Rollback()
+		return "", util.NewError(util.ErrDatabase, "db error4")
+	}
+	tx.Commit()
+
+	return "200", nil
+}
@@ -3,43 +3,61 @@
 package service
 
 import (
+	"crypto/subtle"
 	"encoding/base64"
 	"errors"
-	"fmt"
-	"io/ioutil"
 	"log"
-	"math/rand"
-	"net/http"
-	"net/url"
-	"os"
 	"reflect"
 	"strconv"
 	"strings"
 	"time"
-	"user-service/common/model"
-	"user-service/common/util"
 	"user-service/dto"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/dgrijalva/jwt-go"
+	"github.com/disterbia/wellkinson/common/model"
+	"github.com/disterbia/wellkinson/common/util"
 	"gorm.io/gorm"
 )
 
 type UserService interface {
-	AutoLogin(autoLoginRequest dto.AutoLoginRequest) (string, error) //자동로그인
-	SnsLogin(idToken string, userRequest dto.UserRequest) (string, error)
+	AutoLogin(autoLoginRequest dto.AutoLoginRequest) (dto.LoginResponse, error) //자동로그인
+	SnsLogin(loginRequest dto.LoginRequest) (dto.LoginResponse, error)
 	SetUser(user dto.UserRequest) (string, error) //유저업데이트
 	GetUser(id uint) (dto.UserResponse, error)    //유저조회
-	AdminLogin(email string, password string) (string, error)
+	AdminLogin(adminLoginRequest dto.AdminLoginRequest) (dto.LoginResponse, error)
 	GetMainServices() ([]dto.MainServiceResponse, error)
-	SendAuthCode(number string) (string, error)
+	SendAuthCode(number, ip string) (string, error)
 	VerifyAuthCode(number, code string) (string, error)
 	RemoveUser(id uint) (string, error)
 	LinkEmail(uid uint, idToken string) (string, error) // 0:카카오 1:구글 2:애플
 	GetVersion() (dto.AppVersionResponse, error)
 	GetPolices() ([]dto.PoliceResponse, error)
 	RemoveProfile(uid uint) (string, error)
+	RefreshToken(refreshToken, deviceId string) (dto.LoginResponse, error)
+	GetSessions(uid, currentSid uint) ([]dto.SessionResponse, error)
+	RevokeSession(uid, sid uint) (string, error)
+	GetRevokedSessions() ([]uint, error)
+	IsSessionRevoked(sid uint) bool
+	RetryMessages()
+	GetUserRoles(uid uint) ([]string, error)
+	GrantRole(roleRequest dto.RoleRequest) (string, error)
+	RevokeRole(roleRequest dto.RoleRequest) (string, error)
+	InviteCaregiver(careInviteRequest dto.CareInviteRequest) (dto.CareInviteResponse, error)
+	AcceptCare(careAcceptRequest dto.CareAcceptRequest) (string, error)
+	SetCareScopes(careScopeRequest dto.CareScopeRequest) (string, error)
+	RemoveCare(uid, id uint) (string, error)
+	GetCares(uid uint) ([]dto.CareLinkResponse, error)
+	GetCareAudits(uid uint, page uint) ([]dto.DelegatedWriteResponse, error)
+	SendAdminAuthCode(adminCodeRequest dto.AdminCodeRequest) (string, error)
+	ChangeAdminPassword(adminPasswordRequest dto.AdminPasswordRequest) (string, error)
+	ResetAdminPassword(adminResetRequest dto.AdminResetRequest) (string, error)
+	EnrollTOTP(uid uint) (dto.TotpEnrollResponse, error)
+	ConfirmTOTP(uid uint, code string) (string, error)
+	GetAdminLoginAudits(page uint) ([]dto.AdminLoginAuditResponse, error)
+	RegisterDevice(deviceRequest dto.DeviceRequest) (string, error)
+	GetDevices(uid, currentSid uint) ([]dto.DeviceResponse, error)
 }
 
 type userService struct {
@@ -47,10 +65,11 @@ type userService struct {
 	s3svc     *s3.S3
 	bucket    string
 	bucketUrl string
+	sender    util.MessageSender
 }
 
-func NewUserService(db *gorm.DB, s3svc *s3.S3, bucket string, bucketUrl string) UserService {
-	return &userService{db: db, s3svc: s3svc, bucket: bucket, bucketUrl: bucketUrl}
+func NewUserService(db *gorm.DB, s3svc *s3.S3, bucket string, bucketUrl string, sender util.MessageSender) UserService {
+	return &userService{db: db, s3svc: s3svc, bucket: bucket, bucketUrl: bucketUrl, sender: sender}
 }
 
 type PublicKey struct {
@@ -63,120 +82,205 @@ type JWKS struct {
 	Keys []PublicKey `json:"keys"`
 }
 
-func (service *userService) SendAuthCode(number string) (string, error) {
+// 인증번호 발송 제한
+const (
+	authCodeTTL         = 3 * time.Minute
+	authCodeMaxAttempts = 5
+	authCodeCooldown    = time.Minute
+	authCodeNumberQuota = 5  // 번호당 1시간
+	authCodeIpQuota     = 20 // IP당 1시간
+	authCodeQuotaWindow = time.Hour
+)
+
+func (service *userService) SendAuthCode(number, ip string) (string, error) {
 	//존재하는 번호인지 체크
 	result := service.db.Debug().Where("phone_num=?", number).Find(&model.User{})
 	if result.Error != nil {
-		return "", errors.New("db error")
+		return "", util.NewError(util.ErrDatabase, "db error")
 
 	} else if result.RowsAffected > 0 {
 		// 레코드가 존재할 때
-		return "", errors.New("-1")
+		return "", util.NewError(util.ErrAlreadyRegistered, "phone number already registered")
 	}
 
 	err := util.ValidatePhoneNumber(number)
 	if err != nil {
 		return "", err
 	}
-	var sb strings.Builder
-	for i := 0; i < 6; i++ {
-		fmt.Fprintf(&sb, "%d", rand.Intn(10)) // 0부터 9까지의 숫자를 무작위로 선택
+	if err := service.issueAuthCode(number, ip); err != nil {
+		return "", err
 	}
+	return "200", nil
+}
 
-	apiURL := "https://kakaoapi.aligo.in/akv10/alimtalk/send/"
-	data := url.Values{}
-	data.Set("apikey", os.Getenv("API_KEY"))
-	data.Set("userid", os.Getenv("USER_ID"))
-	data.Set("token", os.Getenv("TOKEN"))
-	data.Set("senderkey", os.Getenv("SENDER_KEY"))
-	data.Set("tpl_code", os.Getenv("TPL_CODE"))
-	data.Set("sender", os.Getenv("SENDER"))
-	data.Set("subject_1", os.Getenv("SUBJECT_1"))
-
-	data.Set("receiver_1", number)
-	data.Set("message_1", "인증번호는 ["+sb.String()+"]"+" 입니다.")
-
-	// HTTP POST 요청 실행
-	resp, err := http.PostForm(apiURL, data)
+// 인증번호 생성 후 발송 (발송 제한 확인, 이전 인증번호 무효화)
+func (service *userService) issueAuthCode(number, ip string) error {
+	code, err := util.GenerateAuthCode()
 	if err != nil {
-		fmt.Printf("HTTP Request Failed: %s\n", err)
-		return "", err
+		return err
 	}
-	defer resp.Body.Close()
 
-	body, _ := ioutil.ReadAll(resp.Body)
-	log.Println(fmt.Errorf("server returned non-200 status: %d, body: %s", resp.StatusCode, string(body)))
+	// 같은 번호로 동시에 요청해도 발송 제한이 지켜지도록 번호별 잠금 후 확인
+	authCode := model.AuthCode{PhoneNumber: number, Code: util.HashToken(code), Ip: ip}
+	err = service.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "auth_code:"+number).Error; err != nil {
+			return util.NewError(util.ErrDatabase, "db error2")
+		}
+		if err := checkAuthCodeQuota(tx, number, ip); err != nil {
+			return err
+		}
+		// 이전 인증번호는 무효화
+		if err := tx.Model(&model.AuthCode{}).Where("phone_number = ? AND invalidated = false", number).Update("invalidated", true).Error; err != nil {
+			return util.NewError(util.ErrDatabase, "db error3")
+		}
+		if err := tx.Create(&authCode).Error; err != nil {
+			return util.NewError(util.ErrDatabase, "db error4")
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
 
-	if err := service.db.Create(&model.AuthCode{PhoneNumber: number, Code: sb.String()}).Error; err != nil {
-		return "", err
+	// 발송 실패는 만료 전까지 재시도되므로 인증번호는 그대로 유효
+	if err := service.sendMessage(number, messageAuthCode, authCode.Id, authCodeText(code), authCodeTTL); err != nil {
+		log.Println("auth code send error:", err)
 	}
-	return "200", nil
+	return nil
 }
 
-func (service *userService) VerifyAuthCode(number, code string) (string, error) {
-	now := time.Now()
-	threeMinutesAgo := now.Add(-3 * time.Minute)
-	threeMinutesAgoStr := threeMinutesAgo.Format("2006-01-02 15:04:05")
-	var authCode model.AuthCode
+// 재발송 대기시간, 번호/IP별 시간당 발송 횟수 확인
+func checkAuthCodeQuota(tx *gorm.DB, number, ip string) error {
+	now := time.Now().In(model.ServerLocation)
 
-	if err := service.db.Where("phone_number = ? AND created >= ? ", number, threeMinutesAgoStr).Last(&authCode).Error; err != nil {
-		return "", errors.New("db error")
+	var last model.AuthCode
+	result := tx.Where("phone_number = ?", number).Order("id DESC").Limit(1).Find(&last)
+	if result.Error != nil {
+		return util.NewError(util.ErrDatabase, "db error")
+	}
+	if result.RowsAffected > 0 {
+		if retry := retryAfter(last.Created, authCodeCooldown, now); retry > 0 {
+			return &util.CooldownError{Reason: "cooldown", RetryAfter: retry}
+		}
 	}
-	if authCode.Code != code {
-		return "", errors.New("-1")
+
+	since := now.Add(-authCodeQuotaWindow).Format(timeLayout)
+	quotas := []struct {
+		column string
+		value  string
+		limit  int
+		reason string
+	}{
+		{"phone_number", number, authCodeNumberQuota, "number quota exceeded"},
+		{"ip", ip, authCodeIpQuota, "ip quota exceeded"},
 	}
-	if err := service.db.Create(&model.VerifiedNumbers{PhoneNumber: authCode.PhoneNumber}).Error; err != nil {
-		return "", errors.New("db error2")
+	for _, q := range quotas {
+		if q.value == "" {
+			continue
+		}
+		var codes []model.AuthCode
+		if err := tx.Select("created").Where(q.column+" = ? AND created >= ?", q.value, since).Order("created ASC").Find(&codes).Error; err != nil {
+			return util.NewError(util.ErrDatabase, "db error")
+		}
+		if len(codes) >= q.limit {
+			// 가장 오래된 발송이 1시간 범위를 벗어나면 다시 가능
+			retry := retryAfter(codes[len(codes)-q.limit].Created, authCodeQuotaWindow, now)
+			return &util.CooldownError{Reason: q.reason, RetryAfter: max(retry, 1)}
+		}
 	}
+	return nil
+}
 
-	return "200", nil
+// created 로부터 window 가 지날때까지 남은 초
+func retryAfter(created string, window time.Duration, now time.Time) int {
+	t, err := time.ParseInLocation(timeLayout, created, model.ServerLocation)
+	if err != nil {
+		return 0
+	}
+	remaining := t.Add(window).Sub(now)
+	if remaining <= 0 {
+		return 0
+	}
+	return int((remaining + time.Second - 1) / time.Second)
 }
 
-func (service *userService) AdminLogin(email string, password string) (string, error) {
-	var u model.User
-	if err := service.db.Debug().Where("email=? AND phone_num=?", email, password).First(&u).Error; err != nil {
+// 오류 코드: VERIFICATION_MISMATCH 인증번호 불일치, VERIFICATION_EXPIRED 인증번호 만료 또는 시도 횟수 초과 (재발송 필요)
+func (service *userService) VerifyAuthCode(number, code string) (string, error) {
+	if err := service.consumeAuthCode(number, code); err != nil {
 		return "", err
 	}
-
-	if !u.IsAdmin {
-		return "", errors.New("not admin")
+	if err := service.db.Create(&model.VerifiedNumbers{PhoneNumber: number}).Error; err != nil {
+		return "", util.NewError(util.ErrDatabase, "db error3")
 	}
 
-	// 새로운 JWT 토큰 생성
-	tokenString, err := util.GenerateJWT(u)
-	if err != nil {
-		return "", err
+	return "200", nil
+}
+
+// 인증번호 확인 후 사용 처리 (실패 누적시 무효화)
+func (service *userService) consumeAuthCode(number, code string) error {
+	since := time.Now().In(model.ServerLocation).Add(-authCodeTTL).Format(timeLayout)
+	var authCode model.AuthCode
+
+	if err := service.db.Where("phone_number = ? AND created >= ? AND invalidated = false", number, since).Order("id DESC").First(&authCode).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return util.NewError(util.ErrVerificationExpired, "auth code expired")
+		}
+		return util.NewError(util.ErrDatabase, "db error")
 	}
 
-	return tokenString, nil
+	if subtle.ConstantTimeCompare([]byte(authCode.Code), []byte(util.HashToken(code))) != 1 {
+		// 실패 횟수 증가, 최대 횟수에 도달하면 무효화
+		if err := service.db.Model(&model.AuthCode{}).Where("id = ? AND invalidated = false", authCode.Id).
+			Updates(map[string]interface{}{
+				"attempts":    gorm.Expr("attempts + 1"),
+				"invalidated": gorm.Expr("attempts + 1 >= ?", authCodeMaxAttempts),
+			}).Error; err != nil {
+			return util.NewError(util.ErrDatabase, "db error2")
+		}
+		if authCode.Attempts+1 >= authCodeMaxAttempts {
+			return util.NewError(util.ErrVerificationExpired, "auth code expired")
+		}
+		return util.NewError(util.ErrVerificationMismatch, "auth code mismatch")
+	}
 
+	// 인증번호는 한번만 사용
+	result := service.db.Model(&model.AuthCode{}).Where("id = ? AND invalidated = false", authCode.Id).Update("invalidated", true)
+	if result.Error != nil {
+		return util.NewError(util.ErrDatabase, "db error2")
+	}
+	if result.RowsAffected == 0 {
+		return util.NewError(util.ErrVerificationExpired, "auth code expired")
+	}
+	return nil
 }
 
-func (service *userService) AutoLogin(autoLoginRequest dto.AutoLoginRequest) (string, error) {
-	if autoLoginRequest.FcmToken == "" || autoLoginRequest.DeviceId == "" {
-		return "", errors.New("check fcm_token,device_id")
+// access 토큰이 아니라 refresh 토큰으로만 로그인 연장 (토큰은 RefreshToken 과 같이 회전)
+func (service *userService) AutoLogin(autoLoginRequest dto.AutoLoginRequest) (dto.LoginResponse, error) {
+	if autoLoginRequest.FcmToken == "" || autoLoginRequest.DeviceId == "" || autoLoginRequest.RefreshToken == "" {
+		return dto.LoginResponse{}, util.NewError(util.ErrInvalidRequest, "check fcm_token,device_id,refresh_token")
 	}
-	// 데이터베이스에서 사용자 조회
-	var u model.User
-	if err := service.db.Where("email = ?", autoLoginRequest.Email).First(&u).Error; err != nil {
-		return "", errors.New("db error")
+	session, u, err := service.findRefreshSession(autoLoginRequest.RefreshToken, autoLoginRequest.DeviceId)
+	if err != nil {
+		return dto.LoginResponse{}, err
 	}
 	if !u.UseAutoLogin {
-		return "", errors.New("not use auto login")
-	}
-	// 새로운 JWT 토큰 생성
-	tokenString, err := util.GenerateJWT(u)
-	if err != nil {
-		return "", err
+		return dto.LoginResponse{}, util.NewError(util.ErrForbidden, "not use auto login")
 	}
 
 	if err := service.db.Model(&u).Updates(model.User{FCMToken: autoLoginRequest.FcmToken, DeviceID: autoLoginRequest.DeviceId}).Error; err != nil {
-		return "", errors.New("db error2")
+		return dto.LoginResponse{}, util.NewError(util.ErrDatabase, "db error2")
+	}
+	if err := registerDevice(service.db, dto.DeviceRequest{Uid: u.Id, DeviceId: autoLoginRequest.DeviceId, FcmToken: autoLoginRequest.FcmToken,
+		Platform: autoLoginRequest.Platform, AppVersion: autoLoginRequest.AppVersion}); err != nil {
+		return dto.LoginResponse{}, err
 	}
-	return tokenString, nil
+
+	// 기존 세션 유지, 토큰만 회전
+	return service.rotateSession(session, u, autoLoginRequest.RefreshToken)
 }
 
-func (service *userService) SnsLogin(idToken string, userRequest dto.UserRequest) (string, error) {
+func (service *userService) SnsLogin(loginRequest dto.LoginRequest) (dto.LoginResponse, error) {
+	idToken, userRequest := loginRequest.IdToken, loginRequest.UserRequest
 	iss := util.DecodeJwt(idToken)
 
 	var user model.User
@@ -185,33 +289,33 @@ func (service *userService) SnsLogin(idToken string, userRequest dto.UserRequest
 	log.Println(userRequest.FCMToken)
 	if strings.Contains(iss, "kakao") { // 카카오
 		if user, err = KakaoLogin(idToken, userRequest); err != nil {
-			return "", err
+			return dto.LoginResponse{}, err
 		}
 	} else if strings.Contains(iss, "google") { // 구글
 		if user, err = GoogleLogin(idToken, userRequest); err != nil {
-			return "", err
+			return dto.LoginResponse{}, err
 		}
 	} else if strings.Contains(iss, "apple") { // 애플
 		if user, err = AppleLogin(idToken, userRequest); err != nil {
-			return "", err
+			return dto.LoginResponse{}, err
 		}
 
 	} //
 	u, err := findOrCreateUser(user, service)
 	if err != nil {
-		return "", err
+		return dto.LoginResponse{}, err
 	}
-
-	// JWT 토큰 생성
-	tokenString, err := util.GenerateJWT(u)
-	if err != nil {
-		return "", err
+	if err := registerDevice(service.db, dto.DeviceRequest{Uid: u.Id, DeviceId: userRequest.DeviceID, FcmToken: userRequest.FCMToken,
+		Platform: loginRequest.Platform, AppVersion: loginRequest.AppVersion}); err != nil {
+		return dto.LoginResponse{}, err
 	}
-	return tokenString, nil
+
+	// 기기별 세션 및 토큰 생성
+	return service.issueTokens(u, userRequest.DeviceID)
 }
 func AppleLogin(idToken string, userRequest dto.UserRequest) (model.User, error) {
 	if userRequest.FCMToken == "" || userRequest.DeviceID == "" {
-		return model.User{}, errors.New("check fcm_token,device_id")
+		return model.User{}, util.NewError(util.ErrInvalidRequest, "check fcm_token,device_id")
 	}
 	jwks, err := getApplePublicKeys()
 	if err != nil {
@@ -226,7 +330,7 @@ func AppleLogin(idToken string, userRequest dto.UserRequest) (model.User, error)
 	if claims, ok := parsedToken.Claims.(jwt.MapClaims); ok && parsedToken.Valid {
 		email, ok := claims["email"].(string)
 		if !ok {
-			return model.User{}, errors.New("email not found in token claims")
+			return model.User{}, util.NewError(util.ErrUnauthorized, "email not found in token claims")
 		}
 
 		var user model.User
@@ -243,12 +347,12 @@ func AppleLogin(idToken string, userRequest dto.UserRequest) (model.User, error)
 		return user, nil
 
 	}
-	return model.User{}, errors.New("invalid token")
+	return model.User{}, util.NewError(util.ErrUnauthorized, "invalid token")
 
 }
 func KakaoLogin(idToken string, userRequest dto.UserRequest) (model.User, error) {
 	if userRequest.FCMToken == "" || userRequest.DeviceID == "" {
-		return model.User{}, errors.New("check fcm_token,device_id")
+		return model.User{}, util.NewError(util.ErrInvalidRequest, "check fcm_token,device_id")
 	}
 	jwks, err := getKakaoPublicKeys()
 	if err != nil {
@@ -263,7 +367,7 @@ func KakaoLogin(idToken string, userRequest dto.UserRequest) (model.User, error)
 	if claims, ok := parsedToken.Claims.(jwt.MapClaims); ok && parsedToken.Valid {
 		email, ok := claims["email"].(string)
 		if !ok {
-			return model.User{}, errors.New("email not found in token claims")
+			return model.User{}, util.NewError(util.ErrUnauthorized, "email not found in token claims")
 		}
 
 		var user model.User
@@ -279,13 +383,13 @@ func KakaoLogin(idToken string, userRequest dto.UserRequest) (model.User, error)
 		user.Email = email
 		return user, nil
 	}
-	return model.User{}, errors.New("invalid token")
+	return model.User{}, util.NewError(util.ErrUnauthorized, "invalid token")
 
 }
 
 func GoogleLogin(idToken string, userRequest dto.UserRequest) (model.User, error) {
 	if userRequest.FCMToken == "" || userRequest.DeviceID == "" {
-		return model.User{}, errors.New("check fcm_token,device_id")
+		return model.User{}, util.NewError(util.ErrInvalidRequest, "check fcm_token,device_id")
 	}
 	email, err := validateGoogleIDToken(idToken)
 	if err != nil {
@@ -320,35 +424,35 @@ func findOrCreateUser(user model.User, service *userService) (model.User, error)
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			if err := service.db.Where("phone_number = ?", user.PhoneNum).First(&model.VerifiedNumbers{}).Error; err != nil {
 
-				return model.User{}, errors.New("-1") // 인증해야함
+				return model.User{}, util.NewError(util.ErrVerificationRequired, "phone number not verified") // 인증해야함
 			}
 			// 유효성 검사 수행
 			if err := util.ValidateDate(user.Birthday); err != nil {
-				return model.User{}, errors.New("-3")
+				return model.User{}, util.WrapError(util.ErrInvalidRequest, err)
 			}
 			if err := util.ValidatePhoneNumber(user.PhoneNum); err != nil {
-				return model.User{}, errors.New("-3")
+				return model.User{}, util.WrapError(util.ErrInvalidRequest, err)
 			}
 			if user.UserType > 2 {
-				return model.User{}, errors.New("-3")
+				return model.User{}, util.NewError(util.ErrInvalidRequest, "invalid user type")
 			}
 			err := service.db.Create(&user).Error
 			if err != nil {
-				return model.User{}, errors.New("-2") //이미 가입된 번호
+				return model.User{}, util.WrapError(util.ErrAlreadyRegistered, err) //이미 가입된 번호
 			}
 		} else if result.Error != nil {
-			return model.User{}, errors.New("db error2")
+			return model.User{}, util.NewError(util.ErrDatabase, "db error2")
 			// 있다면 해당 이메일의 uid로 조회
 		} else {
 			if err := service.db.Where("id = ?", linkedEmail.Uid).First(&user).Error; err != nil {
-				return model.User{}, errors.New("db error3")
+				return model.User{}, util.NewError(util.ErrDatabase, "db error3")
 			}
 		}
 
 	}
 
 	if err := service.db.Model(&user).Updates(model.User{FCMToken: fcmToken, DeviceID: deviceId}).Error; err != nil {
-		return model.User{}, errors.New("db error4")
+		return model.User{}, util.NewError(util.ErrDatabase, "db error4")
 	}
 
 	return user, nil
@@ -373,7 +477,7 @@ func (service *userService) LinkEmail(uid uint, idToken string) (string, error)
 		if claims, ok := parsedToken.Claims.(jwt.MapClaims); ok && parsedToken.Valid {
 			email, ok := claims["email"].(string)
 			if !ok {
-				return "", errors.New("email not found in token claims")
+				return "", util.NewError(util.ErrUnauthorized, "email not found in token claims")
 			}
 			if err := saveLinkedEmail(uid, email, service, uint(util.KakaoSnsType)); err != nil {
 				return "", err
@@ -403,14 +507,14 @@ func (service *userService) LinkEmail(uid uint, idToken string) (string, error)
 		if claims, ok := parsedToken.Claims.(jwt.MapClaims); ok && parsedToken.Valid {
 			email, ok := claims["email"].(string)
 			if !ok {
-				return "", errors.New("email not found in token claims")
+				return "", util.NewError(util.ErrUnauthorized, "email not found in token claims")
 			}
 			if err := saveLinkedEmail(uid, email, service, uint(util.AppleSnsType)); err != nil {
 				return "", err
 			}
 		}
 	} else {
-		return "", errors.New("invalid snsType")
+		return "", util.NewError(util.ErrInvalidRequest, "invalid snsType")
 	}
 	return "200", nil
 }
@@ -418,10 +522,10 @@ func (service *userService) LinkEmail(uid uint, idToken string) (string, error)
 func saveLinkedEmail(uid uint, email string, service *userService, snsType uint) error {
 	var user model.User
 	if err := service.db.Where("id = ? ", email).First(&user).Error; err != nil {
-		return errors.New("db error")
+		return util.NewError(util.ErrDatabase, "db error")
 	}
 	if user.Email == email {
-		return errors.New("wrong request")
+		return util.NewError(util.ErrInvalidRequest, "wrong request")
 	}
 
 	linkedEmail := model.LinkedEmail{Email: email, Uid: uid, SnsType: snsType}
@@ -432,14 +536,14 @@ func saveLinkedEmail(uid uint, email string, service *userService, snsType uint)
 		// 레코드가 존재하지 않으면 새 레코드 생성
 		err := service.db.Create(&linkedEmail).Error
 		if err != nil {
-			return errors.New("db error2")
+			return util.NewError(util.ErrDatabase, "db error2")
 		}
 	} else if result.Error != nil {
-		return errors.New("db error3")
+		return util.NewError(util.ErrDatabase, "db error3")
 	} else {
 		// 레코드가 존재하면 삭제
 		if err := service.db.Where(linkedEmail).Delete(&model.LinkedEmail{}).Error; err != nil {
-			return errors.New("db error4")
+			return util.NewError(util.ErrDatabase, "db error4")
 		}
 	}
 
@@ -460,8 +564,14 @@ func (service *userService) SetUser(userRequest dto.UserRequest) (string, error)
 		}
 	}
 
+	if userRequest.TimeZone != "" {
+		if err := util.ValidateTimeZone(userRequest.TimeZone); err != nil {
+			return "", err
+		}
+	}
+
 	if err := service.db.Where("phone_number = ?", userRequest.PhoneNum).First(&model.VerifiedNumbers{}).Error; err != nil {
-		return "", errors.New("-1")
+		return "", util.NewError(util.ErrVerificationRequired, "phone number not verified")
 	}
 
 	var fileName, thumbnailFileName string
@@ -482,7 +592,7 @@ func (service *userService) SetUser(userRequest dto.UserRequest) (string, error)
 	if userRequest.ProfileImage != "" {
 		imgData, err := base64.StdEncoding.DecodeString(userRequest.ProfileImage)
 		if err != nil {
-			return "", err
+			return "", util.NewError(util.ErrInvalidRequest, "invalid profile_image")
 		}
 
 		contentType, ext, err := getImageFormat(imgData)
@@ -546,6 +656,15 @@ func (service *userService) SetUser(userRequest dto.UserRequest) (string, error)
 		}
 	}
 
+	// 시간대 변경 여부 (알람 발송 시각 재계산용)
+	timeZoneChanged := false
+	if userRequest.TimeZone != "" {
+		var prev model.User
+		if err := service.db.Select("time_zone").Where("id = ?", userRequest.Id).First(&prev).Error; err == nil {
+			timeZoneChanged = prev.TimeZone != userRequest.TimeZone
+		}
+	}
+
 	//유저 정보 업데이트
 	result := service.db.Model(&model.User{}).Where("id = ?", userRequest.Id).Updates(updateFields)
 	if result.Error != nil {
@@ -560,7 +679,7 @@ func (service *userService) SetUser(userRequest dto.UserRequest) (string, error)
 			}()
 		}
 
-		return "", errors.New("db error")
+		return "", util.NewError(util.ErrDatabase, "db error")
 	}
 
 	if userRequest.ProfileImage != "" {
@@ -575,7 +694,7 @@ func (service *userService) SetUser(userRequest dto.UserRequest) (string, error)
 					deleteFromS3(thumbnailFileName, service.s3svc, service.bucket, service.bucketUrl)
 				}()
 			}
-			return "", errors.New("db error4")
+			return "", util.NewError(util.ErrDatabase, "db error4")
 		}
 		// 이미지 레코드 재 생성
 
@@ -588,7 +707,7 @@ func (service *userService) SetUser(userRequest dto.UserRequest) (string, error)
 					deleteFromS3(thumbnailFileName, service.s3svc, service.bucket, service.bucketUrl)
 				}()
 			}
-			return "", errors.New("db error5")
+			return "", util.NewError(util.ErrDatabase, "db error5")
 		}
 	}
 
@@ -605,7 +724,7 @@ func (service *userService) SetUser(userRequest dto.UserRequest) (string, error)
 				deleteFromS3(thumbnailFileName, service.s3svc, service.bucket, service.bucketUrl)
 			}()
 		}
-		return "", errors.New("db error3")
+		return "", util.NewError(util.ErrDatabase, "db error3")
 	}
 
 	//유저별 사용 서비스 삭제 후
@@ -620,7 +739,7 @@ func (service *userService) SetUser(userRequest dto.UserRequest) (string, error)
 				deleteFromS3(thumbnailFileName, service.s3svc, service.bucket, service.bucketUrl)
 			}()
 		}
-		return "", errors.New("db error6")
+		return "", util.NewError(util.ErrDatabase, "db error6")
 	}
 
 	//유저별 사용 서비스 생성
@@ -644,11 +763,19 @@ func (service *userService) SetUser(userRequest dto.UserRequest) (string, error)
 					deleteFromS3(thumbnailFileName, service.s3svc, service.bucket, service.bucketUrl)
 				}()
 			}
-			return "", errors.New("db error7")
+			return "", util.NewError(util.ErrDatabase, "db error7")
 		}
 
 	}
 	tx.Commit()
+
+	if timeZoneChanged {
+		// 알람의 다음 발송 시각을 fcm-service 가 새 시간대로 다시 계산하도록 표시
+		if err := service.db.Table("alarms").Where("uid = ? AND next_fire_at <> ''", userRequest.Id).
+			Update("next_fire_at", util.RecomputeFireAt).Error; err != nil {
+			log.Println("alarm time zone update error:", err)
+		}
+	}
 	return "200", nil
 }
 
@@ -657,13 +784,13 @@ func (service *userService) GetUser(id uint) (dto.UserResponse, error) {
 	result := service.db.Debug().Preload("ProfileImage", "level != ? AND type = ?", 10, util.UserProfileImageType).
 		Preload("LinkedEmails").First(&user, id)
 	if result.Error != nil {
-		return dto.UserResponse{}, errors.New("db error")
+		return dto.UserResponse{}, util.NewError(util.ErrDatabase, "db error")
 	}
 
 	var useServices []model.UserService
 	result = service.db.Debug().Where("uid = ?", id).Find(&useServices)
 	if result.Error != nil {
-		return dto.UserResponse{}, errors.New("db error2")
+		return dto.UserResponse{}, util.NewError(util.ErrDatabase, "db error2")
 	}
 
 	mainServices := make([]dto.MainServiceResponse, 0)
@@ -680,6 +807,11 @@ func (service *userService) GetUser(id uint) (dto.UserResponse, error) {
 	}
 
 	userResponse.UserServices = mainServices
+	roles, err := userRoles(service.db, user)
+	if err != nil {
+		return dto.UserResponse{}, util.NewError(util.ErrDatabase, "db error3")
+	}
+	userResponse.Roles = roles
 
 	if userResponse.ProfileImage.Url != "" {
 		urlkey := extractKeyFromUrl(userResponse.ProfileImage.Url, service.bucket, service.bucketUrl)
@@ -712,7 +844,7 @@ func (service *userService) GetMainServices() ([]dto.MainServiceResponse, error)
 	var services []model.MainService
 	result := service.db.Where("level != 10").Find(&services)
 	if result.Error != nil {
-		return nil, errors.New("db error")
+		return nil, util.NewError(util.ErrDatabase, "db error")
 	}
 	var serviceResposnes []dto.MainServiceResponse
 	if err := util.CopyStruct(services, &serviceResposnes); err != nil {
@@ -723,9 +855,21 @@ func (service *userService) GetMainServices() ([]dto.MainServiceResponse, error)
 }
 
 func (service *userService) RemoveUser(id uint) (string, error) {
-	if err := service.db.Delete(&model.User{Id: id}).Error; err != nil {
-		return "", errors.New("db error")
+	tx := service.db.Begin()
+	if err := tx.Delete(&model.User{Id: id}).Error; err != nil {
+		tx.Rollback()
+		return "", util.NewError(util.ErrDatabase, "db error")
 	}
+	// 탈퇴한 유저의 모든 세션 폐기
+	if err := revokeSessions(tx.Where("uid = ?", id)); err != nil {
+		tx.Rollback()
+		return "", util.NewError(util.ErrDatabase, "db error2")
+	}
+	if err := tx.Where("uid = ?", id).Delete(&model.Device{}).Error; err != nil {
+		tx.Rollback()
+		return "", util.NewError(util.ErrDatabase, "db error3")
+	}
+	tx.Commit()
 	return "200", nil
 }
 
@@ -733,7 +877,7 @@ func (service *userService) GetVersion() (dto.AppVersionResponse, error) {
 	var version model.AppVersion
 	result := service.db.Last(&version)
 	if result.Error != nil {
-		return dto.AppVersionResponse{}, errors.New("db error")
+		return dto.AppVersionResponse{}, util.NewError(util.ErrDatabase, "db error")
 	}
 	var versionResponse dto.AppVersionResponse
 	if err := util.CopyStruct(version, &versionResponse); err != nil {
@@ -745,7 +889,7 @@ func (service *userService) GetVersion() (dto.AppVersionResponse, error) {
 func (service *userService) GetPolices() ([]dto.PoliceResponse, error) {
 	var polices []model.Polices
 	if err := service.db.Where("is_last = true").Find(&polices).Error; err != nil {
-		return nil, errors.New("db error")
+		return nil, util.NewError(util.ErrDatabase, "db error")
 	}
 
 	var policeResponse []dto.PoliceResponse
@@ -761,7 +905,7 @@ func (service *userService) RemoveProfile(uid uint) (string, error) {
 	// 기존 이미지 레코드 논리삭제
 	result := service.db.Model(&model.Image{}).Where("parent_id = ? AND type =?", uid, util.UserProfileImageType).Select("level").Updates(map[string]interface{}{"level": 10})
 	if result.Error != nil {
-		return "", errors.New("db error2")
+		return "", util.NewError(util.ErrDatabase, "db error2")
 	}
 	return "200", nil
 }
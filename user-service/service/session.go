@@ -0,0 +1,226 @@
+// /user-service/service/session.go
+
+package service
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"log"
+	"time"
+	"user-service/dto"
+
+	"github.com/disterbia/wellkinson/common/model"
+	"github.com/disterbia/wellkinson/common/util"
+	"gorm.io/gorm"
+)
+
+const timeLayout = "2006-01-02 15:04:05"
+
+// 기기 id 를 보내지 않은 관리자 로그인용 기기 id - 로그인마다 새로 만들어 다른 브라우저의 세션을 폐기하지 않음
+func newAdminDeviceId() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "admin-" + hex.EncodeToString(b), nil
+}
+
+// 기기별 세션 생성 후 access/refresh 토큰 발급 (같은 기기의 기존 세션은 폐기)
+func (service *userService) issueTokens(u model.User, deviceId string) (dto.LoginResponse, error) {
+	if deviceId == "" {
+		return dto.LoginResponse{}, util.NewError(util.ErrInvalidRequest, "check device_id")
+	}
+	roles, err := userRoles(service.db, u)
+	if err != nil {
+		return dto.LoginResponse{}, util.NewError(util.ErrDatabase, "db error")
+	}
+	now := time.Now().In(model.ServerLocation)
+
+	tx := service.db.Begin()
+	if err := revokeSessions(tx.Where("uid = ? AND device_id = ?", u.Id, deviceId)); err != nil {
+		tx.Rollback()
+		return dto.LoginResponse{}, util.NewError(util.ErrDatabase, "db error")
+	}
+
+	session := model.Session{Uid: u.Id, DeviceID: deviceId, LastUsed: now.Format(timeLayout), ExpiresAt: now.Add(util.RefreshTokenTTL).Format(timeLayout)}
+	if err := tx.Create(&session).Error; err != nil {
+		tx.Rollback()
+		return dto.LoginResponse{}, util.NewError(util.ErrDatabase, "db error2")
+	}
+
+	refreshToken, hash, err := util.GenerateRefreshToken(session.Id)
+	if err != nil {
+		tx.Rollback()
+		return dto.LoginResponse{}, err
+	}
+	if err := tx.Model(&session).Update("refresh_token_hash", hash).Error; err != nil {
+		tx.Rollback()
+		return dto.LoginResponse{}, util.NewError(util.ErrDatabase, "db error3")
+	}
+
+	tokenString, err := util.GenerateJWT(u, roles, session.Id)
+	if err != nil {
+		tx.Rollback()
+		return dto.LoginResponse{}, err
+	}
+	tx.Commit()
+
+	return dto.LoginResponse{Jwt: tokenString, RefreshToken: refreshToken}, nil
+}
+
+// 조건에 맞는 활성 세션 폐기
+func revokeSessions(query *gorm.DB) error {
+	return query.Model(&model.Session{}).Where("revoked_at = ''").Update("revoked_at", time.Now().In(model.ServerLocation).Format(timeLayout)).Error
+}
+
+// refresh 토큰 회전: 사용된 토큰은 즉시 무효화되고 새 토큰 발급
+func (service *userService) RefreshToken(refreshToken, deviceId string) (dto.LoginResponse, error) {
+	session, u, err := service.findRefreshSession(refreshToken, deviceId)
+	if err != nil {
+		return dto.LoginResponse{}, err
+	}
+	return service.rotateSession(session, u, refreshToken)
+}
+
+// refresh 토큰의 세션과 유저 조회 - 재사용된 토큰이면 세션 폐기
+func (service *userService) findRefreshSession(refreshToken, deviceId string) (model.Session, model.User, error) {
+	sid, err := util.ParseRefreshToken(refreshToken)
+	if err != nil {
+		return model.Session{}, model.User{}, util.WrapError(util.ErrUnauthorized, err)
+	}
+
+	var session model.Session
+	if err := service.db.Where("id = ?", sid).First(&session).Error; err != nil {
+		return model.Session{}, model.User{}, util.NewError(util.ErrUnauthorized, "invalid refresh token")
+	}
+
+	if err := checkRefreshSession(session, util.HashToken(refreshToken), deviceId, time.Now().In(model.ServerLocation)); err != nil {
+		if errors.Is(err, errRefreshTokenReused) {
+			// 이미 회전된 토큰 재사용 -> 탈취로 보고 세션 폐기
+			log.Printf("refresh token reuse detected: session %d", session.Id)
+			if err := revokeSessions(service.db.Where("id = ?", session.Id)); err != nil {
+				log.Println(err)
+			}
+		}
+		return model.Session{}, model.User{}, err
+	}
+
+	var u model.User
+	if err := service.db.Where("id = ?", session.Uid).First(&u).Error; err != nil {
+		return model.Session{}, model.User{}, util.NewError(util.ErrDatabase, "db error")
+	}
+	return session, u, nil
+}
+
+var errRefreshTokenReused = util.NewError(util.ErrUnauthorized, "invalid refresh token")
+
+// 세션이 refresh 토큰으로 갱신 가능한지 확인
+func checkRefreshSession(session model.Session, hash, deviceId string, now time.Time) error {
+	if session.RevokedAt != "" {
+		return util.NewError(util.ErrUnauthorized, "revoked session")
+	}
+	if subtle.ConstantTimeCompare([]byte(session.RefreshTokenHash), []byte(hash)) != 1 {
+		return errRefreshTokenReused
+	}
+	if session.ExpiresAt < now.Format(timeLayout) {
+		return util.NewError(util.ErrUnauthorized, "expired refresh token")
+	}
+	if deviceId != "" && deviceId != session.DeviceID {
+		return util.NewError(util.ErrUnauthorized, "device mismatch")
+	}
+	return nil
+}
+
+// 세션의 refresh 토큰을 새 토큰으로 교체하고 access 토큰 발급
+func (service *userService) rotateSession(session model.Session, u model.User, refreshToken string) (dto.LoginResponse, error) {
+	roles, err := userRoles(service.db, u)
+	if err != nil {
+		return dto.LoginResponse{}, util.NewError(util.ErrDatabase, "db error")
+	}
+
+	newRefreshToken, newHash, err := util.GenerateRefreshToken(session.Id)
+	if err != nil {
+		return dto.LoginResponse{}, err
+	}
+
+	// 동시에 같은 토큰으로 요청이 들어와도 한 번만 회전되도록 이전 해시를 조건으로 갱신
+	now := time.Now().In(model.ServerLocation)
+	result := service.db.Model(&model.Session{}).Where("id = ? AND refresh_token_hash = ? AND revoked_at = ''", session.Id, util.HashToken(refreshToken)).
+		Updates(map[string]interface{}{"refresh_token_hash": newHash, "last_used": now.Format(timeLayout), "expires_at": now.Add(util.RefreshTokenTTL).Format(timeLayout)})
+	if result.Error != nil {
+		return dto.LoginResponse{}, util.NewError(util.ErrDatabase, "db error2")
+	}
+	if result.RowsAffected == 0 {
+		return dto.LoginResponse{}, util.NewError(util.ErrUnauthorized, "invalid refresh token")
+	}
+
+	// 활성 기기 판단용 마지막 접속 시각 갱신
+	if err := service.db.Model(&model.Device{}).Where("uid = ? AND device_id = ?", session.Uid, session.DeviceID).Update("last_seen", now.Format(timeLayout)).Error; err != nil {
+		log.Println(err)
+	}
+
+	tokenString, err := util.GenerateJWT(u, roles, session.Id)
+	if err != nil {
+		return dto.LoginResponse{}, err
+	}
+
+	return dto.LoginResponse{Jwt: tokenString, RefreshToken: newRefreshToken}, nil
+}
+
+func (service *userService) GetSessions(uid, currentSid uint) ([]dto.SessionResponse, error) {
+	var sessions []model.Session
+	if err := service.db.Where("uid = ? AND revoked_at = '' AND expires_at > ?", uid, time.Now().In(model.ServerLocation).Format(timeLayout)).Order("last_used DESC").Find(&sessions).Error; err != nil {
+		return nil, util.NewError(util.ErrDatabase, "db error")
+	}
+
+	var sessionResponses []dto.SessionResponse
+	for _, v := range sessions {
+		sessionResponses = append(sessionResponses, dto.SessionResponse{Id: v.Id, DeviceId: v.DeviceID, Current: v.Id == currentSid,
+			LastUsed: v.LastUsed, ExpiresAt: v.ExpiresAt, Created: v.Created})
+	}
+	return sessionResponses, nil
+}
+
+// 본인 세션 폐기 (로그아웃, 다른 기기 로그아웃)
+func (service *userService) RevokeSession(uid, sid uint) (string, error) {
+	if sid == 0 {
+		return "", util.NewError(util.ErrInvalidRequest, "check session id")
+	}
+	var session model.Session
+	if err := service.db.Where("id = ? AND uid = ?", sid, uid).First(&session).Error; err != nil {
+		return "", util.NewError(util.ErrNotFound, "session not found")
+	}
+	result := service.db.Model(&model.Session{}).Where("id = ? AND uid = ? AND revoked_at = ''", sid, uid).Update("revoked_at", time.Now().In(model.ServerLocation).Format(timeLayout))
+	if result.Error != nil {
+		return "", util.NewError(util.ErrDatabase, "db error")
+	}
+	if result.RowsAffected == 0 {
+		return "", util.NewError(util.ErrNotFound, "session not found")
+	}
+	// 로그아웃한 기기로는 푸시를 보내지 않음
+	if err := unregisterDevice(service.db, uid, session.DeviceID); err != nil {
+		return "", util.NewError(util.ErrDatabase, "db error2")
+	}
+	return "200", nil
+}
+
+// access 토큰 유효기간 내에 폐기된 세션 목록 (게이트웨이 폐기 목록 동기화용)
+func (service *userService) GetRevokedSessions() ([]uint, error) {
+	var ids []uint
+	since := time.Now().In(model.ServerLocation).Add(-util.AccessTokenTTL).Format(timeLayout)
+	if err := service.db.Model(&model.Session{}).Where("revoked_at <> '' AND revoked_at > ?", since).Pluck("id", &ids).Error; err != nil {
+		return nil, util.NewError(util.ErrDatabase, "db error")
+	}
+	return ids, nil
+}
+
+// 세션이 없거나 조회에 실패하면 폐기된 것으로 처리
+func (service *userService) IsSessionRevoked(sid uint) bool {
+	var session model.Session
+	if err := service.db.Select("revoked_at").Where("id = ?", sid).First(&session).Error; err != nil {
+		return true
+	}
+	return session.RevokedAt != ""
+}
@@ -0,0 +1,104 @@
+// /user-service/service/session_test.go
+
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/disterbia/wellkinson/common/model"
+	"github.com/disterbia/wellkinson/common/util"
+)
+
+func TestCheckRefreshSession(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	token, hash, err := util.GenerateRefreshToken(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rotated, _, err := util.GenerateRefreshToken(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	active := model.Session{Id: 7, DeviceID: "phone", RefreshTokenHash: hash, ExpiresAt: now.Add(time.Hour).Format(timeLayout)}
+
+	tests := []struct {
+		name     string
+		session  func(model.Session) model.Session
+		token    string
+		deviceId string
+		wantErr  string
+		reused   bool
+	}{
+		{name: "valid", session: func(s model.Session) model.Session { return s }, token: token, deviceId: "phone"},
+		{name: "device omitted", session: func(s model.Session) model.Session { return s }, token: token},
+		{name: "rotated token reused", session: func(s model.Session) model.Session { return s }, token: rotated, deviceId: "phone", wantErr: "invalid refresh token", reused: true},
+		{name: "revoked", session: func(s model.Session) model.Session { s.RevokedAt = now.Format(timeLayout); return s }, token: token, wantErr: "revoked session"},
+		{name: "expired", session: func(s model.Session) model.Session { s.ExpiresAt = now.Add(-time.Second).Format(timeLayout); return s }, token: token, wantErr: "expired refresh token"},
+		{name: "other device", session: func(s model.Session) model.Session { return s }, token: token, deviceId: "tablet", wantErr: "device mismatch"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkRefreshSession(tt.session(active), util.HashToken(tt.token), tt.deviceId, now)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			appErr := util.ToAppError(err)
+			if appErr.Code != util.ErrUnauthorized || appErr.Detail != tt.wantErr {
+				t.Fatalf("got %v, want %s: %s", err, util.ErrUnauthorized, tt.wantErr)
+			}
+			if errors.Is(err, errRefreshTokenReused) != tt.reused {
+				t.Fatalf("reuse detected = %v, want %v", !tt.reused, tt.reused)
+			}
+		})
+	}
+}
+
+func TestRefreshTokenRoundTrip(t *testing.T) {
+	token, hash, err := util.GenerateRefreshToken(42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash != util.HashToken(token) {
+		t.Fatal("stored hash does not match token")
+	}
+	sid, err := util.ParseRefreshToken(token)
+	if err != nil || sid != 42 {
+		t.Fatalf("ParseRefreshToken = %d, %v", sid, err)
+	}
+
+	next, nextHash, err := util.GenerateRefreshToken(42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next == token || nextHash == hash {
+		t.Fatal("rotation must produce a new token")
+	}
+
+	for _, invalid := range []string{"", "42", "42.", "0.abc", "x.abc"} {
+		if _, err := util.ParseRefreshToken(invalid); err == nil {
+			t.Errorf("ParseRefreshToken(%q) should fail", invalid)
+		}
+	}
+}
+
+// 기기 id 없이 로그인한 관리자 세션끼리 서로 폐기하지 않도록 로그인마다 다른 id
+func TestNewAdminDeviceId(t *testing.T) {
+	first, err := newAdminDeviceId()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := newAdminDeviceId()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first == second || !strings.HasPrefix(first, "admin-") {
+		t.Fatalf("newAdminDeviceId() = %q, %q", first, second)
+	}
+}
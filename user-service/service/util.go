@@ -7,7 +7,6 @@ import (
 	"crypto/rsa"
 	"encoding/base64"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"image"
 	"image/gif"
@@ -21,6 +20,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/dgrijalva/jwt-go"
+	"github.com/disterbia/wellkinson/common/util"
 	"github.com/google/uuid"
 	"github.com/nfnt/resize"
 	"google.golang.org/api/idtoken"
@@ -68,7 +68,7 @@ func verifyAppleIDToken(token string, jwks JWKS) (*jwt.Token, error) {
 	}
 
 	if key == nil {
-		return nil, errors.New("appropriate public key not found")
+		return nil, util.NewError(util.ErrUnauthorized, "appropriate public key not found")
 	}
 
 	parsedToken, err := jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
@@ -122,7 +122,7 @@ func verifyKakaoTokenSignature(token string, jwks JWKS) (*jwt.Token, error) {
 	}
 
 	if key == nil {
-		return nil, errors.New("appropriate public key not found")
+		return nil, util.NewError(util.ErrUnauthorized, "appropriate public key not found")
 	}
 
 	parsedToken, err := jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
@@ -138,7 +138,7 @@ func verifyKakaoTokenSignature(token string, jwks JWKS) (*jwt.Token, error) {
 func extractKidFromToken(token string) (string, error) {
 	parts := strings.Split(token, ".")
 	if len(parts) != 3 {
-		return "", errors.New("invalid token format")
+		return "", util.NewError(util.ErrUnauthorized, "invalid token format")
 	}
 	headerPart := parts[0]
 	headerJson, err := base64.RawURLEncoding.DecodeString(headerPart)
@@ -153,7 +153,7 @@ func extractKidFromToken(token string) (string, error) {
 
 	kid, ok := header["kid"].(string)
 	if !ok {
-		return "", errors.New("kid not found in token header")
+		return "", util.NewError(util.ErrUnauthorized, "kid not found in token header")
 	}
 	return kid, nil
 }
@@ -171,7 +171,7 @@ func validateGoogleIDToken(idToken string) (string, error) {
 	// 이메일 추출
 	email, ok := payload.Claims["email"].(string)
 	if !ok {
-		return "", errors.New("email claim not found in token")
+		return "", util.NewError(util.ErrUnauthorized, "email claim not found in token")
 	}
 
 	return email, nil
@@ -321,7 +321,7 @@ func createThumbnail(data []byte) ([]byte, error) {
 func getImageFormat(imgData []byte) (contentType, extension string, err error) {
 	_, format, err := image.DecodeConfig(bytes.NewReader(imgData))
 	if err != nil {
-		return "", "", err
+		return "", "", util.NewError(util.ErrInvalidRequest, "invalid image")
 	}
 	switch format {
 	case "jpeg":
@@ -337,7 +337,7 @@ func getImageFormat(imgData []byte) (contentType, extension string, err error) {
 		contentType = "image/wepb"
 		extension = ".wepb"
 	default:
-		return "", "", fmt.Errorf("unsupported image format: %s", format)
+		return "", "", util.NewError(util.ErrInvalidRequest, "unsupported image format: "+format)
 	}
 
 	return contentType, extension, nil
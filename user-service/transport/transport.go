@@ -3,41 +3,40 @@
 package transport
 
 import (
+	"errors"
 	"net/http"
-	"user-service/common/util"
+	"strconv"
 	"user-service/dto"
 
 	kitEndpoint "github.com/go-kit/kit/endpoint"
 
+	"github.com/disterbia/wellkinson/common/util"
 	"github.com/gin-gonic/gin"
 )
 
 // @Tags 로그인 /user
 // @Summary 관리자 로그인
-// @Description 관리자 로그인시 호출
+// @Description 관리자 로그인시 호출 - 5회 실패시 15분 잠금 (잠금 중에는 비밀번호가 맞아도 로그인 실패), TOTP 등록한 관리자는 otp_code 필요
 // @Accept  json
 // @Produce  json
-// @Param email body string true "email"
-// @Param password body string true "password"
+// @Param request body dto.AdminLoginRequest true "요청 DTO"
 // @Success 200 {object} dto.SuccessResponse "성공시 JWT 토큰 반환"
+// @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 로그인 실패, 계정 없음, 관리자 아님, OTP 불일치, 잠금은 모두 같은 응답 (detail: "password not set" = 비밀번호 변경 필요, 기존 방식 비밀번호가 맞을때만)"
 // @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
-// @Security jwt
 // @Router /admin-login [post]
 func AdminLoginHandler(loginEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		var requestData map[string]interface{}
-		if err := c.BindJSON(&requestData); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		var req dto.AdminLoginRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			util.AbortBadRequest(c, err)
 			return
 		}
-		email := requestData["email"].(string)
-		password := requestData["password"].(string)
-		response, err := loginEndpoint(c.Request.Context(), map[string]interface{}{
-			"email":    email,
-			"password": password,
-		})
+
+		req.Ip = c.ClientIP()
+		response, err := loginEndpoint(c.Request.Context(), req)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, err)
 			return
 		}
 
@@ -54,20 +53,23 @@ func AdminLoginHandler(loginEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 // @Param request body dto.LoginRequest true "요청 DTO - idToken 필수, user- user_type: 0:해당없음 1:파킨슨 환자 2:보호자 / 최초 로그인 이후 로그인시 fcm_token,device_id 만 필요함"
 // @Success 200 {object} dto.SuccessResponse "성공시 JWT 토큰 반환"
 // @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
-// @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환: 오류메시지 "-1" = 인증필요 , "-2" = 이미 가입한 번호"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - sns 토큰 검증 실패"
+// @Failure 403 {object} dto.ErrorResponse "VERIFICATION_REQUIRED - 번호 인증 필요"
+// @Failure 409 {object} dto.ErrorResponse "ALREADY_REGISTERED - 이미 가입한 번호"
+// @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
 // @Router /sns-login [post]
 func SnsLoginHandler(loginEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var req dto.LoginRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			util.AbortBadRequest(c, err)
 			return
 		}
 
 		response, err := loginEndpoint(c.Request.Context(), req)
 		resp := response.(dto.LoginResponse)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, err)
 			return
 		}
 
@@ -77,34 +79,27 @@ func SnsLoginHandler(loginEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 
 // @Tags 로그인 /user
 // @Summary 자동로그인
-// @Description 최초 로그인 이후 앱 실행시 호출
+// @Description 최초 로그인 이후 앱 실행시 호출 - 로그인때 받은 refresh 토큰 필요, 사용한 refresh 토큰은 폐기되고 새 refresh 토큰이 발급됨
 // @Accept  json
 // @Produce  json
-// @Param Authorization header string true "Bearer {jwt_token}"
-// @Param request body dto.AutoLoginRequest true "요청 DTO"
-// @Success 200 {object} dto.SuccessResponse "성공시 JWT 토큰 반환"
+// @Param request body dto.AutoLoginRequest true "요청 DTO - refresh_token, fcm_token, device_id 필수"
+// @Success 200 {object} dto.SuccessResponse "성공시 JWT 토큰, refresh 토큰 반환"
+// @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 만료, 폐기 또는 재사용된 refresh 토큰 - 재로그인 필요"
+// @Failure 403 {object} dto.ErrorResponse "FORBIDDEN - 자동로그인 사용 안함"
 // @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
-// @Security jwt
 // @Router /auto-login [post]
 func AutoLoginHandler(autoLoginEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// 토큰 검증 및 처리
-		_, email, err := util.VerifyJWT(c)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-			return
-		}
-
 		var req dto.AutoLoginRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			util.AbortBadRequest(c, err)
 			return
 		}
 
-		req.Email = email
 		response, err := autoLoginEndpoint(c.Request.Context(), req)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, err)
 			return
 		}
 
@@ -122,21 +117,23 @@ func AutoLoginHandler(autoLoginEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 // @Param request body dto.UserRequest true "요청 DTO - 업데이트 할 데이터/ ture:남성 user_Type- 0:해당없음 1:파킨슨 환자 2:보호자"
 // @Success 200 {object} dto.BasicResponse "성공시 200 반환"
 // @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
-// @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환: 오류메시지 "-1" = 번호인증 필요"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
+// @Failure 403 {object} dto.ErrorResponse "VERIFICATION_REQUIRED - 번호 인증 필요"
+// @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
 // @Router /set-user [post]
 func SetUserHandler(setUserEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// 토큰 검증 및 처리
 		id, _, err := util.VerifyJWT(c)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
 			return
 		}
 
 		var req dto.UserRequest
 
 		if err := c.ShouldBindJSON(&req); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			util.AbortBadRequest(c, err)
 			return
 		}
 
@@ -144,7 +141,7 @@ func SetUserHandler(setUserEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 
 		response, err := setUserEndpoint(c.Request.Context(), req)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, err)
 			return
 		}
 
@@ -161,6 +158,7 @@ func SetUserHandler(setUserEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 // @Param Authorization header string true "Bearer {jwt_token}"
 // @Success 200 {object} dto.UserResponse "성공시 유저 객체 반환/ ture:남성 user_Type- 0:해당없음 1:파킨슨 환자 2:보호자 sns_type- 0:카카오 1:구글 2:애플"
 // @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
 // @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
 // @Router /get-user [post]
 func GetUserHandler(getUserEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
@@ -168,13 +166,13 @@ func GetUserHandler(getUserEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 		// 토큰 검증 및 처리
 		id, _, err := util.VerifyJWT(c)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
 			return
 		}
 
 		response, err := getUserEndpoint(c.Request.Context(), id)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, err)
 			return
 		}
 
@@ -197,7 +195,7 @@ func GetMainServicesHandeler(getUserEndpoint kitEndpoint.Endpoint) gin.HandlerFu
 
 		response, err := getUserEndpoint(c.Request.Context(), nil)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, err)
 			return
 		}
 
@@ -208,12 +206,14 @@ func GetMainServicesHandeler(getUserEndpoint kitEndpoint.Endpoint) gin.HandlerFu
 
 // @Tags 인증번호 /user
 // @Summary 인증번호 발송
-// @Description 인증번호 발송시 호출
+// @Description 인증번호 발송시 호출 - 재발송은 1분 후, 번호당 시간당 5회, IP당 시간당 20회 까지 (이전 인증번호는 무효화)
 // @Accept  json
 // @Produce  json
 // @Param number path string true "휴대번호"
 // @Success 200 {object} dto.BasicResponse "성공시 200 반환"
 // @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 429 {object} dto.CooldownResponse "TOO_MANY_REQUESTS 발송 제한: retry_after 초 후 재시도 (detail: cooldown, number quota exceeded, ip quota exceeded)"
+// @Failure 409 {object} dto.ErrorResponse "ALREADY_REGISTERED - 이미 가입한 번호"
 // @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
 // @Router /send-code/{number} [post]
 func SendCodeHandler(sendEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
@@ -221,9 +221,19 @@ func SendCodeHandler(sendEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 
 		number := c.Param("number")
 
-		response, err := sendEndpoint(c.Request.Context(), number)
+		response, err := sendEndpoint(c.Request.Context(), map[string]interface{}{
+			"number": number,
+			"ip":     c.ClientIP(),
+		})
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			var cooldown *util.CooldownError
+			if errors.As(err, &cooldown) {
+				c.Header("Retry-After", strconv.Itoa(cooldown.RetryAfter))
+				c.AbortWithStatusJSON(http.StatusTooManyRequests, dto.CooldownResponse{Code: util.ErrTooManyRequests,
+					Message: util.ErrorMessage(util.ErrTooManyRequests, c.GetHeader("Accept-Language")), Detail: cooldown.Reason, RetryAfter: cooldown.RetryAfter})
+				return
+			}
+			util.AbortError(c, err)
 			return
 		}
 
@@ -234,12 +244,13 @@ func SendCodeHandler(sendEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 
 // @Tags 인증번호 /user
 // @Summary 번호 인증
-// @Description 인증번호 입력 후 호출
+// @Description 인증번호 입력 후 호출 (유효시간 3분, 5회 틀리면 인증번호 무효화)
 // @Accept  json
 // @Produce  json
 // @Param request body dto.VerifyRequest true "요청 DTO"
 // @Success 200 {object} dto.BasicResponse "성공시 200 반환"
-// @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환 (VERIFICATION_MISMATCH - 인증번호 불일치)"
+// @Failure 410 {object} dto.ErrorResponse "VERIFICATION_EXPIRED - 만료 또는 시도 횟수 초과 (재발송 필요)"
 // @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
 // @Router /verify-code [post]
 func VerifyHandler(verifyEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
@@ -248,13 +259,13 @@ func VerifyHandler(verifyEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 		var req dto.VerifyRequest
 
 		if err := c.ShouldBindJSON(&req); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			util.AbortBadRequest(c, err)
 			return
 		}
 
 		response, err := verifyEndpoint(c.Request.Context(), req)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, err)
 			return
 		}
 
@@ -271,6 +282,7 @@ func VerifyHandler(verifyEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 // @Param Authorization header string true "Bearer {jwt_token}"
 // @Success 200 {object} dto.BasicResponse "성공시 200 반환"
 // @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
 // @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
 // @Router /remvoe-user [post]
 func RemoveHandler(removeEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
@@ -278,13 +290,13 @@ func RemoveHandler(removeEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 		// 토큰 검증 및 처리
 		id, _, err := util.VerifyJWT(c)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
 			return
 		}
 
 		response, err := removeEndpoint(c.Request.Context(), id)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, err)
 			return
 		}
 
@@ -302,25 +314,26 @@ func RemoveHandler(removeEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 // @Param request body dto.LinkRequest true "요청 DTO"
 // @Success 200 {object} dto.BasicResponse "성공시 200 반환"
 // @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
 // @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
 // @Router /link-email [post]
 func LinkHandler(endPoint kitEndpoint.Endpoint) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		id, _, err := util.VerifyJWT(c)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
 			return
 		}
 		var req dto.LinkRequest
 
 		if err := c.ShouldBindJSON(&req); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			util.AbortBadRequest(c, err)
 			return
 		}
 		req.Id = id
 		response, err := endPoint(c.Request.Context(), req)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, err)
 			return
 		}
 
@@ -343,7 +356,7 @@ func GetVersionHandeler(getUserEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 
 		response, err := getUserEndpoint(c.Request.Context(), nil)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, err)
 			return
 		}
 
@@ -366,7 +379,7 @@ func GetPolicesHandeler(getUserEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 
 		response, err := getUserEndpoint(c.Request.Context(), nil)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, err)
 			return
 		}
 
@@ -382,6 +395,7 @@ func GetPolicesHandeler(getUserEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 // @Produce  json
 // @Param Authorization header string true "Bearer {jwt_token}"
 // @Success 200 {object} dto.BasicResponse "성공시 200 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
 // @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
 // @Security jwt
 // @Router /remove-profile [post]
@@ -390,13 +404,118 @@ func RemoveProfileHandler(removeEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 		// 토큰 검증 및 처리
 		id, _, err := util.VerifyJWT(c)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
 			return
 		}
 
 		response, err := removeEndpoint(c.Request.Context(), id)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			util.AbortError(c, err)
+			return
+		}
+
+		resp := response.(dto.BasicResponse)
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// @Tags 로그인 /user
+// @Summary 토큰 재발급
+// @Description access 토큰 만료시 호출 - 사용한 refresh 토큰은 폐기되고 새 refresh 토큰이 발급됨
+// @Accept  json
+// @Produce  json
+// @Param request body dto.RefreshRequest true "요청 DTO - refresh_token 필수"
+// @Success 200 {object} dto.SuccessResponse "성공시 JWT 토큰, refresh 토큰 반환"
+// @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "만료, 폐기 또는 재사용된 refresh 토큰 - 재로그인 필요"
+// @Router /refresh-token [post]
+func RefreshTokenHandler(refreshEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req dto.RefreshRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			util.AbortBadRequest(c, err)
+			return
+		}
+
+		response, err := refreshEndpoint(c.Request.Context(), req)
+		if err != nil {
+			util.AbortError(c, err)
+			return
+		}
+
+		resp := response.(dto.LoginResponse)
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// @Tags 세션 /user
+// @Summary 로그인 기기 목록 조회
+// @Description 로그인 되어있는 기기(세션) 목록 조회시 호출
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Bearer {jwt_token}"
+// @Success 200 {object} []dto.SessionResponse "세션 목록 - current: 현재 요청한 기기"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
+// @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Security jwt
+// @Router /get-sessions [get]
+func GetSessionsHandler(getSessionsEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// 토큰 검증 및 처리
+		uid, _, sid, err := util.VerifySession(c)
+		if err != nil {
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
+			return
+		}
+
+		response, err := getSessionsEndpoint(c.Request.Context(), map[string]interface{}{
+			"uid": uid,
+			"sid": sid,
+		})
+		if err != nil {
+			util.AbortError(c, err)
+			return
+		}
+
+		resp := response.([]dto.SessionResponse)
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// @Tags 세션 /user
+// @Summary 기기 로그아웃
+// @Description 다른 기기의 세션을 폐기할때 호출
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Bearer {jwt_token}"
+// @Param id path string ture "세션ID"
+// @Success 200 {object} dto.BasicResponse "성공시 200 반환"
+// @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
+// @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Security jwt
+// @Router /revoke-session/{id} [post]
+func RevokeSessionHandler(revokeEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// 토큰 검증 및 처리
+		uid, _, err := util.VerifyJWT(c)
+		if err != nil {
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
+			return
+		}
+		sessionId := c.Param("id")
+		sid, err := strconv.Atoi(sessionId)
+		if err != nil {
+			util.AbortBadRequest(c, err)
+			return
+		}
+
+		response, err := revokeEndpoint(c.Request.Context(), map[string]interface{}{
+			"uid": uid,
+			"sid": uint(sid),
+		})
+		if err != nil {
+			util.AbortError(c, err)
 			return
 		}
 
@@ -404,3 +523,678 @@ func RemoveProfileHandler(removeEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
 		c.JSON(http.StatusOK, resp)
 	}
 }
+
+// @Tags 세션 /user
+// @Summary 로그아웃
+// @Description 현재 기기의 세션을 폐기할때 호출
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Bearer {jwt_token}"
+// @Success 200 {object} dto.BasicResponse "성공시 200 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
+// @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Security jwt
+// @Router /logout [post]
+func LogoutHandler(revokeEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// 토큰 검증 및 처리
+		uid, _, sid, err := util.VerifySession(c)
+		if err != nil {
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
+			return
+		}
+
+		response, err := revokeEndpoint(c.Request.Context(), map[string]interface{}{
+			"uid": uid,
+			"sid": sid,
+		})
+		if err != nil {
+			util.AbortError(c, err)
+			return
+		}
+
+		resp := response.(dto.BasicResponse)
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// 게이트웨이 전용 - 최근 폐기된 세션 id 목록 (swagger 미노출)
+func GetRevokedSessionsHandler(getRevokedEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !util.IsGatewayRequest(c) {
+			util.AbortError(c, util.NewError(util.ErrForbidden, "forbidden"))
+			return
+		}
+
+		response, err := getRevokedEndpoint(c.Request.Context(), nil)
+		if err != nil {
+			util.AbortError(c, err)
+			return
+		}
+
+		resp := response.([]uint)
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// @Tags 공통 /user
+// @Summary 토큰 검증 공개키 조회
+// @Description 서비스들이 JWT 서명 검증에 사용하는 공개키(JWKS) - kid 로 키 선택
+// @Produce  json
+// @Success 200 {object} util.JWKS "공개키 목록"
+// @Router /.well-known/jwks.json [get]
+func GetJWKSHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Cache-Control", "public, max-age=300")
+		c.JSON(http.StatusOK, util.GetJWKS())
+	}
+}
+
+// @Tags 역할 관리 /user
+// @Summary 유저 역할 조회
+// @Description 유저의 역할 조회시 호출 (role:manage 권한 필요)
+// @Produce  json
+// @Param Authorization header string true "Bearer {jwt_token}"
+// @Param id path string true "유저ID"
+// @Success 200 {object} []string "역할 목록"
+// @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 403 {object} dto.ErrorResponse "권한 없음"
+// @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Security jwt
+// @Router /get-roles/{id} [get]
+func GetUserRolesHandler(getRolesEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userId := c.Param("id")
+		uid, err := strconv.Atoi(userId)
+		if err != nil {
+			util.AbortBadRequest(c, err)
+			return
+		}
+
+		response, err := getRolesEndpoint(c.Request.Context(), uint(uid))
+		if err != nil {
+			util.AbortError(c, err)
+			return
+		}
+
+		resp := response.([]string)
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// @Tags 역할 관리 /user
+// @Summary 역할 부여
+// @Description 유저에게 역할 부여시 호출 (role:manage 권한 필요) - 변경된 역할은 토큰 재발급 이후 적용
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Bearer {jwt_token}"
+// @Param request body dto.RoleRequest true "요청 DTO - role: patient, caregiver, clinician, content-admin, super-admin"
+// @Success 200 {object} dto.BasicResponse "성공시 200 반환"
+// @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 403 {object} dto.ErrorResponse "권한 없음"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
+// @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Security jwt
+// @Router /grant-role [post]
+func GrantRoleHandler(grantEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// 토큰 검증 및 처리
+		id, _, err := util.VerifyJWT(c)
+		if err != nil {
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
+			return
+		}
+
+		var req dto.RoleRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			util.AbortBadRequest(c, err)
+			return
+		}
+		req.GrantedBy = id
+
+		response, err := grantEndpoint(c.Request.Context(), req)
+		if err != nil {
+			util.AbortError(c, err)
+			return
+		}
+
+		resp := response.(dto.BasicResponse)
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// @Tags 역할 관리 /user
+// @Summary 역할 회수
+// @Description 유저의 역할 회수시 호출 (role:manage 권한 필요) - user_type 으로 부여된 기본 역할은 회수 불가, 회수 후 해당 유저의 모든 세션 로그아웃
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Bearer {jwt_token}"
+// @Param request body dto.RoleRequest true "요청 DTO"
+// @Success 200 {object} dto.BasicResponse "성공시 200 반환"
+// @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 403 {object} dto.ErrorResponse "권한 없음"
+// @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환: 오류메시지 "last super-admin" = 마지막 super-admin"
+// @Security jwt
+// @Router /revoke-role [post]
+func RevokeRoleHandler(revokeEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req dto.RoleRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			util.AbortBadRequest(c, err)
+			return
+		}
+
+		response, err := revokeEndpoint(c.Request.Context(), req)
+		if err != nil {
+			util.AbortError(c, err)
+			return
+		}
+
+		resp := response.(dto.BasicResponse)
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// @Tags 보호자 /user
+// @Summary 보호자 초대
+// @Description 환자가 보호자 초대 코드 발급시 호출 (72시간 유효) - 보호자는 X-Acting-For: 환자uid 헤더로 허용된 도메인 서비스 호출
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Bearer {jwt_token}"
+// @Param request body dto.CareInviteRequest true "요청 DTO - scopes 도메인: medicine, exercise, sleep, diet, emotion / 권한: read, write"
+// @Success 200 {object} dto.CareInviteResponse "초대 코드"
+// @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
+// @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Security jwt
+// @Router /invite-caregiver [post]
+func InviteCaregiverHandler(inviteEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// 토큰 검증 및 처리
+		id, _, err := util.VerifyJWT(c)
+		if err != nil {
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
+			return
+		}
+
+		var req dto.CareInviteRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			util.AbortBadRequest(c, err)
+			return
+		}
+		req.Uid = id
+
+		response, err := inviteEndpoint(c.Request.Context(), req)
+		if err != nil {
+			util.AbortError(c, err)
+			return
+		}
+
+		resp := response.(dto.CareInviteResponse)
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// @Tags 보호자 /user
+// @Summary 보호자 초대 수락
+// @Description 보호자가 환자에게 받은 초대 코드 입력시 호출
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Bearer {jwt_token}"
+// @Param request body dto.CareAcceptRequest true "요청 DTO"
+// @Success 200 {object} dto.BasicResponse "성공시 200 반환"
+// @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
+// @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Security jwt
+// @Router /accept-care [post]
+func AcceptCareHandler(acceptEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// 토큰 검증 및 처리
+		id, _, err := util.VerifyJWT(c)
+		if err != nil {
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
+			return
+		}
+
+		var req dto.CareAcceptRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			util.AbortBadRequest(c, err)
+			return
+		}
+		req.Uid = id
+
+		response, err := acceptEndpoint(c.Request.Context(), req)
+		if err != nil {
+			util.AbortError(c, err)
+			return
+		}
+
+		resp := response.(dto.BasicResponse)
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// @Tags 보호자 /user
+// @Summary 보호자 권한 변경
+// @Description 환자가 보호자의 도메인별 권한 변경시 호출
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Bearer {jwt_token}"
+// @Param request body dto.CareScopeRequest true "요청 DTO - id: 연결ID"
+// @Success 200 {object} dto.BasicResponse "성공시 200 반환"
+// @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
+// @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Security jwt
+// @Router /set-care-scopes [post]
+func SetCareScopesHandler(setScopesEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// 토큰 검증 및 처리
+		id, _, err := util.VerifyJWT(c)
+		if err != nil {
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
+			return
+		}
+
+		var req dto.CareScopeRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			util.AbortBadRequest(c, err)
+			return
+		}
+		req.Uid = id
+
+		response, err := setScopesEndpoint(c.Request.Context(), req)
+		if err != nil {
+			util.AbortError(c, err)
+			return
+		}
+
+		resp := response.(dto.BasicResponse)
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// @Tags 보호자 /user
+// @Summary 보호자 연결 해제
+// @Description 환자 또는 보호자가 연결(초대 포함) 해제시 호출
+// @Produce  json
+// @Param Authorization header string true "Bearer {jwt_token}"
+// @Param id path string true "연결ID"
+// @Success 200 {object} dto.BasicResponse "성공시 200 반환"
+// @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
+// @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Security jwt
+// @Router /remove-care/{id} [post]
+func RemoveCareHandler(removeEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// 토큰 검증 및 처리
+		uid, _, err := util.VerifyJWT(c)
+		if err != nil {
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
+			return
+		}
+		linkId := c.Param("id")
+		id, err := strconv.Atoi(linkId)
+		if err != nil {
+			util.AbortBadRequest(c, err)
+			return
+		}
+
+		response, err := removeEndpoint(c.Request.Context(), map[string]interface{}{
+			"uid": uid,
+			"id":  uint(id),
+		})
+		if err != nil {
+			util.AbortError(c, err)
+			return
+		}
+
+		resp := response.(dto.BasicResponse)
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// @Tags 보호자 /user
+// @Summary 보호자 연결 목록
+// @Description 내가 환자 또는 보호자인 연결 목록 조회시 호출
+// @Produce  json
+// @Param Authorization header string true "Bearer {jwt_token}"
+// @Success 200 {object} []dto.CareLinkResponse "연결 목록"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
+// @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Security jwt
+// @Router /get-cares [get]
+func GetCaresHandler(getCaresEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// 토큰 검증 및 처리
+		id, _, err := util.VerifyJWT(c)
+		if err != nil {
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
+			return
+		}
+
+		response, err := getCaresEndpoint(c.Request.Context(), id)
+		if err != nil {
+			util.AbortError(c, err)
+			return
+		}
+
+		resp := response.([]dto.CareLinkResponse)
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// @Tags 보호자 /user
+// @Summary 보호자 대리 기록 내역
+// @Description 보호자가 내 계정에 대신 기록한 내역 조회시 호출 (20개씩)
+// @Produce  json
+// @Param Authorization header string true "Bearer {jwt_token}"
+// @Param  page  query  uint  false  "페이지 번호 default 0"
+// @Success 200 {object} []dto.DelegatedWriteResponse "대리 기록 내역"
+// @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
+// @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Security jwt
+// @Router /get-care-audits [get]
+func GetCareAuditsHandler(getAuditsEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// 토큰 검증 및 처리
+		id, _, err := util.VerifyJWT(c)
+		if err != nil {
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
+			return
+		}
+		var queryParams dto.GetPageParams
+		if err := c.ShouldBindQuery(&queryParams); err != nil {
+			util.AbortBadRequest(c, err)
+			return
+		}
+
+		response, err := getAuditsEndpoint(c.Request.Context(), map[string]interface{}{
+			"uid":  id,
+			"page": queryParams.Page,
+		})
+		if err != nil {
+			util.AbortError(c, err)
+			return
+		}
+
+		resp := response.([]dto.DelegatedWriteResponse)
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// @Tags 관리자 인증 /user
+// @Summary 관리자 첫 비밀번호 설정 인증번호 발송
+// @Description 비밀번호 미설정 관리자가 첫 비밀번호를 설정하기 전에 호출 - password 에 기존 전화번호 입력, 맞으면 등록된 전화번호로 인증번호 발송
+// @Description 계정 여부, 전화번호 일치 여부와 관계없이 항상 200 반환
+// @Accept  json
+// @Produce  json
+// @Param request body dto.AdminCodeRequest true "요청 DTO"
+// @Success 200 {object} dto.BasicResponse "성공시 200 반환"
+// @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Router /admin-send-code [post]
+func SendAdminAuthCodeHandler(sendEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req dto.AdminCodeRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			util.AbortBadRequest(c, err)
+			return
+		}
+
+		req.Ip = c.ClientIP()
+		response, err := sendEndpoint(c.Request.Context(), req)
+		if err != nil {
+			util.AbortError(c, err)
+			return
+		}
+
+		resp := response.(dto.BasicResponse)
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// @Tags 관리자 인증 /user
+// @Summary 관리자 비밀번호 변경
+// @Description 관리자 비밀번호 변경시 호출 (10자 이상) - 변경 후 모든 세션 로그아웃
+// @Description 비밀번호 미설정 관리자는 password 에 기존 전화번호, auth_code 에 /admin-send-code 로 받은 인증번호 입력
+// @Accept  json
+// @Produce  json
+// @Param request body dto.AdminPasswordRequest true "요청 DTO"
+// @Success 200 {object} dto.BasicResponse "성공시 200 반환"
+// @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환 (VERIFICATION_MISMATCH - 인증번호 불일치)"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 인증 실패, 잠금 (로그인과 같은 응답)"
+// @Failure 403 {object} dto.ErrorResponse "VERIFICATION_REQUIRED - 비밀번호 미설정 관리자는 인증번호 필요"
+// @Failure 410 {object} dto.ErrorResponse "VERIFICATION_EXPIRED - 인증번호 만료, 시도 횟수 초과"
+// @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Router /admin-change-password [post]
+func ChangeAdminPasswordHandler(changeEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req dto.AdminPasswordRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			util.AbortBadRequest(c, err)
+			return
+		}
+
+		req.Ip = c.ClientIP()
+		response, err := changeEndpoint(c.Request.Context(), req)
+		if err != nil {
+			util.AbortError(c, err)
+			return
+		}
+
+		resp := response.(dto.BasicResponse)
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// @Tags 관리자 인증 /user
+// @Summary 관리자 비밀번호 초기화
+// @Description 관리자 비밀번호 초기화시 호출 (role:manage 권한 필요) - TOTP 도 해제됨
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Bearer {jwt_token}"
+// @Param request body dto.AdminResetRequest true "요청 DTO"
+// @Success 200 {object} dto.BasicResponse "성공시 200 반환"
+// @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 403 {object} dto.ErrorResponse "권한 없음"
+// @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Security jwt
+// @Router /admin-reset-password [post]
+func ResetAdminPasswordHandler(resetEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req dto.AdminResetRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			util.AbortBadRequest(c, err)
+			return
+		}
+
+		response, err := resetEndpoint(c.Request.Context(), req)
+		if err != nil {
+			util.AbortError(c, err)
+			return
+		}
+
+		resp := response.(dto.BasicResponse)
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// @Tags 관리자 인증 /user
+// @Summary TOTP 등록
+// @Description 관리자 2단계 인증 등록 시작시 호출 - 인증 앱에 등록 후 /admin-totp-confirm 으로 확인
+// @Produce  json
+// @Param Authorization header string true "Bearer {jwt_token}"
+// @Success 200 {object} dto.TotpEnrollResponse "TOTP 비밀키, otpauth URL"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
+// @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Security jwt
+// @Router /admin-totp-enroll [post]
+func EnrollTOTPHandler(enrollEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// 토큰 검증 및 처리
+		id, _, err := util.VerifyJWT(c)
+		if err != nil {
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
+			return
+		}
+
+		response, err := enrollEndpoint(c.Request.Context(), id)
+		if err != nil {
+			util.AbortError(c, err)
+			return
+		}
+
+		resp := response.(dto.TotpEnrollResponse)
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// @Tags 관리자 인증 /user
+// @Summary TOTP 확인
+// @Description 인증 앱의 코드로 2단계 인증 활성화시 호출
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Bearer {jwt_token}"
+// @Param request body dto.TotpConfirmRequest true "요청 DTO"
+// @Success 200 {object} dto.BasicResponse "성공시 200 반환"
+// @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
+// @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Security jwt
+// @Router /admin-totp-confirm [post]
+func ConfirmTOTPHandler(confirmEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// 토큰 검증 및 처리
+		id, _, err := util.VerifyJWT(c)
+		if err != nil {
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
+			return
+		}
+
+		var req dto.TotpConfirmRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			util.AbortBadRequest(c, err)
+			return
+		}
+
+		response, err := confirmEndpoint(c.Request.Context(), map[string]interface{}{
+			"uid":  id,
+			"code": req.Code,
+		})
+		if err != nil {
+			util.AbortError(c, err)
+			return
+		}
+
+		resp := response.(dto.BasicResponse)
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// @Tags 관리자 인증 /user
+// @Summary 관리자 로그인 기록
+// @Description 관리자 로그인 시도 기록 조회시 호출 (role:manage 권한 필요, 20개씩)
+// @Produce  json
+// @Param Authorization header string true "Bearer {jwt_token}"
+// @Param  page  query  uint  false  "페이지 번호 default 0"
+// @Success 200 {object} []dto.AdminLoginAuditResponse "로그인 기록"
+// @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 403 {object} dto.ErrorResponse "권한 없음"
+// @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Security jwt
+// @Router /admin-login-audits [get]
+func GetAdminLoginAuditsHandler(getAuditsEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var queryParams dto.GetPageParams
+		if err := c.ShouldBindQuery(&queryParams); err != nil {
+			util.AbortBadRequest(c, err)
+			return
+		}
+
+		response, err := getAuditsEndpoint(c.Request.Context(), queryParams.Page)
+		if err != nil {
+			util.AbortError(c, err)
+			return
+		}
+
+		resp := response.([]dto.AdminLoginAuditResponse)
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// @Tags 세션 /user
+// @Summary 푸시 기기 등록
+// @Description 앱 실행중 fcm 토큰이 갱신되었을때 호출
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Bearer {jwt_token}"
+// @Param request body dto.DeviceRequest true "요청 DTO - fcm_token,device_id 필수"
+// @Success 200 {object} dto.BasicResponse "성공시 200 반환"
+// @Failure 400 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
+// @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Security jwt
+// @Router /register-device [post]
+func RegisterDeviceHandler(registerDeviceEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// 토큰 검증 및 처리
+		uid, _, err := util.VerifyJWT(c)
+		if err != nil {
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
+			return
+		}
+
+		var req dto.DeviceRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			util.AbortBadRequest(c, err)
+			return
+		}
+		req.Uid = uid
+
+		response, err := registerDeviceEndpoint(c.Request.Context(), req)
+		if err != nil {
+			util.AbortError(c, err)
+			return
+		}
+
+		resp := response.(dto.BasicResponse)
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// @Tags 세션 /user
+// @Summary 푸시 기기 목록 조회
+// @Description 푸시를 받는 기기 목록 조회시 호출
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Bearer {jwt_token}"
+// @Success 200 {object} []dto.DeviceResponse "기기 목록 - current: 현재 요청한 기기"
+// @Failure 401 {object} dto.ErrorResponse "UNAUTHORIZED - 토큰이 없거나 유효하지 않음"
+// @Failure 500 {object} dto.ErrorResponse "요청 처리 실패시 오류 메시지 반환"
+// @Security jwt
+// @Router /get-devices [get]
+func GetDevicesHandler(getDevicesEndpoint kitEndpoint.Endpoint) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// 토큰 검증 및 처리
+		uid, _, sid, err := util.VerifySession(c)
+		if err != nil {
+			util.AbortError(c, util.WrapError(util.ErrUnauthorized, err))
+			return
+		}
+
+		response, err := getDevicesEndpoint(c.Request.Context(), map[string]interface{}{
+			"uid": uid,
+			"sid": sid,
+		})
+		if err != nil {
+			util.AbortError(c, err)
+			return
+		}
+
+		resp := response.([]dto.DeviceResponse)
+		c.JSON(http.StatusOK, resp)
+	}
+}
@@ -2,11 +2,10 @@
 package service
 
 import (
-	"errors"
-	"vocal-service/common/model"
-	"vocal-service/common/util"
 	"vocal-service/dto"
 
+	"github.com/disterbia/wellkinson/common/model"
+	"github.com/disterbia/wellkinson/common/util"
 	"gorm.io/gorm"
 )
 
@@ -29,7 +28,7 @@ func (service *vocalService) GetVoiceTables() ([]dto.VoiceWordResponse, error) {
 
 	err := service.db.Find(&voiceWords).Error
 	if err != nil {
-		return nil, errors.New("db error")
+		return nil, util.NewError(util.ErrDatabase, "db error")
 	}
 	if err := util.CopyStruct(voiceWords, &voiceResponses); err != nil {
 		return nil, err
@@ -55,7 +54,7 @@ func (service *vocalService) GetVocalScores(id uint, startDate, endDate string)
 
 	err := query.Find(&vocalScores).Error
 	if err != nil {
-		return nil, errors.New("db error")
+		return nil, util.NewError(util.ErrDatabase, "db error")
 	}
 
 	if err := util.CopyStruct(vocalScores, &vocalScoreResponse); err != nil {